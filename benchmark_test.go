@@ -2,7 +2,9 @@ package main
 
 import (
 	"testing"
+	"todo_cli/internal/journal"
 	"todo_cli/internal/manager"
+	"todo_cli/internal/render"
 	"todo_cli/internal/task"
 )
 
@@ -23,11 +25,33 @@ func (m *MockStorage) Clear(fileName *string) error {
 	return nil
 }
 
+func (m *MockStorage) Lock(fileName *string) (func() error, error) {
+	return func() error { return nil }, nil
+}
+
 type MockRender struct{}
 
-func (r *MockRender) RenderList(tasks []*task.Task)         {}
-func (r *MockRender) RenderMap(data map[string]interface{}) {}
-func (r *MockRender) RenderDetailed(tasks *task.Task)       {}
+func (r *MockRender) RenderList(tasks []*task.Task)                               {}
+func (r *MockRender) RenderStats(stats render.StatusStats)                        {}
+func (r *MockRender) RenderVersion(info render.VersionInfo)                       {}
+func (r *MockRender) RenderDetailed(tasks *task.Task)                             {}
+func (r *MockRender) RenderWithSubtasks(parent *task.Task, subtasks []*task.Task) {}
+func (r *MockRender) RenderGraph(graph render.Graph, format string)               {}
+func (r *MockRender) RenderRecurring(series []render.Series)                      {}
+func (r *MockRender) RenderTree(nodes []render.TaskNode)                          {}
+func (r *MockRender) RenderTagStats(stats []render.TagStats)                      {}
+func (r *MockRender) RenderLists(lists []render.ListInfo)                         {}
+func (r *MockRender) RenderFlowStats(stats render.FlowStats)                      {}
+func (r *MockRender) RenderActivity(activity render.Activity)                     {}
+func (r *MockRender) RenderTimeLog(log render.TimeLog)                            {}
+func (r *MockRender) RenderTimeReport(report render.TimeReport)                   {}
+func (r *MockRender) RenderPomodoro(progress render.PomodoroProgress)             {}
+func (r *MockRender) RenderEffortStats(stats render.EffortStats)                  {}
+func (r *MockRender) RenderHistory(entries []journal.Entry)                       {}
+func (r *MockRender) RenderImport(report render.ImportReport)                     {}
+func (r *MockRender) RenderMessage(message string)                                {}
+func (r *MockRender) RenderWarning(err error)                                     {}
+func (r *MockRender) RenderError(err error)                                       {}
 
 func BenchmarkCreateTasks(b *testing.B) {
 	store := &MockStorage{tasks: []*task.Task{}}
@@ -76,7 +100,7 @@ func BenchmarkStartTasks(b *testing.B) {
 	id, _ := mgr.Create(testData)
 	b.ResetTimer()
 	for b.Loop() {
-		mgr.Start(*id)
+		mgr.Start(*id, false)
 	}
 }
 
@@ -92,7 +116,7 @@ func BenchmarkCompletetTasks(b *testing.B) {
 	id, _ := mgr.Create(testData)
 	b.ResetTimer()
 	for b.Loop() {
-		mgr.Complete(*id)
+		mgr.Complete(*id, false)
 	}
 }
 
@@ -108,7 +132,7 @@ func BenchmarkDeletetTasks(b *testing.B) {
 	id, _ := mgr.Create(testData)
 	b.ResetTimer()
 	for b.Loop() {
-		mgr.Delete(*id)
+		mgr.Delete(*id, "")
 	}
 }
 
@@ -140,7 +164,7 @@ func BenchmarkListTasks(b *testing.B) {
 	mgr.Create(testData)
 	b.ResetTimer()
 	for b.Loop() {
-		mgr.List("all")
+		mgr.List(manager.ListOptions{Status: "all"})
 	}
 }
 
@@ -172,6 +196,6 @@ func BenchmarkStatsTasks(b *testing.B) {
 	mgr.Create(testData)
 	b.ResetTimer()
 	for b.Loop() {
-		mgr.Stats()
+		mgr.Stats("")
 	}
 }
@@ -2,10 +2,21 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	addPriority string
+	addDue      string
+	addTags     []string
+	addParent   int
+	addEvery    string
+	addEstimate string
+)
+
 var addCmd = &cobra.Command{
 	Use:   "add [заголовок] [описание]",
 	Short: "Создание новой задачи",
@@ -13,19 +24,37 @@ var addCmd = &cobra.Command{
 
 Заголовок является обязательным аргументом, описание — опциональным.
 После создания задачу можно будет отредактировать командой edit.
+Приоритет задаётся флагом --priority: low, medium, high, critical.
+Срок задаётся флагом --due: дата (2025-12-31, 31.12.2025) или фраза
+(today, tomorrow, fri, next fri, +3d, +2w, next week, next month).
+Оценка трудоёмкости задаётся флагом --estimate: время (2h, 90m, 1h30m) или story points (3pt).
+Теги задаются словами вида +tag в заголовке или флагом --tag (можно повторять).
+Флаг --parent создаёт подзадачу указанной задачи.
+Флаг --every делает задачу повторяющейся: после выполнения командой todo complete
+создаётся следующая задача серии со сдвинутым сроком. Правило повтора:
+day, week, month, year, интервал (2weeks, 3days, 6months), weekday (по будням),
+день недели (mon..sun) или iCalendar RRULE с ключами FREQ, INTERVAL, BYDAY
+и BYMONTHDAY (FREQ=MONTHLY;BYMONTHDAY=-1 - последний день месяца).
 
 Примеры:
   todo add "Купить продукты"
   todo add "Написать отчёт" "Подготовить отчёт для руководства"
+  todo add "Починить прод" --priority critical
+  todo add "Сдать отчёт" --due fri
+  todo add "Перевести API на v2" --estimate 4h
+  todo add "Починить логин +auth +backend"
+  todo add "Обновить зависимости" --tag infra --tag backend
+  todo add --parent 12 "Написать тесты"
+  todo add "Недельный отчёт" --every week --due fri
+  todo add "Выставить счёт" --every "FREQ=MONTHLY;BYMONTHDAY=-1"
 `,
 	Args: cobra.MatchAll(
 		cobra.MinimumNArgs(1),
 		cobra.MaximumNArgs(2),
 	),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args[0]) == 0 {
-			fmt.Print("укажите корректные данные для заголовка или описания задачи\n")
-			return
+			return fmt.Errorf("%w: укажите корректные данные для заголовка или описания задачи", ErrUsage)
 		}
 		title := args[0]
 		description := ""
@@ -38,16 +67,41 @@ var addCmd = &cobra.Command{
 			"title":       title,
 			"description": description,
 		}
+		if addPriority != "" {
+			data["priority"] = addPriority
+		}
+		if addDue != "" {
+			data["due"] = addDue
+		}
+		if addEstimate != "" {
+			data["estimate"] = addEstimate
+		}
+		if len(addTags) > 0 {
+			data["tags"] = strings.Join(addTags, ",")
+		}
+		if addEvery != "" {
+			data["every"] = addEvery
+		}
+		if cmd.Flags().Changed("parent") {
+			data["parent"] = strconv.Itoa(addParent)
+		}
 
 		idTask, err := mgr.Create(data)
 		if err != nil {
-			fmt.Printf("%v\n", err)
-			return
+			return err
 		}
-		fmt.Printf("Задача #%d добавлена успешно\n", *idTask)
+		out.RenderMessage(fmt.Sprintf("Задача #%d добавлена успешно", *idTask))
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(addCmd)
+
+	addCmd.Flags().StringVarP(&addPriority, "priority", "p", "", "Приоритет задачи: low, medium, high, critical")
+	addCmd.Flags().StringVar(&addDue, "due", "", "Срок выполнения: дата или фраза (tomorrow, fri, +3d, next month)")
+	addCmd.Flags().StringVar(&addEstimate, "estimate", "", "Оценка трудоёмкости: время (2h, 90m) или story points (3pt)")
+	addCmd.Flags().StringArrayVar(&addTags, "tag", nil, "Тег задачи (можно указать несколько раз)")
+	addCmd.Flags().IntVar(&addParent, "parent", 0, "ID родительской задачи")
+	addCmd.Flags().StringVar(&addEvery, "every", "", "Правило повтора: day, week, 2weeks, month, weekday или RRULE")
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var blockBy []string
+
+var blockCmd = &cobra.Command{
+	Use:   "block [ID задачи] --by [ID]",
+	Short: "Добавить задаче зависимость от других задач",
+	Long: `Отмечает, что задачу нельзя начать, пока не выполнены задачи из флага --by.
+
+Команда todo start откажется начинать такую задачу (обойти проверку можно флагом --force).
+Флаг --by можно повторять или перечислить ID через запятую.
+Зависимость, которая замкнёт цепочку ожидания в цикл, не добавляется.
+
+Примеры:
+  todo block 5 --by 3
+  todo block 5 --by 3,4
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idTask, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+		if len(blockBy) == 0 {
+			return fmt.Errorf("%w: укажите блокирующие задачи флагом --by", ErrUsage)
+		}
+		by, err := parseIDs(blockBy)
+		if err != nil {
+			return err
+		}
+		err = mgr.Block(idTask, by)
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Задача #%d ждёт завершения других задач", idTask))
+		return nil
+	},
+}
+
+var unblockCmd = &cobra.Command{
+	Use:   "unblock [ID задачи]",
+	Short: "Снять с задачи зависимости от других задач",
+	Long: `Снимает с задачи зависимости от задач из флага --by, без флага - все зависимости.
+
+Примеры:
+  todo unblock 5 --by 3
+  todo unblock 5
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idTask, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+		by, err := parseIDs(blockBy)
+		if err != nil {
+			return err
+		}
+		err = mgr.Unblock(idTask, by)
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("С задачи #%d сняты зависимости", idTask))
+		return nil
+	},
+}
+
+// parseIDs преобразует значения флага в ID задач (см. parseID).
+func parseIDs(values []string) ([]int, error) {
+	ids := make([]int, 0, len(values))
+	for _, value := range values {
+		id, err := parseID(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func init() {
+	blockCmd.Flags().StringSliceVar(&blockBy, "by", nil, "ID задач, которые нужно выполнить раньше (можно повторять)")
+	unblockCmd.Flags().StringSliceVar(&blockBy, "by", nil, "ID задач, зависимость от которых нужно снять")
+	rootCmd.AddCommand(blockCmd)
+	rootCmd.AddCommand(unblockCmd)
+}
@@ -2,11 +2,12 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/spf13/cobra"
 )
 
+var completeCascade bool
+
 var completeCmd = &cobra.Command{
 	Use:   "complete [ID задачи]",
 	Short: "Отметить задачу как выполненную (установить статус 'completed')",
@@ -14,30 +15,38 @@ var completeCmd = &cobra.Command{
 
 Используйте эту команду, когда задача полностью завершена.
 Для выполнения команды необходимо передать ID задачи.
+Учёт времени по задаче (см. todo start) останавливается.
+Задачу с невыполненными подзадачами можно завершить только с флагом --cascade:
+тогда сначала завершаются все её подзадачи.
+Для повторяющейся задачи (см. todo add --every) создаётся следующая задача серии
+со сдвинутым сроком, пропущенные повторы не создаются.
+Команда todo undo отменяет все эти изменения разом.
 
 Примеры:
   todo complete 7
+  todo complete 12 --cascade
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
 
-		if len(args) < 1 {
-			fmt.Print("не передан ID задачи\n")
-			return
-		}
-		idTask, err := strconv.Atoi(args[0])
+		idTask, err := parseID(args[0])
 		if err != nil {
-			fmt.Printf("не верное значение для ID задачи: %v\n", args[0])
-			return
+			return err
 		}
-		err = mgr.Complete(idTask)
+		next, err := mgr.Complete(idTask, completeCascade)
 		if err != nil {
-			fmt.Printf("%v\n", err)
-			return
+			return err
 		}
-		fmt.Printf("Задача #%d завершена\n", idTask)
+		out.RenderMessage(fmt.Sprintf("Задача #%d завершена", idTask))
+		if next != nil {
+			out.RenderMessage(fmt.Sprintf("Создана следующая задача серии #%d", *next))
+		}
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(completeCmd)
+
+	completeCmd.Flags().BoolVar(&completeCascade, "cascade", false, "Завершить задачу вместе со всеми подзадачами")
 }
@@ -2,41 +2,60 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
+	"todo_cli/internal/manager"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	deleteCascade bool
+	deleteDetach  bool
+)
+
 var deleteCmd = &cobra.Command{
 	Use:   "delete [ID задачи]",
-	Short: "Удаление задачи по её ID",
-	Long: `Полностью удаляет задачу из списка.
+	Short: "Удаление задачи в корзину по её ID",
+	Long: `Перемещает задачу в корзину: она перестаёт выводиться в списке, поиске и статистике.
 
-Внимание: операция необратима! Удалённую задачу невозможно восстановить.
+Вернуть задачу можно командой todo restore или отменить удаление командой todo undo.
+Окончательно удалить задачи из корзины можно командой todo trash empty.
 Для удаления необходимо передать ID задачи.
+Задачу с подзадачами можно удалить только с одним из флагов: --cascade перемещает
+в корзину и все подзадачи, --detach делает подзадачи задачами верхнего уровня.
 
 Примеры:
   todo delete 8
+  todo delete 12 --cascade
+  todo delete 12 --detach
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) < 1 {
-			fmt.Print("не передан ID задачи\n")
-			return
-		}
-		idTask, err := strconv.Atoi(args[0])
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idTask, err := parseID(args[0])
 		if err != nil {
-			fmt.Printf("не верное значение для ID задачи: %v\n", args[0])
-			return
+			return err
+		}
+		if deleteCascade && deleteDetach {
+			return fmt.Errorf("%w: флаги --cascade и --detach нельзя использовать вместе", ErrUsage)
 		}
-		err = mgr.Delete(idTask)
+		subtasks := ""
+		if deleteCascade {
+			subtasks = manager.SubtasksCascade
+		}
+		if deleteDetach {
+			subtasks = manager.SubtasksDetach
+		}
+		err = mgr.Delete(idTask, subtasks)
 		if err != nil {
-			fmt.Printf("%v\n", err)
-			return
+			return err
 		}
-		fmt.Printf("задача с #%d успешно удалена\n", idTask)
+		out.RenderMessage(fmt.Sprintf("задача с #%d перемещена в корзину", idTask))
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(deleteCmd)
+
+	deleteCmd.Flags().BoolVar(&deleteCascade, "cascade", false, "Удалить задачу вместе со всеми подзадачами")
+	deleteCmd.Flags().BoolVar(&deleteDetach, "detach", false, "Сделать подзадачи задачами верхнего уровня")
 }
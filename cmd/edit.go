@@ -2,13 +2,19 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var (
 	title, description string
+	editPriority       string
+	editDue            string
+	editTags           []string
+	editUntags         []string
+	editEvery          string
+	editEstimate       string
 )
 
 var editCmd = &cobra.Command{
@@ -16,39 +22,59 @@ var editCmd = &cobra.Command{
 	Short: "Редактирование заголовка или описания задачи",
 	Long: `Изменяет заголовок и/или описание существующей задачи.
 
-Необходимо указать ID задачи и хотя бы один из флагов: --title или --description.
-Можно изменить оба поля одновременно.
+Необходимо указать ID задачи и хотя бы один из флагов: --title, --description, --priority, --due,
+--estimate, --tag, --untag или --every. Можно изменить несколько полей одновременно.
+Значение --due none снимает срок с задачи, --estimate none - оценку, --tag добавляет тег, --untag удаляет.
+Флаг --every задаёт правило повтора (см. todo add --help), --every none снимает повтор.
 
 Примеры:
   todo edit 14 --title "Купить книгу по архитектуре облачных приложений"
   todo edit 5 --description "Новое описание задачи"
   todo edit 7 -t "Новый заголовок" -d "Новое описание"
+  todo edit 3 --priority high
+  todo edit 3 --due "next month"
+  todo edit 3 --estimate 3pt
+  todo edit 3 --tag backend --untag frontend
+  todo edit 3 --every month
 `,
 	Args: cobra.MatchAll(
 		cobra.MinimumNArgs(1),
 	),
-	Run: func(cmd *cobra.Command, args []string) {
-		if title == "" && description == "" {
-			fmt.Print("укажите значение для изменения заголовка или описания задачи\n")
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if title == "" && description == "" && editPriority == "" && editDue == "" &&
+			len(editTags) == 0 && len(editUntags) == 0 && editEvery == "" && editEstimate == "" {
+			return fmt.Errorf("%w: укажите значение для изменения заголовка, описания, приоритета, срока, оценки, тегов или повтора задачи", ErrUsage)
 		}
-		data := make(map[string]string, 2)
+		data := make(map[string]string, 8)
 		if title != "" {
 			data["title"] = title
 		}
 		if description != "" {
 			data["description"] = description
 		}
-		idTask, err := strconv.Atoi(args[0])
-		if err != nil {
-			fmt.Printf("не верное значение для ID задачи: %v\n", args[0])
-			return
+		if editPriority != "" {
+			data["priority"] = editPriority
+		}
+		if editDue != "" {
+			data["due"] = editDue
+		}
+		if editEstimate != "" {
+			data["estimate"] = editEstimate
+		}
+		if len(editTags) > 0 {
+			data["tags"] = strings.Join(editTags, ",")
+		}
+		if len(editUntags) > 0 {
+			data["remove_tags"] = strings.Join(editUntags, ",")
+		}
+		if editEvery != "" {
+			data["every"] = editEvery
 		}
-		err = mgr.Edit(idTask, data)
+		idTask, err := parseID(args[0])
 		if err != nil {
-			fmt.Printf("%v\n", err)
-			return
+			return err
 		}
+		return mgr.Edit(idTask, data)
 	},
 }
 
@@ -57,4 +83,10 @@ func init() {
 
 	editCmd.Flags().StringVarP(&title, "title", "t", "", "Новое название для заголовка задачи")
 	editCmd.Flags().StringVarP(&description, "description", "d", "", "Новое описание для задачи")
+	editCmd.Flags().StringVarP(&editPriority, "priority", "p", "", "Новый приоритет задачи: low, medium, high, critical")
+	editCmd.Flags().StringVar(&editDue, "due", "", "Новый срок выполнения: дата, фраза или none")
+	editCmd.Flags().StringVar(&editEstimate, "estimate", "", "Новая оценка: время (2h, 90m), story points (3pt) или none")
+	editCmd.Flags().StringArrayVar(&editTags, "tag", nil, "Добавить тег (можно указать несколько раз)")
+	editCmd.Flags().StringArrayVar(&editUntags, "untag", nil, "Удалить тег (можно указать несколько раз)")
+	editCmd.Flags().StringVar(&editEvery, "every", "", "Правило повтора: day, week, 2weeks, month, weekday, RRULE или none")
 }
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/manager"
+	"todo_cli/internal/render"
+	"todo_cli/internal/storage"
+	"todo_cli/internal/task"
+)
+
+// ErrUsage - команда вызвана с некорректными аргументами или флагами
+var ErrUsage = errors.New("некорректное использование команды")
+
+// Коды завершения процесса. Описаны в справке todo -h и README.
+const (
+	ExitOK           = 0 // команда выполнена успешно
+	ExitError        = 1 // прочие ошибки
+	ExitUsage        = 2 // некорректные аргументы или флаги, команда недоступна для выбранного хранилища
+	ExitNotFound     = 3 // задача или список задач не найдены
+	ExitInvalidID    = 4 // некорректный ID задачи
+	ExitInvalidState = 5 // некорректный статус задачи, у задачи есть подзадачи, она заблокирована, не ведётся учёт времени, список не пуст или выбран по умолчанию, нечего отменять или повторять, задачи изменены в обход истории
+	ExitInvalidData  = 6 // некорректные данные: название, приоритет, срок, оценка, тег, период, родительская задача, циклическая зависимость, правило повтора, имя списка (некорректное или уже занятое), формат вывода или файла, хранилище в настройках
+	ExitStorage      = 7 // ошибка чтения или записи файла задач, списка задач, настроек, файла импорта или экспорта
+	ExitLocked       = 8 // файл задач заблокирован другим процессом
+)
+
+// порядок важен: ошибка сопоставляется с первым подходящим кодом
+var exitCodes = []struct {
+	err  error
+	code int
+}{
+	{ErrUsage, ExitUsage},
+	{manager.ErrListsUnsupported, ExitUsage},
+	{manager.ErrHistoryUnsupported, ExitUsage},
+	{task.ErrTaskNotFound, ExitNotFound},
+	{storage.ErrListNotFound, ExitNotFound},
+	{task.ErrInvalidID, ExitInvalidID},
+	{task.ErrInvalidStatus, ExitInvalidState},
+	{task.ErrHasSubtasks, ExitInvalidState},
+	{task.ErrBlocked, ExitInvalidState},
+	{task.ErrNotTracking, ExitInvalidState},
+	{storage.ErrListNotEmpty, ExitInvalidState},
+	{storage.ErrDefaultList, ExitInvalidState},
+	{journal.ErrNothingToUndo, ExitInvalidState},
+	{journal.ErrNothingToRedo, ExitInvalidState},
+	{journal.ErrConflict, ExitInvalidState},
+	{task.ErrTaskTitle, ExitInvalidData},
+	{task.ErrInvalidPriority, ExitInvalidData},
+	{task.ErrInvalidParent, ExitInvalidData},
+	{task.ErrDependencyCycle, ExitInvalidData},
+	{task.ErrInvalidRecurrence, ExitInvalidData},
+	{task.ErrInvalidDue, ExitInvalidData},
+	{task.ErrInvalidEstimate, ExitInvalidData},
+	{task.ErrInvalidTag, ExitInvalidData},
+	{task.ErrInvalidPeriod, ExitInvalidData},
+	{storage.ErrInvalidListName, ExitInvalidData},
+	{storage.ErrListExists, ExitInvalidData},
+	{render.ErrUnknownFormat, ExitInvalidData},
+	{storage.ErrUnknownCodec, ExitInvalidData},
+	{storage.ErrUnknownBackend, ExitInvalidData},
+	{storage.ErrLocked, ExitLocked},
+	{storage.ErrSerializeJson, ExitStorage},
+	{storage.ErrDeserializeJson, ExitStorage},
+	{storage.ErrSerializeCsv, ExitStorage},
+	{storage.ErrDeserializeCsv, ExitStorage},
+	{storage.ErrDeserializeIcs, ExitStorage},
+}
+
+// exitCodeFor возвращает код завершения процесса для ошибки команды.
+func exitCodeFor(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	for _, value := range exitCodes {
+		if errors.Is(err, value.err) {
+			return value.code
+		}
+	}
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return ExitStorage
+	}
+	return ExitError
+}
+
+// parseID преобразует аргумент команды в ID задачи.
+// Возвращает ошибку task.ErrInvalidID, если аргумент не является положительным числом.
+func parseID(value string) (int, error) {
+	idTask, err := strconv.Atoi(value)
+	if err != nil || idTask <= 0 {
+		return 0, fmt.Errorf("%w: %v", task.ErrInvalidID, value)
+	}
+	return idTask, nil
+}
@@ -0,0 +1,50 @@
+//go:build !production
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/manager"
+	"todo_cli/internal/storage"
+	"todo_cli/internal/task"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"без ошибки", nil, ExitOK},
+		{"прочая ошибка", errors.New("boom"), ExitError},
+		{"некорректные аргументы", fmt.Errorf("%w: количество", ErrUsage), ExitUsage},
+		{"списки недоступны", manager.ErrListsUnsupported, ExitUsage},
+		{"история недоступна", fmt.Errorf("ошибка: %w", manager.ErrHistoryUnsupported), ExitUsage},
+		{"задача не найдена", fmt.Errorf("%w: #42", task.ErrTaskNotFound), ExitNotFound},
+		{"список не найден", storage.ErrListNotFound, ExitNotFound},
+		{"некорректный ID", task.ErrInvalidID, ExitInvalidID},
+		{"есть подзадачи", task.ErrHasSubtasks, ExitInvalidState},
+		{"список не пуст", storage.ErrListNotEmpty, ExitInvalidState},
+		{"список по умолчанию", storage.ErrDefaultList, ExitInvalidState},
+		{"нечего отменять", journal.ErrNothingToUndo, ExitInvalidState},
+		{"нечего повторять", journal.ErrNothingToRedo, ExitInvalidState},
+		{"задачи изменены в обход истории", fmt.Errorf("не удалось отменить: %w", journal.ErrConflict), ExitInvalidState},
+		{"имя списка занято", storage.ErrListExists, ExitInvalidData},
+		{"неизвестное хранилище", fmt.Errorf("ошибка (%w): sqlite", storage.ErrUnknownBackend), ExitInvalidData},
+		{"ошибка разбора файла", storage.ErrDeserializeIcs, ExitStorage},
+		{"ошибка файловой системы", &fs.PathError{Op: "open", Path: "tasks.json", Err: fs.ErrPermission}, ExitStorage},
+		{"файл заблокирован", storage.ErrLocked, ExitLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, exitCodeFor(tt.err))
+		})
+	}
+}
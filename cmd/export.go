@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"todo_cli/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat  string
+	exportFile    string
+	exportDeleted bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Выгрузка задач в файл для переноса или табличных редакторов",
+	Long: `Выгружает задачи текущего списка в stdout или в файл --file.
+
+Флаг --format задаёт формат: json (формат хранилища, по умолчанию), csv, todotxt, markdown, ics
+или taskwarrior.
+CSV содержит заголовок и все поля задачи: даты в RFC 3339, теги и ID блокирующих задач
+через запятую, журнал времени, помодоро и историю статусов в JSON. Файлы json и csv
+загружаются обратно командой todo import без потерь.
+todotxt - строки формата todo.txt: (A) приоритет, x и даты завершения и создания, теги
+как +project и @context, срок, оценка и связи задач в расширениях due:, est:, id:, parent:
+и т.д. Описание, журнал времени, помодоро и история статусов в todo.txt не попадают.
+markdown - чек-лист GitHub для PR и README: разделы по статусу, пункты - [ ] и - [x] с тегами,
+подзадачи вложены в пункт родителя, описание - текст с отступом под пунктом.
+ics - календарь iCalendar: задачи записываются как VTODO с названием, описанием, статусом,
+приоритетом, тегами, датами создания, начала, срока и завершения, родителем и правилом повтора.
+Файл можно открыть или подписаться на него в приложении календаря.
+taskwarrior - JSON для task import: название, заметки с описанием, статус, приоритет H/M/L,
+теги (тег project:имя - проект), даты, зависимости depends и повтор recur для задач со сроком.
+Подзадачи и оценка в Taskwarrior не передаются. О значениях, которые Taskwarrior не сохраняет
+точно (приоритет critical, повтор без срока или по нескольким дням недели), выводятся замечания в stderr.
+Задачи из корзины выгружаются только с флагом --deleted.
+
+Примеры:
+  todo export --format csv > tasks.csv
+  todo export --format csv --file tasks.csv
+  todo export --format todotxt --file todo.txt
+  todo export --format markdown --file TODO.md
+  todo export --format ics --file ~/Calendars/todo.ics
+  todo export --format taskwarrior | task import
+  todo --list work export --deleted --file work.json
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		codec, err := storage.NewCodec(exportFormat)
+		if err != nil {
+			return err
+		}
+		data, err := mgr.Export(codec, exportDeleted)
+		if err != nil {
+			return err
+		}
+		if exportFile == "" {
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		}
+		err = os.WriteFile(exportFile, data, 0644)
+		if err != nil {
+			return fmt.Errorf("не удалось записать файл экспорта: %w", err)
+		}
+		out.RenderMessage(fmt.Sprintf("Задачи выгружены в %s", exportFile))
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", storage.CodecJSON, "Формат файла: json, csv, todotxt, markdown, ics или taskwarrior")
+	exportCmd.Flags().StringVar(&exportFile, "file", "", "Файл для выгрузки (по умолчанию stdout)")
+	exportCmd.Flags().BoolVar(&exportDeleted, "deleted", false, "Выгрузить также задачи из корзины")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"todo_cli/internal/render"
+
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Граф зависимостей между задачами",
+	Long: `Выводит задачи, которые ждут завершения других задач, и задачи, которых они ждут.
+
+Флаг --format задаёт формат: text (по умолчанию) или dot для Graphviz.
+Стрелки в DOT ведут от блокирующей задачи к заблокированной.
+При --output json граф выводится объектом с задачами и зависимостями.
+
+Примеры:
+  todo graph
+  todo graph --format dot | dot -Tpng -o graph.png
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return mgr.Graph(graphFormat)
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", render.GraphText, "Формат графа: text или dot")
+	rootCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"todo_cli/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	importFormat string
+	importMap    string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <файл>",
+	Short: "Загрузка задач из файла в текущий список",
+	Long: `Добавляет в текущий список задачи из файла и выводит, сколько задач создано,
+пропущено и не загружено из-за ошибок (с номером записи и причиной).
+
+Формат определяется по расширению файла (.json, .csv, .txt для todo.txt,
+.md для чек-листа Markdown или .ics для iCalendar) или задаётся флагом --format.
+Вывод task export из Taskwarrior загружается с --format taskwarrior.
+Задачи получают новые ID после последней задачи списка, связи между загруженными задачами
+(подзадачи, зависимости, серии повторов) сохраняются. Запись с тем же названием и датой
+создания, что и у задачи списка, считается дубликатом и пропускается.
+
+В CSV обязательна только колонка с названием задачи. Колонки называются как поля задачи
+(title, description, status, priority, created, due, estimate, tags и т.д., см. todo export),
+а колонки с другими названиями сопоставляются полям флагом --map. Разделитель - запятая
+или точка с запятой, даты - 2025-10-15, 15.10.2025 или RFC 3339.
+В Markdown задачами становятся пункты - [ ] и - [x], вложенные пункты - подзадачами,
+текст с отступом под пунктом - описанием, а заголовок "## В работе" отмечает задачи в работе.
+Из iCalendar загружаются задачи VTODO (события и другие компоненты пропускаются), RELATED-TO
+со ссылкой на задачу того же файла становится родителем, отменённые задачи (CANCELLED) не загружаются.
+Из Taskwarrior проект становится тегом project:имя, заметки - описанием, depends - зависимостями,
+экземпляры повторяющейся задачи - одной серией (recur: weekly, biweekly, quarterly, 2wk, 3mo и т.д.);
+шаблоны повторов (status recurring) не загружаются, а задача с неизвестным правилом повтора создаётся
+без повтора и выводится в разделе замечаний.
+Записи с ошибками не прерывают загрузку, все созданные задачи можно убрать одной командой todo undo.
+
+Примеры:
+  todo import tasks.csv
+  todo import backup.json
+  todo import ~/Dropbox/todo/todo.txt
+  todo import TODO.md
+  todo import tasks.ics
+  task export > tw.json && todo import tw.json --format taskwarrior
+  todo import sheet.csv --map "Задача=title,Срок=due,Метки=tags"
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := importFormat
+		if format == "" {
+			var err error
+			format, err = storage.CodecByExt(args[0])
+			if err != nil {
+				return err
+			}
+		}
+		codec, err := storage.NewCodec(format)
+		if err != nil {
+			return err
+		}
+		if importMap != "" {
+			csvCodec, ok := codec.(*storage.CsvCodec)
+			if !ok {
+				return fmt.Errorf("%w: флаг --map используется только для csv", ErrUsage)
+			}
+			csvCodec.Mapping, err = storage.ParseCsvMapping(importMap)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrUsage, err)
+			}
+		}
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("не удалось прочитать файл импорта: %w", err)
+		}
+		return mgr.Import(codec, data)
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&importFormat, "format", "f", "", "Формат файла: json, csv, todotxt, markdown, ics или taskwarrior (по умолчанию - по расширению)")
+	importCmd.Flags().StringVar(&importMap, "map", "", "Сопоставление колонок CSV полям задачи: \"Колонка=поле,...\"")
+	rootCmd.AddCommand(importCmd)
+}
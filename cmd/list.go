@@ -2,30 +2,77 @@ package cmd
 
 import (
 	"fmt"
+	"todo_cli/internal/manager"
 
 	"github.com/spf13/cobra"
 )
 
-var status string
+var (
+	status        string
+	listPriority  string
+	listSort      string
+	listOverdue   bool
+	listDueToday  bool
+	listDueBefore string
+	listTags      []string
+	listTagsAny   bool
+	listTree      bool
+	listBlocked   bool
+	listReady     bool
+	listRecurring bool
+)
 
 var listCmd = &cobra.Command{
 	Use:   "list",
-	Short: "Просмотр всех задач или фильтрация по статусу",
-	Long: `Отображает список задач с возможностью фильтрации по статусу.
+	Short: "Просмотр всех задач или фильтрация по статусу и приоритету",
+	Long: `Отображает список задач с возможностью фильтрации по статусу и приоритету.
 
 По умолчанию показывает все задачи. Используйте флаг --status для фильтрации.
 Доступные статусы: pending, in_progress, completed.
+Флаг --priority оставляет задачи с указанным приоритетом: low, medium, high, critical.
+Флаг --sort задаёт порядок вывода: id (по умолчанию) или priority.
+Флаги --overdue, --due-today и --due-before показывают просроченные задачи,
+задачи со сроком сегодня и задачи со сроком раньше указанной даты.
+Флаг --tag (можно повторять) оставляет задачи со всеми указанными тегами,
+а вместе с --any - хотя бы с одним из них.
+Флаг --tree выводит задачи деревом: подзадачи под родительскими задачами.
+Флаги --blocked и --ready оставляют невыполненные задачи, которые ждут других задач
+(см. todo block), и задачи, готовые к работе.
+Флаг --recurring выводит серии повторяющихся задач со сроком следующего повтора.
 
 Примеры:
   todo list
   todo list --status completed
   todo list -s in_progress
+  todo list --priority high
+  todo list --sort priority
+  todo list --overdue
+  todo list --due-before "next week"
+  todo list --tag backend --tag auth
+  todo list --tag backend --tag frontend --any
+  todo list --tree
+  todo list --ready
+  todo list --recurring
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		err := mgr.List(status)
-		if err != nil {
-			fmt.Printf("%v\n", err)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if listBlocked && listReady {
+			return fmt.Errorf("%w: флаги --blocked и --ready нельзя использовать вместе", ErrUsage)
+		}
+		options := manager.ListOptions{
+			Status:    status,
+			Priority:  listPriority,
+			SortBy:    listSort,
+			Overdue:   listOverdue,
+			DueToday:  listDueToday,
+			DueBefore: listDueBefore,
+			Tags:      listTags,
+			TagsAny:   listTagsAny,
+			Tree:      listTree,
+			Blocked:   listBlocked,
+			Ready:     listReady,
+			Recurring: listRecurring,
 		}
+		return mgr.List(options)
 	},
 }
 
@@ -33,4 +80,15 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 
 	listCmd.Flags().StringVarP(&status, "status", "s", "all", "Название статуса для фильтра")
+	listCmd.Flags().StringVarP(&listPriority, "priority", "p", "", "Приоритет для фильтра")
+	listCmd.Flags().StringVar(&listSort, "sort", manager.SortByID, "Сортировка: id или priority")
+	listCmd.Flags().BoolVar(&listOverdue, "overdue", false, "Только просроченные задачи")
+	listCmd.Flags().BoolVar(&listDueToday, "due-today", false, "Только задачи со сроком сегодня")
+	listCmd.Flags().StringVar(&listDueBefore, "due-before", "", "Только задачи со сроком раньше даты")
+	listCmd.Flags().StringArrayVar(&listTags, "tag", nil, "Тег для фильтра (можно указать несколько раз)")
+	listCmd.Flags().BoolVar(&listTagsAny, "any", false, "Достаточно совпадения хотя бы одного тега")
+	listCmd.Flags().BoolVar(&listTree, "tree", false, "Вывести задачи деревом с подзадачами")
+	listCmd.Flags().BoolVar(&listBlocked, "blocked", false, "Только задачи, которые ждут других задач")
+	listCmd.Flags().BoolVar(&listReady, "ready", false, "Только невыполненные задачи, готовые к работе")
+	listCmd.Flags().BoolVar(&listRecurring, "recurring", false, "Серии повторяющихся задач и сроки следующих повторов")
 }
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var deleteListForce bool
+
+var listsCmd = &cobra.Command{
+	Use:   "lists",
+	Short: "Управление списками задач",
+	Long: `Отображает все списки задач с количеством задач в каждом.
+
+Списки позволяют хранить, например, личные и рабочие задачи отдельно.
+Каждый список хранится в отдельном файле ~/.todo/<имя>.json.
+Выбрать список для одной команды можно глобальным флагом --list,
+а список по умолчанию - командой todo lists use.
+
+Примеры:
+  todo lists
+  todo lists create work
+  todo --list work add "Подготовить релиз"
+  todo lists use work
+  todo lists rename work job
+  todo lists delete job --force
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return mgr.Lists()
+	},
+}
+
+var listsCreateCmd = &cobra.Command{
+	Use:   "create [имя списка]",
+	Short: "Создание нового списка задач",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := mgr.CreateList(args[0])
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Список %s создан", args[0]))
+		return nil
+	},
+}
+
+var listsRenameCmd = &cobra.Command{
+	Use:   "rename [старое имя] [новое имя]",
+	Short: "Переименование списка задач",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := mgr.RenameList(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Список %s переименован в %s", args[0], args[1]))
+		return nil
+	},
+}
+
+var listsDeleteCmd = &cobra.Command{
+	Use:   "delete [имя списка]",
+	Short: "Удаление списка задач",
+	Long: `Удаляет список задач вместе с его резервной копией.
+
+Список, в котором есть задачи, удаляется только с флагом --force.
+Список по умолчанию удалить нельзя - сначала выберите другой командой todo lists use.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := mgr.DeleteList(args[0], deleteListForce)
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Список %s удалён", args[0]))
+		return nil
+	},
+}
+
+var listsUseCmd = &cobra.Command{
+	Use:   "use [имя списка]",
+	Short: "Выбор списка задач по умолчанию",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := mgr.SetDefaultList(args[0])
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Список %s выбран по умолчанию", args[0]))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listsCmd)
+	listsCmd.AddCommand(listsCreateCmd, listsRenameCmd, listsDeleteCmd, listsUseCmd)
+
+	listsDeleteCmd.Flags().BoolVarP(&deleteListForce, "force", "f", false, "Удалить список, даже если в нём есть задачи")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"todo_cli/internal/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	logBy   string
+	logDays int
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Затраченное время по задачам, дням или тегам",
+	Long: `Выводит время, затраченное на задачи за последние --days дней (по умолчанию 7),
+включая сегодняшний, и задачу, по которой сейчас идёт учёт времени.
+
+Флаг --by задаёт группировку: task (по задачам, по умолчанию), day (по дням)
+или tag (по тегам). Время задачи с несколькими тегами учитывается в каждом теге.
+Время учитывается по интервалам работы между todo start и todo stop или todo complete.
+
+Примеры:
+  todo log
+  todo log --by day --days 30
+  todo log --by tag -o json
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch logBy {
+		case manager.TimeByTask, manager.TimeByDay, manager.TimeByTag:
+		default:
+			return fmt.Errorf("%w: группировка должна быть task, day или tag: %s", ErrUsage, logBy)
+		}
+		if logDays <= 0 {
+			return fmt.Errorf("%w: количество дней должно быть положительным числом: %d", ErrUsage, logDays)
+		}
+		return mgr.TimeLog(logBy, logDays)
+	},
+}
+
+func init() {
+	logCmd.Flags().StringVar(&logBy, "by", manager.TimeByTask, "Группировка: task, day или tag")
+	logCmd.Flags().IntVar(&logDays, "days", manager.DefaultLogDays, "Количество последних дней")
+	rootCmd.AddCommand(logCmd)
+}
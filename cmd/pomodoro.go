@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"todo_cli/internal/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var pomodoroOptions = manager.PomodoroOptions{}
+
+var pomodoroCmd = &cobra.Command{
+	Use:   "pomodoro [ID задачи]",
+	Short: "Работа над задачей по технике помодоро",
+	Long: `Запускает отсчёт помодоро по задаче: --work работы (по умолчанию 25m),
+затем --break перерыва (по умолчанию 5m), и так --rounds раз подряд.
+
+На время работы задача переводится в работу и по ней ведётся учёт времени (см. todo start),
+после работы учёт останавливается, а помодоро записывается в журнал помодоро задачи.
+Количество помодоро выводится в todo show, время работы учитывается в todo log и todo report.
+Ctrl+C во время работы записывает отработанную часть как прерванный помодоро.
+
+Примеры:
+  todo pomodoro 3
+  todo pomodoro 3 --work 50m --break 10m
+  todo pomodoro 3 --rounds 4
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idTask, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+		if pomodoroOptions.Work <= 0 || pomodoroOptions.Break < 0 {
+			return fmt.Errorf("%w: длительность работы должна быть положительной, а перерыва - неотрицательной", ErrUsage)
+		}
+		if pomodoroOptions.Rounds <= 0 {
+			return fmt.Errorf("%w: количество помодоро должно быть положительным числом: %d", ErrUsage, pomodoroOptions.Rounds)
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		result, err := mgr.Pomodoro(ctx, idTask, pomodoroOptions)
+		if err != nil {
+			return err
+		}
+		if result.Interrupted {
+			out.RenderMessage(fmt.Sprintf("Помодоро по задаче #%d прерван, отработано: %s",
+				idTask, result.Worked.Round(time.Second)))
+			return nil
+		}
+		out.RenderMessage(fmt.Sprintf("Помодоро по задаче #%d завершены: %d", idTask, result.Completed))
+		return nil
+	},
+}
+
+func init() {
+	pomodoroCmd.Flags().DurationVar(&pomodoroOptions.Work, "work", manager.DefaultPomodoroWork, "Длительность работы")
+	pomodoroCmd.Flags().DurationVar(&pomodoroOptions.Break, "break", manager.DefaultPomodoroBreak, "Длительность перерыва")
+	pomodoroCmd.Flags().IntVar(&pomodoroOptions.Rounds, "rounds", 1, "Количество помодоро подряд")
+	rootCmd.AddCommand(pomodoroCmd)
+}
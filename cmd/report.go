@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"todo_cli/internal/manager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportWeek  bool
+	reportMonth bool
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Отчёт о затраченном времени за неделю или месяц",
+	Long: `Выводит затраченное время за текущую неделю (с понедельника) или текущий месяц:
+часы по каждому дню периода, общее время и время по задачам.
+
+По умолчанию и с флагом --week отчёт строится за неделю, с флагом --month - за месяц.
+
+Примеры:
+  todo report --week
+  todo report --month -o json
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if reportWeek && reportMonth {
+			return fmt.Errorf("%w: флаги --week и --month нельзя использовать вместе", ErrUsage)
+		}
+		if reportMonth {
+			return mgr.TimeReport(manager.PeriodMonth)
+		}
+		return mgr.TimeReport(manager.PeriodWeek)
+	},
+}
+
+func init() {
+	reportCmd.Flags().BoolVar(&reportWeek, "week", false, "Отчёт за текущую неделю")
+	reportCmd.Flags().BoolVar(&reportMonth, "month", false, "Отчёт за текущий месяц")
+	rootCmd.AddCommand(reportCmd)
+}
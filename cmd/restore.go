@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [ID задачи]",
+	Short: "Восстановление задачи из корзины",
+	Long: `Возвращает задачу из корзины в список с её исходным ID.
+
+Для восстановления необходимо передать ID задачи.
+Посмотреть задачи в корзине можно командой todo trash.
+
+Примеры:
+  todo restore 8
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idTask, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+		err = mgr.Restore(idTask)
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Задача #%d восстановлена из корзины", idTask))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"todo_cli/internal/manager"
 	"todo_cli/internal/render"
@@ -12,6 +13,21 @@ import (
 // глобальный менеджер для использования в командах
 var mgr *manager.Manager
 
+// рендер для вывода сообщений и ошибок команд, выбирается флагом --output
+var out render.Render = &render.TerminalRender{}
+
+// формат вывода из глобального флага --output
+var outputFormat string
+
+// команда начала выполняться: ошибки до этого момента - ошибки разбора аргументов и флагов
+var commandStarted bool
+
+// хранилище вынесено отдельно, чтобы глобальные флаги могли менять его настройки
+var store = &storage.FileStorage{}
+
+// имя списка задач из глобального флага --list
+var listName string
+
 // rootCmd показывает базовую команду (тут только описание тк не указан Run) если команда передана без аргументов
 var rootCmd = &cobra.Command{
 	Use:   "todo",
@@ -22,23 +38,91 @@ var rootCmd = &cobra.Command{
 
 Для справки вызовите:
 
-todo -h`,
+todo -h
+
+Хранилище задач выбирается настройкой backend в ~/.todo/config.json:
+  "backend": "json"    - списки задач в ~/.todo (по умолчанию)
+  "backend": "todotxt" - один файл в формате todo.txt, путь задаётся настройкой
+                         "todotxt_file" (по умолчанию ~/.todo/todo.txt)
+                         и файл .meta рядом с ним для полей, которых нет в todo.txt
+
+Коды завершения:
+  0 - успешно
+  1 - прочие ошибки
+  2 - некорректные аргументы или флаги, команда недоступна для выбранного хранилища
+  3 - задача или список задач не найдены
+  4 - некорректный ID задачи
+  5 - некорректный статус задачи, у задачи есть подзадачи, она заблокирована, не ведётся учёт времени,
+      список не пуст или выбран по умолчанию, нечего отменять или повторять, задачи изменены в обход истории
+  6 - некорректные данные (название, приоритет, срок, оценка, тег, период, родительская задача, циклическая зависимость, правило повтора, имя списка (некорректное или уже занятое), формат вывода или файла, хранилище в настройках)
+  7 - ошибка хранилища (чтение или запись файла задач, списка задач, настроек, файла импорта или экспорта)
+  8 - файл задач заблокирован другим процессом`,
+	// перед любой командой выбираем формат вывода и переключаемся на выбранный список задач
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// аргументы уже разобраны - дальше ошибки не связаны с использованием команды
+		cmd.SilenceUsage = true
+		commandStarted = true
+		selected, err := render.New(outputFormat)
+		if err != nil {
+			return err
+		}
+		out = selected
+		backend, err := openStorage()
+		if err != nil {
+			return err
+		}
+		mgr = manager.NewManager(backend, filter, out)
+		return mgr.UseList(listName)
+	},
+	// ошибки выводятся через текущий рендер в Execute
+	SilenceErrors: true,
 }
 
+// Execute запускает команду и завершает процесс с кодом, соответствующим ошибке (см. exitCodeFor).
+// Ошибки выводятся через текущий рендер в stderr.
 func Execute() {
 	err := rootCmd.Execute()
+	if err == nil {
+		return
+	}
+	if !commandStarted {
+		err = fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	out.RenderError(err)
+	os.Exit(exitCodeFor(err))
+}
+
+// openStorage возвращает хранилище задач, выбранное настройкой backend в ~/.todo/config.json:
+// списки задач в JSON (по умолчанию) или один файл todo.txt из настройки todotxt_file.
+func openStorage() (manager.Storage, error) {
+	config, err := storage.LoadConfig()
 	if err != nil {
-		os.Exit(1)
+		return nil, err
 	}
+	switch config.Backend {
+	case "", storage.BackendJSON:
+		return store, nil
+	case storage.BackendTodoTxt:
+		return &storage.TodoTxtStorage{Path: config.TodoTxtFile, LockTimeout: store.LockTimeout}, nil
+	}
+	return nil, fmt.Errorf("ошибка (%w): %s, допустимые значения backend: %s, %s",
+		storage.ErrUnknownBackend, config.Backend, storage.BackendJSON, storage.BackendTodoTxt)
 }
 
+// фильтр не зависит от флагов и общий для всех запусков менеджера
+var filter = &manager.FilterTasks{}
+
 func init() {
-	store := &storage.FileStorage{}
-	filter := &manager.FilterTasks{}
-	render := &render.TerminalRender{}
-	mgr = manager.NewManager(store, filter, render)
+	mgr = manager.NewManager(store, filter, out)
 	cobra.AddTemplateFunc("tr", translate)
 	rootCmd.SetUsageTemplate(usageTemplate)
+
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", render.FormatText,
+		"Формат вывода: text или json")
+	rootCmd.PersistentFlags().StringVar(&listName, "list", "",
+		"Список задач (по умолчанию - выбранный командой todo lists use)")
+	rootCmd.PersistentFlags().DurationVar(&store.LockTimeout, "lock-timeout", storage.DefaultLockTimeout,
+		"Сколько ждать, пока другой процесс todo освободит файл задач")
 }
 
 func translate(s string) string {
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
@@ -19,11 +17,8 @@ var searchCmd = &cobra.Command{
 	Args: cobra.MatchAll(
 		cobra.MinimumNArgs(1), // минимум 1 аргумент
 	),
-	Run: func(cmd *cobra.Command, args []string) {
-		err := mgr.Search(args[0])
-		if err != nil {
-			fmt.Printf("%v\n", err)
-		}
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return mgr.Search(args[0])
 	},
 }
 
@@ -1,9 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-	"strconv"
-
 	"github.com/spf13/cobra"
 )
 
@@ -17,21 +14,13 @@ var showCmd = &cobra.Command{
 Примеры:
   todo show 12
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) < 1 {
-			fmt.Print("не передан ID задачи\n")
-			return
-		}
-		idTask, err := strconv.Atoi(args[0])
-		if err != nil {
-			fmt.Printf("не верное значение для ID задачи: %v\n", args[0])
-			return
-		}
-		err = mgr.Show(idTask)
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idTask, err := parseID(args[0])
 		if err != nil {
-			fmt.Printf("%v\n", err)
-			return
+			return err
 		}
+		return mgr.Show(idTask)
 	},
 }
 
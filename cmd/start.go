@@ -2,41 +2,49 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/spf13/cobra"
 )
 
+var startForce bool
+
 var startCmd = &cobra.Command{
 	Use:   "start [ID задачи]",
 	Short: "Начать выполнение задачи (установить статус 'in_progress')",
-	Long: `Переводит задачу в статус "in_progress" (в работе).
+	Long: `Переводит задачу в статус "in_progress" (в работе) и запускает учёт времени по ней.
 
 Используйте эту команду, когда начинаете работать над задачей.
 Для выполнения команды необходимо передать ID задачи.
+Учёт времени ведётся только по одной задаче: если он шёл по другой задаче,
+он останавливается (см. todo stop), а сама задача остаётся в работе.
+Повторный запуск задачи в работе возобновляет учёт времени после todo stop.
+Задачу, которая ждёт завершения других задач (см. todo block), можно начать
+только с флагом --force.
 
 Примеры:
   todo start 15
+  todo start 15 --force
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) < 1 {
-			fmt.Print("не передан ID задачи\n")
-			return
-		}
-		idTask, err := strconv.Atoi(args[0])
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idTask, err := parseID(args[0])
 		if err != nil {
-			fmt.Printf("не верное значение для ID задачи: %v\n", args[0])
-			return
+			return err
 		}
-		err = mgr.Start(idTask)
+		stopped, err := mgr.Start(idTask, startForce)
 		if err != nil {
-			fmt.Printf("%v\n", err)
-			return
+			return err
 		}
-		fmt.Printf("Задача #%d переведена в статус 'in_progress' \n", idTask)
+		if stopped != nil {
+			out.RenderMessage(fmt.Sprintf("Учёт времени по задаче #%d остановлен", *stopped))
+		}
+		out.RenderMessage(fmt.Sprintf("Задача #%d переведена в статус 'in_progress', идёт учёт времени", idTask))
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(startCmd)
+
+	startCmd.Flags().BoolVar(&startForce, "force", false, "Начать задачу, даже если она ждёт других задач")
 }
@@ -2,10 +2,18 @@ package cmd
 
 import (
 	"fmt"
+	"todo_cli/internal/manager"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	statsPeriod string
+	statsChart  bool
+	statsDays   int
+	statsEffort bool
+)
+
 var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Просмотр статистики по задачам",
@@ -14,17 +22,55 @@ var statsCmd = &cobra.Command{
 Показывает общее количество задач и количество задач для каждого статуса:
 pending (ожидает), in_progress (в работе), completed (выполнено).
 
+С флагом --period (week или month) выводит показатели потока задач за последние 6 недель или месяцев:
+  - количество выполненных задач в каждом периоде;
+  - lead time - время от создания до завершения задачи;
+  - cycle time - время от начала работы (in_progress) до завершения;
+  - самые старые невыполненные задачи.
+Для lead time и cycle time выводятся среднее, медиана и 90-й перцентиль.
+
+С флагом --chart рисует графики за последние --days дней (по умолчанию 14):
+  - созданные и выполненные задачи по дням;
+  - количество открытых задач на конец каждого дня (burndown);
+  - тепловую карту выполненных задач за 12 недель.
+
+С флагом --effort выводит суммарные оценки задач (см. todo add --estimate) для планирования:
+по статусам, по тегам и всего - оценку в часах и story points, затраченное время
+и оставшуюся по оценке работу невыполненных задач.
+
 Примеры:
   todo stats
+  todo stats --period week
+  todo stats --period month -o json
+  todo stats --chart
+  todo stats --chart --days 30
+  todo stats --effort
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		err := mgr.Stats()
-		if err != nil {
-			fmt.Printf("%v\n", err)
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if statsEffort {
+			if statsChart || statsPeriod != "" {
+				return fmt.Errorf("%w: флаг --effort нельзя использовать вместе с --chart и --period", ErrUsage)
+			}
+			return mgr.Effort()
+		}
+		if statsChart {
+			if statsPeriod != "" {
+				return fmt.Errorf("%w: флаги --chart и --period нельзя использовать вместе", ErrUsage)
+			}
+			if statsDays <= 0 {
+				return fmt.Errorf("%w: количество дней должно быть положительным числом: %d", ErrUsage, statsDays)
+			}
+			return mgr.Activity(statsDays)
 		}
+		return mgr.Stats(statsPeriod)
 	},
 }
 
 func init() {
+	statsCmd.Flags().StringVar(&statsPeriod, "period", "", "Показатели потока задач за период: week или month")
+	statsCmd.Flags().BoolVar(&statsChart, "chart", false, "Графики активности и тепловая карта выполненных задач")
+	statsCmd.Flags().IntVar(&statsDays, "days", manager.DefaultActivityDays, "Количество дней в графиках (с --chart)")
+	statsCmd.Flags().BoolVar(&statsEffort, "effort", false, "Суммарные оценки и затраченное время по статусам и тегам")
 	rootCmd.AddCommand(statsCmd)
 }
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Остановить учёт времени по задаче",
+	Long: `Останавливает учёт времени по задаче, начатой командой todo start.
+
+Статус задачи не меняется: она остаётся в работе, а учёт времени
+можно возобновить командой todo start с тем же ID.
+Учёт времени также останавливается при завершении задачи (todo complete).
+
+Примеры:
+  todo stop
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idTask, err := mgr.Stop()
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Учёт времени по задаче #%d остановлен", idTask))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(stopCmd)
+}
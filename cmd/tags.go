@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Просмотр всех тегов с количеством задач",
+	Long: `Отображает все теги, которыми отмечены задачи, и количество задач с каждым тегом
+в разбивке по статусам: pending (ожидает), in_progress (в работе), completed (выполнено).
+
+Примеры:
+  todo tags
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return mgr.Tags()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+}
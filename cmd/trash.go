@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var trashOlderThan string
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Просмотр задач в корзине",
+	Long: `Отображает задачи, удалённые командой todo delete.
+
+Задачи в корзине не выводятся в списке, поиске и статистике.
+Вернуть задачу с её исходным ID можно командой todo restore,
+окончательно удалить задачи - командой todo trash empty.
+
+Примеры:
+  todo trash
+  todo restore 8
+  todo trash empty
+  todo trash empty --older-than 30d
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return mgr.Trash()
+	},
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Очистка корзины",
+	Long: `Окончательно удаляет задачи из корзины. Операцию нельзя отменить командой todo undo.
+
+С флагом --older-than удаляются только задачи, перемещённые в корзину раньше
+указанного срока: 12h - часы, 30d - дни, 2w - недели.
+
+Примеры:
+  todo trash empty
+  todo trash empty --older-than 30d
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		count, err := mgr.EmptyTrash(trashOlderThan)
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Удалено задач из корзины: %d", count))
+		return nil
+	},
+}
+
+func init() {
+	trashEmptyCmd.Flags().StringVar(&trashOlderThan, "older-than", "",
+		"Удалить только задачи, которые лежат в корзине дольше срока (12h, 30d, 2w)")
+	trashCmd.AddCommand(trashEmptyCmd)
+	rootCmd.AddCommand(trashCmd)
+}
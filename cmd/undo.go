@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var undoHistory bool
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [количество операций]",
+	Short: "Отмена последних изменений задач",
+	Long: `Отменяет последние операции над задачами текущего списка:
+создание, изменение, начало работы, завершение и удаление.
+
+По умолчанию отменяется одна операция - всё, что изменила одна команда: например,
+удаление задачи вместе с подзадачами или импорт отменяются целиком. История хранится рядом с файлом списка
+(~/.todo/<имя>.json.history), поэтому отмена работает между запусками.
+Отменённые операции можно вернуть командой todo redo, пока не выполнена новая операция.
+Флаг --history (-l) выводит историю операций от последней к первой.
+Он называется не --list: этот флаг выбирает список задач, как и в остальных командах.
+
+Примеры:
+  todo undo
+  todo undo 3
+  todo undo --history
+  todo undo -l
+  todo --list work undo --history
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if undoHistory {
+			return mgr.History()
+		}
+		count, err := parseCount(args)
+		if err != nil {
+			return err
+		}
+		count, err = mgr.Undo(count)
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Отменено операций: %d", count))
+		return nil
+	},
+}
+
+var redoCmd = &cobra.Command{
+	Use:   "redo [количество операций]",
+	Short: "Повтор отменённых изменений задач",
+	Long: `Повторяет операции, отменённые командой todo undo.
+
+По умолчанию повторяется одна операция. Новое изменение задач очищает
+список отменённых операций - после него повторять нечего.
+
+Примеры:
+  todo redo
+  todo redo 2
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		count, err := parseCount(args)
+		if err != nil {
+			return err
+		}
+		count, err = mgr.Redo(count)
+		if err != nil {
+			return err
+		}
+		out.RenderMessage(fmt.Sprintf("Повторено операций: %d", count))
+		return nil
+	},
+}
+
+// parseCount возвращает количество операций из необязательного аргумента команды (по умолчанию 1).
+// Возвращает ошибку ErrUsage, если аргумент не является положительным числом.
+func parseCount(args []string) (int, error) {
+	if len(args) == 0 {
+		return 1, nil
+	}
+	count, err := strconv.Atoi(args[0])
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("%w: количество операций должно быть положительным числом: %v", ErrUsage, args[0])
+	}
+	return count, nil
+}
+
+func init() {
+	// --list уже занят глобальным выбором списка задач, поэтому длинное имя флага - --history,
+	// а короткое -l сохраняет привычный вызов todo undo -l
+	undoCmd.Flags().BoolVarP(&undoHistory, "history", "l", false, "Показать историю операций")
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(redoCmd)
+}
@@ -1,7 +1,7 @@
 package cmd
 
 import (
-	"fmt"
+	"todo_cli/internal/render"
 
 	"github.com/spf13/cobra"
 )
@@ -17,8 +17,7 @@ var versionCmd = &cobra.Command{
 	Short: "Показать версию приложения",
 	Long:  `Отображает версию приложения и дату сборки`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("Версия: %s\n", Version)
-		fmt.Printf("Дата: %s\n", BuildDate)
+		out.RenderVersion(render.VersionInfo{Version: Version, Date: BuildDate})
 	},
 }
 
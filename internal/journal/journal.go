@@ -0,0 +1,214 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"todo_cli/internal/task"
+)
+
+// Op - тип операции, изменившей задачу
+type Op string
+
+const (
+	OpCreate   Op = "create"
+	OpEdit     Op = "edit"
+	OpStart    Op = "start"
+	OpStop     Op = "stop"
+	OpPomodoro Op = "pomodoro"
+	OpComplete Op = "complete"
+	OpDelete   Op = "delete"
+	OpRestore  Op = "restore"
+	OpBlock    Op = "block"
+	OpUnblock  Op = "unblock"
+	OpImport   Op = "import"
+)
+
+var (
+	ErrNothingToUndo = errors.New("нет операций для отмены")
+	ErrNothingToRedo = errors.New("нет операций для повтора")
+	ErrConflict      = errors.New("состояние задач не совпадает с историей")
+)
+
+// Limit - сколько последних операций хранится в истории, более старые отбрасываются
+const Limit = 100
+
+// Entry - запись журнала: снимки задачи до и после операции.
+// Before = nil для создания задачи, After = nil для удаления.
+// Записи одной команды (например, удаление задачи вместе с подзадачами) имеют общий номер Group
+// и отменяются и повторяются вместе. Записи без номера (из журналов старых версий) - отдельные операции.
+type Entry struct {
+	Op     Op         `json:"op"`
+	TaskID int        `json:"task_id"`
+	Group  int        `json:"group,omitempty"`
+	At     time.Time  `json:"at"`
+	Before *task.Task `json:"before,omitempty"`
+	After  *task.Task `json:"after,omitempty"`
+}
+
+// NewEntry создаёт запись журнала с копиями снимков задачи,
+// чтобы последующие изменения задач не меняли историю.
+func NewEntry(op Op, before, after *task.Task, at time.Time) Entry {
+	entry := Entry{Op: op, At: at, Before: before.Clone(), After: after.Clone()}
+	if after != nil {
+		entry.TaskID = after.ID
+	} else if before != nil {
+		entry.TaskID = before.ID
+	}
+	return entry
+}
+
+// Title возвращает название задачи из последнего доступного снимка.
+func (e Entry) Title() string {
+	if e.After != nil {
+		return e.After.Title
+	}
+	if e.Before != nil {
+		return e.Before.Title
+	}
+	return ""
+}
+
+// History - журнал операций над списком задач.
+// Done - выполненные операции (последняя в конце), Undone - отменённые, которые можно повторить.
+type History struct {
+	Done   []Entry `json:"done"`
+	Undone []Entry `json:"undone"`
+}
+
+// Record добавляет в журнал одну операцию - записи об изменениях задач, сделанных одной командой.
+// Новая операция сбрасывает отменённые: после неё повторить их уже нельзя.
+// В журнале остаются последние Limit операций.
+func (h *History) Record(entries ...Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	group := 1
+	if len(h.Done) > 0 {
+		group = h.Done[len(h.Done)-1].Group + 1
+	}
+	for _, entry := range entries {
+		entry.Group = group
+		h.Done = append(h.Done, entry)
+	}
+	if start := groupsStart(h.Done, Limit); start > 0 {
+		h.Done = slices.Clone(h.Done[start:])
+	}
+	h.Undone = nil
+}
+
+// SameOperation сообщает, что соседние записи журнала относятся к одной операции.
+func SameOperation(a, b Entry) bool {
+	return a.Group != 0 && a.Group == b.Group
+}
+
+// groupsStart возвращает индекс, с которого начинаются последние n операций журнала (0, если операций меньше n).
+func groupsStart(entries []Entry, n int) int {
+	start := len(entries)
+	for ; n > 0 && start > 0; n-- {
+		start--
+		for start > 0 && SameOperation(entries[start-1], entries[start]) {
+			start--
+		}
+	}
+	return start
+}
+
+// Operations возвращает количество операций, к которым относятся записи журнала.
+func Operations(entries []Entry) int {
+	count := 0
+	for i := range entries {
+		if i == 0 || !SameOperation(entries[i-1], entries[i]) {
+			count += 1
+		}
+	}
+	return count
+}
+
+// Forget удаляет из журнала все операции над задачами с указанными ID.
+// Вызывается при окончательном удалении задач: их ID могут достаться новым задачам,
+// и отмена старых операций затёрла бы новые задачи.
+func (h *History) Forget(ids ...int) {
+	forgotten := func(entry Entry) bool { return slices.Contains(ids, entry.TaskID) }
+	h.Done = slices.DeleteFunc(h.Done, forgotten)
+	h.Undone = slices.DeleteFunc(h.Undone, forgotten)
+}
+
+// Recent возвращает выполненные операции от последней к первой - в порядке, в котором их отменит Undo.
+func (h *History) Recent() []Entry {
+	entries := slices.Clone(h.Done)
+	slices.Reverse(entries)
+	return entries
+}
+
+// Undo отменяет последние n операций (или все, если их меньше n) и возвращает
+// изменённый список задач и записи отменённых операций от последней к первой.
+// Если хотя бы одну запись отменить нельзя, ни задачи, ни журнал не меняются.
+func (h *History) Undo(tasks []*task.Task, n int) ([]*task.Task, []Entry, error) {
+	if len(h.Done) == 0 {
+		return nil, nil, ErrNothingToUndo
+	}
+	start := groupsStart(h.Done, n)
+	result := slices.Clone(tasks)
+	entries := make([]Entry, 0, len(h.Done)-start)
+	var err error
+	for i := len(h.Done) - 1; i >= start; i-- {
+		entry := h.Done[i]
+		result, err = apply(result, entry.TaskID, entry.After, entry.Before)
+		if err != nil {
+			return nil, nil, fmt.Errorf("не удалось отменить операцию %s задачи #%d: %w", entry.Op, entry.TaskID, err)
+		}
+		entries = append(entries, entry)
+	}
+	h.Done = h.Done[:start]
+	h.Undone = append(h.Undone, entries...)
+	return result, entries, nil
+}
+
+// Redo повторяет последние n отменённых операций (или все, если их меньше n) и возвращает
+// изменённый список задач и записи повторённых операций в порядке выполнения.
+// Если хотя бы одну запись повторить нельзя, ни задачи, ни журнал не меняются.
+func (h *History) Redo(tasks []*task.Task, n int) ([]*task.Task, []Entry, error) {
+	if len(h.Undone) == 0 {
+		return nil, nil, ErrNothingToRedo
+	}
+	start := groupsStart(h.Undone, n)
+	result := slices.Clone(tasks)
+	entries := make([]Entry, 0, len(h.Undone)-start)
+	var err error
+	for i := len(h.Undone) - 1; i >= start; i-- {
+		entry := h.Undone[i]
+		result, err = apply(result, entry.TaskID, entry.Before, entry.After)
+		if err != nil {
+			return nil, nil, fmt.Errorf("не удалось повторить операцию %s задачи #%d: %w", entry.Op, entry.TaskID, err)
+		}
+		entries = append(entries, entry)
+	}
+	h.Undone = h.Undone[:start]
+	h.Done = append(h.Done, entries...)
+	return result, entries, nil
+}
+
+// apply переводит задачу id из состояния from в состояние to:
+// from = nil - задача добавляется, to = nil - удаляется, иначе заменяется снимком to.
+// Возвращает ErrConflict, если задача уже есть (при добавлении) или её нет (при изменении и удалении).
+func apply(tasks []*task.Task, id int, from, to *task.Task) ([]*task.Task, error) {
+	index := slices.IndexFunc(tasks, func(t *task.Task) bool { return t.ID == id })
+	switch {
+	case from == nil:
+		if index != -1 {
+			return nil, fmt.Errorf("%w: задача #%d уже существует", ErrConflict, id)
+		}
+		// задачи хранятся по возрастанию ID - восстанавливаем задачу на её место
+		position, _ := slices.BinarySearchFunc(tasks, id, func(t *task.Task, id int) int { return t.ID - id })
+		return slices.Insert(tasks, position, to.Clone()), nil
+	case index == -1:
+		return nil, fmt.Errorf("%w: задача #%d не найдена", ErrConflict, id)
+	case to == nil:
+		return slices.Delete(tasks, index, index+1), nil
+	default:
+		tasks[index] = to.Clone()
+		return tasks, nil
+	}
+}
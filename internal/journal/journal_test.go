@@ -0,0 +1,221 @@
+//go:build !production
+
+package journal_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ids возвращает ID задач в порядке следования
+func ids(tasks []*task.Task) []int {
+	result := make([]int, 0, len(tasks))
+	for _, value := range tasks {
+		result = append(result, value.ID)
+	}
+	return result
+}
+
+// historyFor возвращает задачи после серии операций и журнал этих операций:
+// создание #7, изменение названия #1, завершение #2 и удаление #3.
+func historyFor(t *testing.T) ([]*task.Task, *journal.History) {
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	history := &journal.History{}
+	now := time.Now()
+
+	created, err := task.NewTask(7, "new task", "description", task.StatusPending.String())
+	require.NoError(t, err)
+	tasks = append(tasks, created)
+	history.Record(journal.NewEntry(journal.OpCreate, nil, created, now))
+
+	before := tasks[0].Clone()
+	tasks[0].Title = "renamed task"
+	history.Record(journal.NewEntry(journal.OpEdit, before, tasks[0], now))
+
+	before = tasks[1].Clone()
+	tasks[1].Status = task.StatusCompleted
+	history.Record(journal.NewEntry(journal.OpComplete, before, tasks[1], now))
+
+	deleted := tasks[2]
+	tasks = append(tasks[:2], tasks[3:]...)
+	history.Record(journal.NewEntry(journal.OpDelete, deleted, nil, now))
+
+	return tasks, history
+}
+
+func TestUndo(t *testing.T) {
+	tests := []struct {
+		name          string
+		n             int
+		expectedOps   []journal.Op
+		expectedIDs   []int
+		expectedTitle string
+		expectedState task.Status
+	}{
+		{"отмена удаления", 1, []journal.Op{journal.OpDelete}, []int{1, 2, 3, 4, 5, 6, 7}, "renamed task", task.StatusCompleted},
+		{"отмена трёх операций", 3, []journal.Op{journal.OpDelete, journal.OpComplete, journal.OpEdit},
+			[]int{1, 2, 3, 4, 5, 6, 7}, "pending task 1", task.StatusPending},
+		{"больше операций, чем в истории", 10,
+			[]journal.Op{journal.OpDelete, journal.OpComplete, journal.OpEdit, journal.OpCreate},
+			[]int{1, 2, 3, 4, 5, 6}, "pending task 1", task.StatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, history := historyFor(t)
+
+			result, entries, err := history.Undo(tasks, tt.n)
+			require.NoError(t, err)
+
+			ops := make([]journal.Op, 0, len(entries))
+			for _, entry := range entries {
+				ops = append(ops, entry.Op)
+			}
+			assert.Equal(t, tt.expectedOps, ops)
+			assert.Equal(t, tt.expectedIDs, ids(result))
+			assert.Equal(t, tt.expectedTitle, result[0].Title)
+			assert.Equal(t, tt.expectedState, result[1].Status)
+			assert.Len(t, history.Done, 4-len(entries))
+			assert.Len(t, history.Undone, len(entries))
+		})
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	tasks, history := historyFor(t)
+	expected := ids(tasks)
+
+	undone, _, err := history.Undo(tasks, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, ids(undone))
+
+	redone, entries, err := history.Redo(undone, 4)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, journal.OpCreate, entries[0].Op)
+	assert.Equal(t, journal.OpDelete, entries[3].Op)
+	assert.Equal(t, expected, ids(redone))
+	assert.Equal(t, "renamed task", redone[0].Title)
+	assert.Len(t, history.Done, 4)
+	assert.Empty(t, history.Undone)
+
+	_, _, err = history.Redo(redone, 1)
+	assert.ErrorIs(t, err, journal.ErrNothingToRedo)
+}
+
+func TestUndoRedoGroup(t *testing.T) {
+	tasks, history := historyFor(t)
+	now := time.Now()
+
+	// удаление #4, #5 и #6 одной командой - одна операция
+	deleted := make([]journal.Entry, 0, 3)
+	for _, value := range tasks[2:5] {
+		before := value.Clone()
+		value.DeletedAt = &now
+		deleted = append(deleted, journal.NewEntry(journal.OpDelete, before, value, now))
+	}
+	history.Record(deleted...)
+	assert.Equal(t, 5, journal.Operations(history.Done))
+
+	undone, entries, err := history.Undo(tasks, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, 1, journal.Operations(entries))
+	for _, value := range undone[2:5] {
+		assert.False(t, value.IsDeleted())
+	}
+	assert.Len(t, history.Done, 4)
+
+	redone, entries, err := history.Redo(undone, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 5, 6}, []int{entries[0].TaskID, entries[1].TaskID, entries[2].TaskID})
+	for _, value := range redone[2:5] {
+		assert.True(t, value.IsDeleted())
+	}
+
+	// записи без номера операции из журналов старых версий отменяются по одной
+	legacy := &journal.History{Done: slices.Clone(deleted)}
+	for i := range legacy.Done {
+		legacy.Done[i].Group = 0
+	}
+	_, entries, err = legacy.Undo(redone, 1)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestUndoErrors(t *testing.T) {
+	t.Run("пустая история", func(t *testing.T) {
+		history := &journal.History{}
+		_, _, err := history.Undo(testutil.EmptyTasks(), 1)
+		assert.ErrorIs(t, err, journal.ErrNothingToUndo)
+	})
+
+	t.Run("задача удалена в обход истории", func(t *testing.T) {
+		tasks, history := historyFor(t)
+		// удаляем #7, созданную первой операцией, и отменяем все операции
+		tasks = tasks[:len(tasks)-1]
+
+		_, _, err := history.Undo(tasks, 4)
+		assert.ErrorIs(t, err, journal.ErrConflict)
+		// при ошибке журнал не меняется
+		assert.Len(t, history.Done, 4)
+		assert.Empty(t, history.Undone)
+	})
+}
+
+func TestRecord(t *testing.T) {
+	tasks, history := historyFor(t)
+	_, _, err := history.Undo(tasks, 2)
+	require.NoError(t, err)
+	require.Len(t, history.Undone, 2)
+
+	// новая операция сбрасывает отменённые
+	history.Record(journal.NewEntry(journal.OpStart, tasks[0], tasks[0], time.Now()))
+	assert.Empty(t, history.Undone)
+	assert.Equal(t, journal.OpStart, history.Recent()[0].Op)
+
+	for i := 0; i < journal.Limit; i++ {
+		history.Record(journal.NewEntry(journal.OpEdit, tasks[0], tasks[0], time.Now()))
+	}
+	assert.Len(t, history.Done, journal.Limit)
+
+	// лимит считается по операциям: старая операция отбрасывается целиком
+	history.Record(journal.NewEntry(journal.OpEdit, tasks[0], tasks[0], time.Now()),
+		journal.NewEntry(journal.OpEdit, tasks[1], tasks[1], time.Now()))
+	assert.Len(t, history.Done, journal.Limit+1)
+	assert.Equal(t, journal.Limit, journal.Operations(history.Done))
+}
+
+func TestNewEntryCopiesSnapshots(t *testing.T) {
+	tasks, err := testutil.SingleTask()
+	require.NoError(t, err)
+	tasks[0].AddTags("auth")
+
+	entry := journal.NewEntry(journal.OpEdit, tasks[0], tasks[0], time.Now())
+	tasks[0].Title = "changed"
+	tasks[0].AddTags("backend")
+
+	assert.Equal(t, 1, entry.TaskID)
+	assert.Equal(t, "single task", entry.Before.Title)
+	assert.Equal(t, []string{"auth"}, entry.After.Tags)
+}
+
+func TestForget(t *testing.T) {
+	tasks, history := historyFor(t)
+	_, _, err := history.Undo(tasks, 1)
+	require.NoError(t, err)
+
+	// #3 удалена последней операцией, #1 изменена второй
+	history.Forget(3, 1)
+	require.Len(t, history.Done, 2)
+	assert.Equal(t, journal.OpComplete, history.Done[1].Op)
+	assert.Empty(t, history.Undone)
+}
@@ -0,0 +1,66 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+)
+
+const (
+	// количество дней в графиках активности по умолчанию
+	DefaultActivityDays = 14
+	// количество недель в тепловой карте выполненных задач
+	heatmapWeeks = 12
+)
+
+// openAt сообщает, была ли задача открыта (создана и ещё не выполнена) на момент end.
+// Выполненные задачи без даты завершения (созданные до её появления) открытыми не считаются.
+func openAt(value *task.Task, end time.Time) bool {
+	if !value.CreatedAt.Before(end) {
+		return false
+	}
+	if value.Status != task.StatusCompleted {
+		return true
+	}
+	return value.CompletedAt != nil && !value.CompletedAt.Before(end)
+}
+
+// dailyActivity возвращает активность по дням с from до to (не включая to).
+func dailyActivity(tasks []*task.Task, from, to time.Time) []render.DayActivity {
+	days := make([]render.DayActivity, 0)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		activity := render.DayActivity{Date: day}
+		for _, value := range tasks {
+			if !value.CreatedAt.Before(day) && value.CreatedAt.Before(end) {
+				activity.Created += 1
+			}
+			if value.CompletedAt != nil && value.Status == task.StatusCompleted &&
+				!value.CompletedAt.Before(day) && value.CompletedAt.Before(end) {
+				activity.Completed += 1
+			}
+			if openAt(value, end) {
+				activity.Open += 1
+			}
+		}
+		days = append(days, activity)
+	}
+	return days
+}
+
+// GetActivity возвращает данные для графиков активности:
+// созданные, выполненные и открытые на конец дня задачи за последние days дней (включая сегодняшний)
+// и выполненные задачи по дням за последние heatmapWeeks недель, начиная с понедельника, для тепловой карты.
+// Возвращает ошибку, если days не положительное.
+func (f *FilterTasks) GetActivity(tasks []*task.Task, days int, now time.Time) (render.Activity, error) {
+	if days <= 0 {
+		return render.Activity{}, fmt.Errorf("количество дней должно быть положительным: %d", days)
+	}
+	tomorrow := task.StartOfDay(now).AddDate(0, 0, 1)
+	heatmapStart := periodStart(now, PeriodWeek).AddDate(0, 0, -7*(heatmapWeeks-1))
+	return render.Activity{
+		Days:    dailyActivity(tasks, tomorrow.AddDate(0, 0, -days), tomorrow),
+		Heatmap: dailyActivity(tasks, heatmapStart, tomorrow),
+	}, nil
+}
@@ -0,0 +1,75 @@
+//go:build !production
+
+package manager
+
+import (
+	"testing"
+	"time"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAt(t *testing.T) {
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+	tasks := flowTasks(t, now)
+	legacy, err := task.NewTask(7, "legacy task", "description", task.StatusCompleted.String())
+	require.NoError(t, err)
+	legacy.CreatedAt = now.AddDate(0, 0, -10)
+	legacy.CompletedAt = nil
+
+	tests := []struct {
+		name     string
+		task     *task.Task
+		end      time.Time
+		expected bool
+	}{
+		{"невыполненная задача после создания", tasks[0], now, true},
+		{"задача ещё не создана", tasks[0], now.AddDate(0, 0, -6), false},
+		{"выполненная задача до завершения", tasks[3], now.AddDate(0, 0, -2), true},
+		{"выполненная задача после завершения", tasks[3], now, false},
+		{"выполненная задача без даты завершения", legacy, now, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, openAt(tt.task, tt.end))
+		})
+	}
+}
+
+func TestGetActivity(t *testing.T) {
+	// среда, 15 октября 2025
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+	tasks := flowTasks(t, now)
+	filter := &FilterTasks{}
+
+	activity, err := filter.GetActivity(tasks, 10, now)
+	require.NoError(t, err)
+
+	require.Len(t, activity.Days, 10)
+	assert.Equal(t, time.Date(2025, time.October, 6, 0, 0, 0, 0, time.UTC), activity.Days[0].Date)
+	assert.Equal(t, time.Date(2025, time.October, 15, 0, 0, 0, 0, time.UTC), activity.Days[9].Date)
+	// #4 создана 10 дней назад - раньше периода, #1 - 5 дней назад
+	assert.Equal(t, 1, activity.Days[4].Created)
+	// #5 выполнена 9 дней назад, #4 - вчера
+	assert.Equal(t, 1, activity.Days[0].Completed)
+	assert.Equal(t, 1, activity.Days[8].Completed)
+	// на 6 октября открыты #2, #3 и #4, на сегодня - #1, #2 и #3
+	assert.Equal(t, 3, activity.Days[0].Open)
+	assert.Equal(t, 3, activity.Days[9].Open)
+
+	// тепловая карта начинается с понедельника и заканчивается сегодняшним днём
+	require.Len(t, activity.Heatmap, 7*(heatmapWeeks-1)+3)
+	assert.Equal(t, time.Monday, activity.Heatmap[0].Date.Weekday())
+	completed := 0
+	for _, day := range activity.Heatmap {
+		completed += day.Completed
+	}
+	assert.Equal(t, 3, completed)
+
+	_, err = filter.GetActivity(testutil.EmptyTasks(), 0, now)
+	assert.Error(t, err)
+}
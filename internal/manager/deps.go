@@ -0,0 +1,140 @@
+package manager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+)
+
+// idsLabel возвращает ID задач в формате #1, #2 для сообщений об ошибках.
+// sep - разделитель между ID.
+func idsLabel(ids []int, sep string) string {
+	labels := make([]string, 0, len(ids))
+	for _, id := range ids {
+		labels = append(labels, fmt.Sprintf("#%d", id))
+	}
+	return strings.Join(labels, sep)
+}
+
+// Block добавляет задаче id зависимости от задач by: её нельзя начать, пока они не выполнены (см. Start).
+// Блокирующие задачи должны существовать и не находиться в корзине.
+// Выводит детальную информацию об обновлённой задаче.
+// Возвращает ошибку task.ErrDependencyCycle, если задача зависит от себя или одна из задач by
+// уже ждёт задачу id напрямую или через другие задачи, ошибку, если задача не найдена
+// или произошла ошибка при сохранении.
+func (m *Manager) Block(id int, by []int) error {
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return err
+	}
+	for _, blocker := range by {
+		if _, err := activeIndex(m, tasks, blocker); err != nil {
+			return fmt.Errorf("блокирующая задача: %w", err)
+		}
+		if path := task.DependencyPath(tasks, blocker, id); path != nil {
+			return fmt.Errorf("%w: #%d не может ждать #%d, получится цепочка %s",
+				task.ErrDependencyCycle, id, blocker, idsLabel(append([]int{id}, path...), " → "))
+		}
+	}
+	before := tasks[*indexTask].Clone()
+	tasks[*indexTask].AddBlockers(by...)
+
+	err = m.store.Save(tasks, m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при записи: %w", err)
+	}
+	err = m.record(journal.OpBlock, before, tasks[*indexTask])
+	if err != nil {
+		return err
+	}
+	m.render.RenderDetailed(tasks[*indexTask])
+	return nil
+}
+
+// Unblock снимает с задачи id зависимости от задач by, при пустом by - все зависимости.
+// Выводит детальную информацию об обновлённой задаче.
+// Возвращает ошибку, если задача не найдена или произошла ошибка при сохранении.
+func (m *Manager) Unblock(id int, by []int) error {
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return err
+	}
+	before := tasks[*indexTask].Clone()
+	if len(by) == 0 {
+		by = slices.Clone(tasks[*indexTask].BlockedBy)
+	}
+	tasks[*indexTask].RemoveBlockers(by...)
+
+	err = m.store.Save(tasks, m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при записи: %w", err)
+	}
+	err = m.record(journal.OpUnblock, before, tasks[*indexTask])
+	if err != nil {
+		return err
+	}
+	m.render.RenderDetailed(tasks[*indexTask])
+	return nil
+}
+
+// Graph выводит граф зависимостей задач в формате render.GraphText или render.GraphDOT.
+// В граф попадают задачи вне корзины, которые ждут других задач или блокируют их.
+// Возвращает ошибку render.ErrUnknownFormat для неизвестного формата, ошибку,
+// если у задач нет зависимостей или произошла ошибка при загрузке.
+func (m *Manager) Graph(format string) error {
+	if format != render.GraphText && format != render.GraphDOT {
+		return fmt.Errorf("ошибка (%w) графа: %s", render.ErrUnknownFormat, format)
+	}
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	tasks = m.filter.GetTasksByDeleted(tasks, false)
+	active := make(map[int]bool, len(tasks))
+	for _, value := range tasks {
+		active[value.ID] = true
+	}
+	graph := render.Graph{Tasks: make([]*task.Task, 0), Dependencies: make([]render.Dependency, 0)}
+	linked := make(map[int]bool)
+	for _, value := range tasks {
+		for _, blocker := range value.BlockedBy {
+			if !active[blocker] {
+				continue
+			}
+			graph.Dependencies = append(graph.Dependencies, render.Dependency{Task: value.ID, BlockedBy: blocker})
+			linked[value.ID] = true
+			linked[blocker] = true
+		}
+	}
+	if len(graph.Dependencies) == 0 {
+		return fmt.Errorf("у задач нет зависимостей")
+	}
+	for _, value := range tasks {
+		if linked[value.ID] {
+			graph.Tasks = append(graph.Tasks, value)
+		}
+	}
+	m.render.RenderGraph(graph, format)
+	return nil
+}
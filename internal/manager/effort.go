@@ -0,0 +1,87 @@
+package manager
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+)
+
+// значение оценки, которое снимает оценку с задачи при редактировании
+const estimateNone = "none"
+
+// parseEstimate разбирает оценку задачи из пользовательского ввода.
+// Пустая строка и "none" означают отсутствие оценки и возвращают nil.
+func parseEstimate(value string) (*task.Estimate, error) {
+	if value == "" || value == estimateNone {
+		return nil, nil
+	}
+	estimate, err := task.ParseEstimate(value)
+	if err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
+
+// addEffort добавляет задачу к суммарным оценкам: оценку временем или в story points, затраченное время
+// и, для невыполненной задачи с оценкой временем, оставшуюся работу (превышение оценки не уменьшает остаток).
+func addEffort(effort *render.Effort, value *task.Task, now time.Time) {
+	effort.Tasks += 1
+	effort.Spent += value.TimeSpent(now)
+	if value.Estimate == nil {
+		return
+	}
+	effort.Estimated += 1
+	if value.Estimate.IsPoints() {
+		effort.Points += value.Estimate.Points
+		return
+	}
+	effort.Duration += value.Estimate.Duration
+	if remaining, ok := value.Remaining(now); ok && value.Status != task.StatusCompleted {
+		effort.Remaining += max(remaining, 0)
+	}
+}
+
+// GetEffortStats возвращает суммарные оценки и затраченное время по статусам (pending, in_progress, completed),
+// по тегам в алфавитном порядке и по всем задачам. Задача с несколькими тегами учитывается в каждом из них.
+func (f *FilterTasks) GetEffortStats(tasks []*task.Task, now time.Time) render.EffortStats {
+	stats := render.EffortStats{ByStatus: []render.StatusEffort{
+		{Status: task.StatusPending}, {Status: task.StatusProgress}, {Status: task.StatusCompleted},
+	}}
+	byTag := make(map[string]*render.Effort)
+	for _, value := range tasks {
+		addEffort(&stats.Total, value, now)
+		for i := range stats.ByStatus {
+			if stats.ByStatus[i].Status == value.Status {
+				addEffort(&stats.ByStatus[i].Effort, value, now)
+			}
+		}
+		for _, tag := range value.Tags {
+			if byTag[tag] == nil {
+				byTag[tag] = &render.Effort{}
+			}
+			addEffort(byTag[tag], value, now)
+		}
+	}
+	stats.ByTag = make([]render.TagEffort, 0, len(byTag))
+	for tag, effort := range byTag {
+		stats.ByTag = append(stats.ByTag, render.TagEffort{Tag: tag, Effort: *effort})
+	}
+	sort.Slice(stats.ByTag, func(i, j int) bool {
+		return stats.ByTag[i].Tag < stats.ByTag[j].Tag
+	})
+	return stats
+}
+
+// Effort выводит суммарные оценки задач, затраченное и оставшееся время по статусам и тегам
+// (см. Filter.GetEffortStats). Задачи из корзины не учитываются.
+// Возвращает ошибку при загрузке задач.
+func (m *Manager) Effort() error {
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	m.render.RenderEffortStats(m.filter.GetEffortStats(m.filter.GetTasksByDeleted(tasks, false), time.Now()))
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build !production
+
+package manager
+
+import (
+	"testing"
+	"time"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEffortStats(t *testing.T) {
+	now := time.Date(2025, time.October, 15, 12, 0, 0, 0, time.UTC)
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	// #1 pending: 2h, +backend; #2 pending: 3pt, +backend +api; #3 in_progress: 4h, затрачено 5ч;
+	// #4 completed: 1h, затрачено 30м - выполненная задача не добавляет остаток
+	tasks[0].Estimate = &task.Estimate{Duration: 2 * time.Hour}
+	tasks[0].AddTags("backend")
+	tasks[1].Estimate = &task.Estimate{Points: 3}
+	tasks[1].AddTags("backend", "api")
+	tasks[2].Estimate = &task.Estimate{Duration: 4 * time.Hour}
+	tasks[2].TimeLog = []task.Interval{{Start: now.Add(-5 * time.Hour), End: &now}}
+	tasks[3].Estimate = &task.Estimate{Duration: time.Hour}
+	tasks[3].TimeLog = []task.Interval{{Start: now.Add(-30 * time.Minute), End: &now}}
+
+	stats := (&FilterTasks{}).GetEffortStats(tasks, now)
+
+	assert.Equal(t, []render.StatusEffort{
+		{Status: task.StatusPending, Effort: render.Effort{Tasks: 2, Estimated: 2, Duration: 2 * time.Hour, Points: 3, Remaining: 2 * time.Hour}},
+		{Status: task.StatusProgress, Effort: render.Effort{Tasks: 1, Estimated: 1, Duration: 4 * time.Hour, Spent: 5 * time.Hour}},
+		{Status: task.StatusCompleted, Effort: render.Effort{Tasks: 3, Estimated: 1, Duration: time.Hour, Spent: 30 * time.Minute}},
+	}, stats.ByStatus)
+	assert.Equal(t, []render.TagEffort{
+		{Tag: "api", Effort: render.Effort{Tasks: 1, Estimated: 1, Points: 3}},
+		{Tag: "backend", Effort: render.Effort{Tasks: 2, Estimated: 2, Duration: 2 * time.Hour, Points: 3, Remaining: 2 * time.Hour}},
+	}, stats.ByTag)
+	assert.Equal(t, render.Effort{
+		Tasks: 6, Estimated: 4, Duration: 7 * time.Hour, Points: 3, Spent: 5*time.Hour + 30*time.Minute, Remaining: 2 * time.Hour,
+	}, stats.Total)
+}
@@ -2,7 +2,10 @@ package manager
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
+	"todo_cli/internal/render"
 	"todo_cli/internal/task"
 )
 
@@ -10,8 +13,23 @@ import (
 type Filter interface {
 	GetIndexByID(tasks []*task.Task, id int) *int
 	GetTasksByStatus(tasks []*task.Task, status task.Status) ([]*task.Task, error)
-	GetStatsTasksByStatus(tasks []*task.Task) map[string]interface{}
+	GetStatsTasksByStatus(tasks []*task.Task) render.StatusStats
 	GetTasksBySearchWord(tasks []*task.Task, word string) []*task.Task
+	GetTasksByPriority(tasks []*task.Task, priority task.Priority) ([]*task.Task, error)
+	SortTasksByPriority(tasks []*task.Task) []*task.Task
+	GetOverdueTasks(tasks []*task.Task, now time.Time) []*task.Task
+	GetTasksDueBefore(tasks []*task.Task, date time.Time) []*task.Task
+	GetTasksDueOn(tasks []*task.Task, date time.Time) []*task.Task
+	GetTasksByTags(tasks []*task.Task, tags []string, matchAll bool) []*task.Task
+	GetStatsTasksByTag(tasks []*task.Task) []render.TagStats
+	GetEffortStats(tasks []*task.Task, now time.Time) render.EffortStats
+	GetTasksByDeleted(tasks []*task.Task, deleted bool) []*task.Task
+	GetTasksByBlocked(tasks []*task.Task, all []*task.Task, blocked bool) []*task.Task
+	GetRecurring(tasks []*task.Task, all []*task.Task, now time.Time) []render.Series
+	GetFlowStats(tasks []*task.Task, period string, now time.Time) (render.FlowStats, error)
+	GetActivity(tasks []*task.Task, days int, now time.Time) (render.Activity, error)
+	GetTimeLog(tasks []*task.Task, by string, days int, now time.Time) (render.TimeLog, error)
+	GetTimeReport(tasks []*task.Task, period string, now time.Time) (render.TimeReport, error)
 }
 
 type FilterTasks struct{}
@@ -27,6 +45,32 @@ func (f *FilterTasks) GetIndexByID(tasks []*task.Task, id int) *int {
 	return nil
 }
 
+// GetTasksByDeleted возвращает задачи из корзины при deleted = true, иначе - задачи вне корзины.
+func (f *FilterTasks) GetTasksByDeleted(tasks []*task.Task, deleted bool) []*task.Task {
+	filteredTasks := make([]*task.Task, 0, len(tasks))
+	for _, value := range tasks {
+		if value.IsDeleted() == deleted {
+			filteredTasks = append(filteredTasks, value)
+		}
+	}
+	return filteredTasks
+}
+
+// GetTasksByBlocked возвращает невыполненные задачи, которые ждут завершения других задач при blocked = true,
+// иначе - невыполненные задачи, готовые к работе. Статусы блокирующих задач ищутся в all (см. task.OpenBlockers).
+func (f *FilterTasks) GetTasksByBlocked(tasks []*task.Task, all []*task.Task, blocked bool) []*task.Task {
+	filteredTasks := make([]*task.Task, 0, len(tasks))
+	for _, value := range tasks {
+		if value.Status == task.StatusCompleted {
+			continue
+		}
+		if (len(task.OpenBlockers(all, value)) > 0) == blocked {
+			filteredTasks = append(filteredTasks, value)
+		}
+	}
+	return filteredTasks
+}
+
 // GetTasksByStatus возвращает новый слайс задач, отфильтрованных по заданному статусу.
 // Возвращает ошибку task.ErrInvalidStatus, если переданный статус невалиден.
 func (f *FilterTasks) GetTasksByStatus(tasks []*task.Task, status task.Status) ([]*task.Task, error) {
@@ -44,28 +88,24 @@ func (f *FilterTasks) GetTasksByStatus(tasks []*task.Task, status task.Status) (
 
 // GetStatsTasksByStatus возвращает статистику задач, сгруппированных по статусам.
 // Метод подсчитывает общее количество задач и количество задач в каждом статусе:
-// Pending (ожидает), Progress (в работе), Completed (выполнено).
-func (f *FilterTasks) GetStatsTasksByStatus(tasks []*task.Task) map[string]interface{} {
-	var allTask, completed, progress, pending int
-	allTask = len(tasks)
+// Pending (ожидает), Progress (в работе), Completed (выполнено), а также количество просроченных задач.
+func (f *FilterTasks) GetStatsTasksByStatus(tasks []*task.Task) render.StatusStats {
+	stats := render.StatusStats{Total: len(tasks)}
+	now := time.Now()
 	for _, value := range tasks {
-		if value.Status == task.StatusPending {
-			pending += 1
+		if value.IsOverdue(now) {
+			stats.Overdue += 1
 		}
-		if value.Status == task.StatusProgress {
-			progress += 1
+		switch value.Status {
+		case task.StatusPending:
+			stats.Pending += 1
+		case task.StatusProgress:
+			stats.InProgress += 1
+		case task.StatusCompleted:
+			stats.Completed += 1
 		}
-		if value.Status == task.StatusCompleted {
-			completed += 1
-		}
-	}
-	data := map[string]interface{}{
-		"Всего задач:": allTask,
-		"Выполнено":    completed,
-		"В работе":     progress,
-		"Ожидает":      pending,
 	}
-	return data
+	return stats
 }
 
 // GetTasksBySearchWord возвращает задачи, содержащие указанное слово в названии или описании.
@@ -82,3 +122,111 @@ func (f *FilterTasks) GetTasksBySearchWord(tasks []*task.Task, word string) []*t
 	}
 	return foundTasks
 }
+
+// GetTasksByPriority возвращает новый слайс задач с указанным приоритетом.
+// Возвращает ошибку task.ErrInvalidPriority, если переданный приоритет невалиден.
+func (f *FilterTasks) GetTasksByPriority(tasks []*task.Task, priority task.Priority) ([]*task.Task, error) {
+	if !priority.Valid() {
+		return nil, fmt.Errorf("ошибка валидации (%w): %s", task.ErrInvalidPriority, priority)
+	}
+	filteredTasks := make([]*task.Task, 0, len(tasks))
+	for _, value := range tasks {
+		if value.Priority == priority {
+			filteredTasks = append(filteredTasks, value)
+		}
+	}
+	return filteredTasks, nil
+}
+
+// SortTasksByPriority возвращает новый слайс задач, отсортированный от критичных к задачам без приоритета.
+// Задачи с одинаковым приоритетом сохраняют порядок по ID.
+func (f *FilterTasks) SortTasksByPriority(tasks []*task.Task) []*task.Task {
+	sortedTasks := make([]*task.Task, len(tasks))
+	copy(sortedTasks, tasks)
+	sort.SliceStable(sortedTasks, func(i, j int) bool {
+		if sortedTasks[i].Priority.Rank() != sortedTasks[j].Priority.Rank() {
+			return sortedTasks[i].Priority.Rank() > sortedTasks[j].Priority.Rank()
+		}
+		return sortedTasks[i].ID < sortedTasks[j].ID
+	})
+	return sortedTasks
+}
+
+// GetOverdueTasks возвращает незавершённые задачи, срок которых истёк до текущего дня.
+func (f *FilterTasks) GetOverdueTasks(tasks []*task.Task, now time.Time) []*task.Task {
+	filteredTasks := make([]*task.Task, 0, len(tasks))
+	for _, value := range tasks {
+		if value.IsOverdue(now) {
+			filteredTasks = append(filteredTasks, value)
+		}
+	}
+	return filteredTasks
+}
+
+// GetTasksDueBefore возвращает задачи, срок которых наступает раньше указанного дня.
+// Задачи без срока в результат не попадают.
+func (f *FilterTasks) GetTasksDueBefore(tasks []*task.Task, date time.Time) []*task.Task {
+	filteredTasks := make([]*task.Task, 0, len(tasks))
+	day := task.StartOfDay(date)
+	for _, value := range tasks {
+		if value.Due != nil && task.StartOfDay(*value.Due).Before(day) {
+			filteredTasks = append(filteredTasks, value)
+		}
+	}
+	return filteredTasks
+}
+
+// GetTasksDueOn возвращает задачи, срок которых приходится на указанный день.
+func (f *FilterTasks) GetTasksDueOn(tasks []*task.Task, date time.Time) []*task.Task {
+	filteredTasks := make([]*task.Task, 0, len(tasks))
+	day := task.StartOfDay(date)
+	for _, value := range tasks {
+		if value.Due != nil && task.StartOfDay(*value.Due).Equal(day) {
+			filteredTasks = append(filteredTasks, value)
+		}
+	}
+	return filteredTasks
+}
+
+// GetTasksByTags возвращает задачи, отмеченные указанными тегами.
+// При matchAll = true задача должна содержать все теги (AND), иначе хотя бы один (OR).
+// Пустой список тегов не фильтрует задачи.
+func (f *FilterTasks) GetTasksByTags(tasks []*task.Task, tags []string, matchAll bool) []*task.Task {
+	if len(tags) == 0 {
+		return tasks
+	}
+	filteredTasks := make([]*task.Task, 0, len(tasks))
+	for _, value := range tasks {
+		matched := 0
+		for _, tag := range tags {
+			if value.HasTag(tag) {
+				matched += 1
+			}
+		}
+		if (matchAll && matched == len(tags)) || (!matchAll && matched > 0) {
+			filteredTasks = append(filteredTasks, value)
+		}
+	}
+	return filteredTasks
+}
+
+// GetStatsTasksByTag возвращает статистику по статусам для каждого тега, отсортированную по тегу.
+// Для каждого тега задачи отбираются через GetTasksByTags и считаются через GetStatsTasksByStatus.
+func (f *FilterTasks) GetStatsTasksByTag(tasks []*task.Task) []render.TagStats {
+	seen := make(map[string]bool)
+	stats := make([]render.TagStats, 0)
+	for _, value := range tasks {
+		for _, tag := range value.Tags {
+			if seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			stats = append(stats, render.TagStats{
+				Tag:   tag,
+				Stats: f.GetStatsTasksByStatus(f.GetTasksByTags(tasks, []string{tag}, true)),
+			})
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Tag < stats[j].Tag })
+	return stats
+}
@@ -4,6 +4,8 @@ package manager
 
 import (
 	"testing"
+	"time"
+	"todo_cli/internal/render"
 	"todo_cli/internal/task"
 	"todo_cli/internal/testutil"
 
@@ -105,6 +107,8 @@ func TestGetTasksBySearchWord(t *testing.T) {
 }
 
 func TestGetStatsTasksByStatus(t *testing.T) {
+	tasksDue, err := testutil.DueTasks(time.Now())
+	require.NoError(t, err, "не должно быть ошибки при создании задачи")
 	tasksEmpty := testutil.EmptyTasks()
 	tasksSingle, err := testutil.SingleTask()
 	require.NoError(t, err, "не должно быть ошибки при создании задачи")
@@ -113,11 +117,12 @@ func TestGetStatsTasksByStatus(t *testing.T) {
 	tests := []struct {
 		name          string
 		tasks         []*task.Task
-		expectedStats map[string]interface{}
+		expectedStats render.StatusStats
 	}{
-		{"пустой список задач", tasksEmpty, testutil.StatsTask(0, 0, 0, 0)},
-		{"одна задача в статусе pending", tasksSingle, testutil.StatsTask(1, 0, 0, 1)},
-		{"несколько задач с разными статусами (2-pending; 1-progress; 3-completed)", tasksMany, testutil.StatsTask(6, 3, 1, 2)},
+		{"пустой список задач", tasksEmpty, testutil.StatsTask(0, 0, 0, 0, 0)},
+		{"одна задача в статусе pending", tasksSingle, testutil.StatsTask(1, 0, 0, 1, 0)},
+		{"несколько задач с разными статусами (2-pending; 1-progress; 3-completed)", tasksMany, testutil.StatsTask(6, 3, 1, 2, 0)},
+		{"задачи со сроками (1 просрочена)", tasksDue, testutil.StatsTask(5, 2, 1, 2, 1)},
 	}
 	filter := &FilterTasks{}
 	for _, tt := range tests {
@@ -127,3 +132,173 @@ func TestGetStatsTasksByStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTasksByPriority(t *testing.T) {
+	tasksEmpty := testutil.EmptyTasks()
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err, "не должно быть ошибки при создании задач")
+
+	tests := []struct {
+		name          string
+		tasks         []*task.Task
+		priority      task.Priority
+		expectedCount int
+		expectedErr   error
+	}{
+		{"пустой список задач", tasksEmpty, task.PriorityHigh, 0, nil},
+		{"фильтр по приоритету high - 2 задачи", tasksMany, task.PriorityHigh, 2, nil},
+		{"фильтр по приоритету critical - 1 задача", tasksMany, task.PriorityCritical, 1, nil},
+		{"задачи без приоритета - 1 задача", tasksMany, task.PriorityNone, 1, nil},
+		{"невалидный приоритет - ошибка", tasksMany, task.Priority("urgent"), 0, task.ErrInvalidPriority},
+	}
+
+	filter := &FilterTasks{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := filter.GetTasksByPriority(tt.tasks, tt.priority)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, tt.expectedCount)
+				for _, tsk := range result {
+					assert.Equal(t, tt.priority, tsk.Priority)
+				}
+			}
+		})
+	}
+}
+
+func TestSortTasksByPriority(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err, "не должно быть ошибки при создании задач")
+
+	filter := &FilterTasks{}
+	result := filter.SortTasksByPriority(tasksMany)
+
+	ids := make([]int, 0, len(result))
+	for _, tsk := range result {
+		ids = append(ids, tsk.ID)
+	}
+	// critical, high, high, medium, low, без приоритета
+	assert.Equal(t, []int{3, 2, 6, 4, 1, 5}, ids)
+	// исходный слайс не изменяется
+	assert.Equal(t, 1, tasksMany[0].ID)
+}
+
+func TestGetTasksByDue(t *testing.T) {
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.Local)
+	tasksDue, err := testutil.DueTasks(now)
+	require.NoError(t, err, "не должно быть ошибки при создании задач")
+
+	filter := &FilterTasks{}
+	ids := func(tasks []*task.Task) []int {
+		result := make([]int, 0, len(tasks))
+		for _, tsk := range tasks {
+			result = append(result, tsk.ID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		result   []*task.Task
+		expected []int
+	}{
+		{"просроченные задачи", filter.GetOverdueTasks(tasksDue, now), []int{1}},
+		{"срок сегодня", filter.GetTasksDueOn(tasksDue, now), []int{2}},
+		{"срок раньше завтра", filter.GetTasksDueBefore(tasksDue, now.AddDate(0, 0, 1)), []int{1, 2, 5}},
+		{"срок раньше сегодня", filter.GetTasksDueBefore(tasksDue, now), []int{1, 5}},
+		{"пустой список", filter.GetOverdueTasks(testutil.EmptyTasks(), now), []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ids(tt.result))
+		})
+	}
+}
+
+func TestGetTasksByTags(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err, "не должно быть ошибки при создании задач")
+	tasksMany[0].AddTags("auth", "backend")
+	tasksMany[1].AddTags("backend")
+	tasksMany[2].AddTags("frontend")
+
+	tests := []struct {
+		name          string
+		tags          []string
+		matchAll      bool
+		expectedCount int
+	}{
+		{"без тегов - все задачи", []string{}, true, 6},
+		{"один тег", []string{"backend"}, true, 2},
+		{"все теги (AND)", []string{"auth", "backend"}, true, 1},
+		{"любой тег (OR)", []string{"auth", "frontend"}, false, 2},
+		{"несуществующий тег", []string{"docs"}, false, 0},
+	}
+
+	filter := &FilterTasks{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := filter.GetTasksByTags(tasksMany, tt.tags, tt.matchAll)
+			assert.Len(t, result, tt.expectedCount)
+		})
+	}
+}
+
+func TestGetTasksByDeleted(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err, "не должно быть ошибки при создании задач")
+	now := time.Now()
+	tasksMany[0].DeletedAt = &now
+	tasksMany[4].DeletedAt = &now
+
+	filter := &FilterTasks{}
+	deleted := filter.GetTasksByDeleted(tasksMany, true)
+	active := filter.GetTasksByDeleted(tasksMany, false)
+
+	assert.Equal(t, []*task.Task{tasksMany[0], tasksMany[4]}, deleted)
+	assert.Len(t, active, 4)
+	assert.NotContains(t, active, tasksMany[0])
+}
+
+func TestGetStatsTasksByTag(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err, "не должно быть ошибки при создании задач")
+	tasksMany[0].AddTags("docs")
+	tasksMany[0].AddTags("backend")
+	tasksMany[2].AddTags("backend")
+
+	filter := &FilterTasks{}
+	result := filter.GetStatsTasksByTag(tasksMany)
+
+	// теги идут в алфавитном порядке независимо от порядка задач
+	expected := []render.TagStats{
+		{Tag: "backend", Stats: testutil.StatsTask(2, 0, 1, 1, 0)},
+		{Tag: "docs", Stats: testutil.StatsTask(1, 0, 0, 1, 0)},
+	}
+	assert.Equal(t, expected, result)
+	assert.Empty(t, filter.GetStatsTasksByTag(testutil.EmptyTasks()))
+}
+
+func TestGetTasksByBlocked(t *testing.T) {
+	tasks := dependencyTasks(t)
+	filter := &FilterTasks{}
+
+	assert.Equal(t, []*task.Task{tasks[0], tasks[1]}, filter.GetTasksByBlocked(tasks, tasks, true))
+	assert.Equal(t, []*task.Task{tasks[2]}, filter.GetTasksByBlocked(tasks, tasks, false))
+
+	// статусы блокирующих задач берутся из полного списка, а не из отфильтрованного
+	assert.Equal(t, []*task.Task{tasks[0]}, filter.GetTasksByBlocked(tasks[:1], tasks, true))
+
+	// #3 выполнена - #2 больше не заблокирована, а задача в корзине не блокирует
+	tasks[2].Status = task.StatusCompleted
+	deleted := time.Now()
+	tasks[1].DeletedAt = &deleted
+	assert.Empty(t, filter.GetTasksByBlocked(tasks, tasks, true))
+}
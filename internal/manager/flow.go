@@ -0,0 +1,115 @@
+package manager
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+)
+
+// допустимые периоды статистики потока задач
+const (
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+const (
+	// сколько последних периодов (включая текущий) попадает в статистику потока
+	flowPeriods = 6
+	// сколько самых старых открытых задач выводится в статистике
+	flowOldest = 5
+)
+
+// periodStart возвращает начало периода, в который попадает t: понедельник недели или первое число месяца.
+func periodStart(t time.Time, period string) time.Time {
+	day := task.StartOfDay(t)
+	if period == PeriodMonth {
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	// time.Weekday начинается с воскресенья, неделя - с понедельника
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// nextPeriod возвращает начало периода, следующего за периодом, начинающимся в start.
+func nextPeriod(start time.Time, period string) time.Time {
+	if period == PeriodMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+// percentile возвращает перцентиль p (0..100) отсортированных длительностей методом ближайшего ранга.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// durationStats возвращает среднее, медиану и 90-й перцентиль длительностей.
+func durationStats(durations []time.Duration) render.DurationStats {
+	if len(durations) == 0 {
+		return render.DurationStats{}
+	}
+	slices.Sort(durations)
+	var total time.Duration
+	for _, value := range durations {
+		total += value
+	}
+	return render.DurationStats{
+		Count:   len(durations),
+		Average: total / time.Duration(len(durations)),
+		P50:     percentile(durations, 50),
+		P90:     percentile(durations, 90),
+	}
+}
+
+// GetFlowStats возвращает показатели потока задач за последние flowPeriods недель или месяцев до now:
+// количество выполненных задач в каждом периоде (от старого к текущему), lead time (создание → завершение)
+// и cycle time (начало → завершение) задач, выполненных за эти периоды, и самые старые невыполненные задачи.
+// Задачи без даты завершения (созданные до её появления) в пропускной способности и времени не учитываются.
+// Возвращает ошибку task.ErrInvalidPeriod, если период не week и не month.
+func (f *FilterTasks) GetFlowStats(tasks []*task.Task, period string, now time.Time) (render.FlowStats, error) {
+	if period != PeriodWeek && period != PeriodMonth {
+		return render.FlowStats{}, fmt.Errorf("ошибка валидации (%w): %s", task.ErrInvalidPeriod, period)
+	}
+	stats := render.FlowStats{Period: period, Throughput: make([]render.PeriodCount, flowPeriods)}
+	start := periodStart(now, period)
+	for i := flowPeriods - 1; i >= 0; i-- {
+		stats.Throughput[i].Start = start
+		start = periodStart(start.AddDate(0, 0, -1), period)
+	}
+	windowStart := stats.Throughput[0].Start
+
+	leadTimes := make([]time.Duration, 0)
+	cycleTimes := make([]time.Duration, 0)
+	open := make([]*task.Task, 0)
+	for _, value := range tasks {
+		if value.Status != task.StatusCompleted {
+			open = append(open, value)
+			continue
+		}
+		if value.CompletedAt == nil || value.CompletedAt.Before(windowStart) {
+			continue
+		}
+		for i := range stats.Throughput {
+			if value.CompletedAt.Before(nextPeriod(stats.Throughput[i].Start, period)) {
+				stats.Throughput[i].Completed += 1
+				break
+			}
+		}
+		leadTimes = append(leadTimes, value.CompletedAt.Sub(value.CreatedAt))
+		if cycleTime, ok := value.CycleTime(); ok {
+			cycleTimes = append(cycleTimes, cycleTime)
+		}
+	}
+	stats.LeadTime = durationStats(leadTimes)
+	stats.CycleTime = durationStats(cycleTimes)
+
+	slices.SortStableFunc(open, func(a, b *task.Task) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	stats.Oldest = open[:min(len(open), flowOldest)]
+	return stats, nil
+}
@@ -0,0 +1,112 @@
+//go:build !production
+
+package manager
+
+import (
+	"testing"
+	"time"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeriodStart(t *testing.T) {
+	// среда, 15 октября 2025
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    time.Time
+		period   string
+		expected time.Time
+	}{
+		{"неделя с понедельника", now, PeriodWeek, time.Date(2025, time.October, 13, 0, 0, 0, 0, time.UTC)},
+		{"воскресенье - конец недели", time.Date(2025, time.October, 19, 23, 0, 0, 0, time.UTC), PeriodWeek,
+			time.Date(2025, time.October, 13, 0, 0, 0, 0, time.UTC)},
+		{"месяц с первого числа", now, PeriodMonth, time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, periodStart(tt.value, tt.period))
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	assert.Equal(t, time.Duration(5), percentile(durations, 50))
+	assert.Equal(t, time.Duration(9), percentile(durations, 90))
+	assert.Equal(t, time.Duration(1), percentile(durations, 0))
+	assert.Equal(t, time.Duration(0), percentile(nil, 50))
+}
+
+// flowTasks возвращает задачи ManyTasks с датами для статистики потока относительно now (среда):
+// #4 создана 10 дней назад, начата 3 дня назад и выполнена вчера (текущая неделя),
+// #5 создана 20 дней назад и выполнена 9 дней назад без перевода в работу (позапрошлая неделя),
+// #6 создана 30 дней назад, начата и выполнена 60 дней назад - вне статистики за 6 недель.
+// Невыполненные #1-#3 созданы 5, 40 и 15 дней назад.
+func flowTasks(t *testing.T, now time.Time) []*task.Task {
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	day := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	for i, days := range []int{5, 40, 15, 10, 20, 30} {
+		tasks[i].CreatedAt = day(days)
+	}
+	tasks[3].StartedAt = testutil.TimePtr(day(3))
+	tasks[3].CompletedAt = testutil.TimePtr(day(1))
+	tasks[4].CompletedAt = testutil.TimePtr(day(9))
+	tasks[5].StartedAt = testutil.TimePtr(day(61))
+	tasks[5].CompletedAt = testutil.TimePtr(day(60))
+	return tasks
+}
+
+func TestGetFlowStats(t *testing.T) {
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+	tasks := flowTasks(t, now)
+	filter := &FilterTasks{}
+
+	stats, err := filter.GetFlowStats(tasks, PeriodWeek, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, PeriodWeek, stats.Period)
+	require.Len(t, stats.Throughput, flowPeriods)
+	assert.Equal(t, time.Date(2025, time.September, 8, 0, 0, 0, 0, time.UTC), stats.Throughput[0].Start)
+	assert.Equal(t, time.Date(2025, time.October, 13, 0, 0, 0, 0, time.UTC), stats.Throughput[5].Start)
+	completed := make([]int, 0, flowPeriods)
+	for _, count := range stats.Throughput {
+		completed = append(completed, count.Completed)
+	}
+	assert.Equal(t, []int{0, 0, 0, 0, 1, 1}, completed)
+
+	assert.Equal(t, 2, stats.LeadTime.Count)
+	assert.Equal(t, 10*24*time.Hour, stats.LeadTime.Average)
+	assert.Equal(t, 9*24*time.Hour, stats.LeadTime.P50)
+	assert.Equal(t, 11*24*time.Hour, stats.LeadTime.P90)
+	assert.Equal(t, 1, stats.CycleTime.Count)
+	assert.Equal(t, 2*24*time.Hour, stats.CycleTime.Average)
+
+	// невыполненные задачи от самой старой
+	require.Len(t, stats.Oldest, 3)
+	assert.Equal(t, []int{2, 3, 1}, []int{stats.Oldest[0].ID, stats.Oldest[1].ID, stats.Oldest[2].ID})
+}
+
+func TestGetFlowStatsMonth(t *testing.T) {
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+	filter := &FilterTasks{}
+
+	stats, err := filter.GetFlowStats(flowTasks(t, now), PeriodMonth, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC), stats.Throughput[0].Start)
+	// #6 выполнена в августе, #4 и #5 - в октябре
+	assert.Equal(t, 1, stats.Throughput[3].Completed)
+	assert.Equal(t, 2, stats.Throughput[5].Completed)
+	assert.Equal(t, 3, stats.LeadTime.Count)
+
+	_, err = filter.GetFlowStats(flowTasks(t, now), "year", now)
+	assert.ErrorIs(t, err, task.ErrInvalidPeriod)
+}
@@ -0,0 +1,192 @@
+package manager
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+	"unicode/utf8"
+)
+
+// Codec преобразует задачи в формат файла обмена и обратно (реализуется storage.JsonCodec, storage.CsvCodec).
+// Decode возвращает задачи и ошибки по записям файла: для записи, которую не удалось разобрать, задача равна nil,
+// а ошибка вместе с задачей - замечание к записи, которая загружается не полностью.
+type Codec interface {
+	Encode(tasks []*task.Task) ([]byte, error)
+	Decode(data []byte) ([]*task.Task, []error, error)
+}
+
+// ExportWarner описывает преобразователь, формат которого передаёт не все значения полей задач.
+// Реализуется преобразователем опционально: замечания выводятся после выгрузки (см. Manager.Export).
+type ExportWarner interface {
+	ExportWarnings(tasks []*task.Task) []error
+}
+
+// importKey возвращает ключ поиска дубликатов при импорте: название задачи и дата её создания.
+func importKey(value *task.Task) string {
+	return value.Title + "\x00" + value.CreatedAt.Local().Format(time.DateOnly)
+}
+
+// validateImported проверяет поля задачи из файла, которые при создании проверяет task.NewTask.
+func validateImported(value *task.Task) error {
+	if utf8.RuneCountInString(value.Title) <= 1 {
+		return fmt.Errorf("ошибка в названии задачи (%w)", task.ErrTaskTitle)
+	}
+	if value.Status != "" && !value.Status.Valid() {
+		return fmt.Errorf("ошибка валидации (%w): %s", task.ErrInvalidStatus, value.Status)
+	}
+	if !value.Priority.Valid() {
+		return fmt.Errorf("ошибка валидации (%w): %s", task.ErrInvalidPriority, value.Priority)
+	}
+	return nil
+}
+
+// prepareImported заполняет поля, которых не было в файле: без даты создания задача считается созданной now,
+// без статуса - ожидающей, даты начала и завершения и история статусов берутся из даты создания.
+func prepareImported(value *task.Task, now time.Time) {
+	if value.CreatedAt.IsZero() {
+		value.CreatedAt = now
+	}
+	if value.Status == "" {
+		value.Status = task.StatusPending
+	}
+	created := value.CreatedAt
+	switch {
+	case value.Status == task.StatusProgress && value.StartedAt == nil:
+		value.StartedAt = &created
+	case value.Status == task.StatusCompleted && value.CompletedAt == nil:
+		value.CompletedAt = &created
+	}
+	if len(value.StatusHistory) == 0 {
+		value.StatusHistory = []task.StatusChange{{Status: value.Status, At: created}}
+	}
+}
+
+// relinkImported переводит ссылки импортированной задачи на другие задачи (родитель, блокирующие задачи, серия)
+// со старых ID из файла на новые. Ссылки на задачи, которых не было в файле, снимаются.
+func relinkImported(value *task.Task, ids map[int]int) {
+	value.ParentID = ids[value.ParentID]
+	blockedBy := make([]int, 0, len(value.BlockedBy))
+	for _, id := range value.BlockedBy {
+		if newID, ok := ids[id]; ok && !slices.Contains(blockedBy, newID) {
+			blockedBy = append(blockedBy, newID)
+		}
+	}
+	value.BlockedBy = nil
+	if len(blockedBy) > 0 {
+		value.BlockedBy = blockedBy
+	}
+	value.SeriesID = ids[value.SeriesID]
+	if value.SeriesID == 0 && value.Recur != "" {
+		value.SeriesID = value.ID
+	}
+}
+
+// Export возвращает задачи текущего списка в формате файла обмена. Задачи из корзины выгружаются только при deleted = true.
+// Если формат не может сохранить некоторые значения задач (см. ExportWarner), выводятся замечания о них.
+// Возвращает ошибку при загрузке или преобразовании задач.
+func (m *Manager) Export(codec Codec, deleted bool) ([]byte, error) {
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении: %w", err)
+	}
+	if !deleted {
+		tasks = m.filter.GetTasksByDeleted(tasks, false)
+	}
+	data, err := codec.Encode(tasks)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при экспорте: %w", err)
+	}
+	if warner, ok := codec.(ExportWarner); ok {
+		for _, warning := range warner.ExportWarnings(tasks) {
+			m.render.RenderWarning(warning)
+		}
+	}
+	return data, nil
+}
+
+// Import добавляет в текущий список задачи из файла обмена и выводит итог импорта (см. render.ImportReport).
+// Задачи получают новые ID после максимального существующего, ссылки между импортированными задачами
+// (родитель, блокирующие задачи, серия повторов) переводятся на новые ID. Запись, совпадающая с задачей списка
+// или уже импортированной записью по названию и дате создания, пропускается, а ссылки на неё ведут на найденную задачу.
+// Записи, которые не удалось разобрать или проверить, не прерывают импорт и выводятся с причиной,
+// замечания преобразователя к созданным задачам выводятся отдельно.
+// Учёт времени по импортированной задаче останавливается, если он уже ведётся по другой задаче.
+// Созданные задачи записываются в историю одной операцией: todo undo удаляет их все.
+// Возвращает ошибку, если файл не удалось разобрать целиком или произошла ошибка при загрузке или сохранении.
+func (m *Manager) Import(codec Codec, data []byte) error {
+	decoded, errs, err := codec.Decode(data)
+	if err != nil {
+		return fmt.Errorf("ошибка при импорте: %w", err)
+	}
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	now := time.Now()
+	existing := make(map[string]int, len(tasks))
+	for _, value := range tasks {
+		existing[importKey(value)] = value.ID
+	}
+	report := render.ImportReport{Created: make([]*task.Task, 0, len(decoded))}
+	ids := make(map[int]int, len(decoded))
+	nextTask := nextID(tasks)
+	for i, value := range decoded {
+		if value == nil {
+			reason := "пустая запись"
+			if errs[i] != nil {
+				reason = errs[i].Error()
+			}
+			report.Failed = append(report.Failed, render.ImportIssue{Record: i + 1, Reason: reason})
+			continue
+		}
+		if err := validateImported(value); err != nil {
+			report.Failed = append(report.Failed, render.ImportIssue{Record: i + 1, Title: value.Title, Reason: err.Error()})
+			continue
+		}
+		prepareImported(value, now)
+		if id, ok := existing[importKey(value)]; ok {
+			ids[value.ID] = id
+			report.Skipped = append(report.Skipped, render.ImportIssue{Record: i + 1, Title: value.Title,
+				Reason: fmt.Sprintf("дубликат задачи #%d (то же название и дата создания)", id)})
+			continue
+		}
+		ids[value.ID] = nextTask
+		value.ID = nextTask
+		nextTask += 1
+		existing[importKey(value)] = value.ID
+		report.Created = append(report.Created, value)
+		if errs[i] != nil {
+			report.Warnings = append(report.Warnings, render.ImportIssue{Record: i + 1, Title: value.Title, Reason: errs[i].Error()})
+		}
+	}
+	delete(ids, 0)
+	entries := make([]journal.Entry, 0, len(report.Created))
+	for _, value := range report.Created {
+		relinkImported(value, ids)
+		if value.IsTracking() && trackingIndex(tasks) != nil {
+			value.StopTracking(now)
+		}
+		tasks = append(tasks, value)
+		entries = append(entries, journal.NewEntry(journal.OpImport, nil, value, now))
+	}
+	if len(report.Created) > 0 {
+		err = m.store.Save(tasks, m.fileName)
+		if err != nil {
+			return fmt.Errorf("ошибка при записи: %w", err)
+		}
+		err = m.recordEntries(entries...)
+		if err != nil {
+			return err
+		}
+	}
+	m.render.RenderImport(report)
+	return nil
+}
@@ -0,0 +1,210 @@
+//go:build !production
+
+package manager
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCodec возвращает заданные задачи при разборе и запоминает задачи, переданные на выгрузку.
+type stubCodec struct {
+	tasks    []*task.Task
+	errs     []error
+	err      error
+	warnings []error
+	encoded  []*task.Task
+}
+
+func (c *stubCodec) ExportWarnings(tasks []*task.Task) []error {
+	return c.warnings
+}
+
+func (c *stubCodec) Encode(tasks []*task.Task) ([]byte, error) {
+	c.encoded = tasks
+	return []byte("encoded"), c.err
+}
+
+func (c *stubCodec) Decode(data []byte) ([]*task.Task, []error, error) {
+	return c.tasks, c.errs, c.err
+}
+
+func TestImport(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	mockStorage := new(MockHistoryStorage)
+	mockRender := new(MockRender)
+
+	var saved []*task.Task
+	var report render.ImportReport
+	mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+	mockStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*task.Task)
+	}).Return(nil)
+	mockRender.On("RenderImport", mock.Anything).Run(func(args mock.Arguments) {
+		report = args.Get(0).(render.ImportReport)
+	}).Return()
+
+	// #12 совпадает с задачей списка #1, #13 - с записью #11 из того же файла;
+	// замечание к пропущенному дубликату #12 не выводится
+	codec := &stubCodec{
+		tasks: []*task.Task{
+			{ID: 10, Title: "epic", Status: task.StatusProgress, Recur: "FREQ=WEEKLY", SeriesID: 10},
+			{ID: 11, Title: "subtask", ParentID: 10, BlockedBy: []int{12, 99}},
+			{ID: 12, Title: "pending task 1", CreatedAt: tasksMany[0].CreatedAt},
+			nil,
+			{Title: "x"},
+			{ID: 13, Title: "subtask"},
+		},
+		errs: []error{nil, errors.New("поле recur: правило не поддерживается"), errors.New("поле recur: правило не поддерживается"),
+			errors.New("поле due: некорректная дата"), nil, nil},
+	}
+	manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+	require.NoError(t, manager.Import(codec, nil))
+
+	require.Len(t, saved, 8)
+	epic, subtask := saved[6], saved[7]
+	assert.Equal(t, 7, epic.ID)
+	assert.Equal(t, 7, epic.SeriesID)
+	require.NotNil(t, epic.StartedAt)
+	assert.Equal(t, epic.CreatedAt, *epic.StartedAt)
+	assert.Equal(t, []task.StatusChange{{Status: task.StatusProgress, At: epic.CreatedAt}}, epic.StatusHistory)
+	assert.Equal(t, 8, subtask.ID)
+	assert.Equal(t, task.StatusPending, subtask.Status)
+	assert.Equal(t, 7, subtask.ParentID)
+	assert.Equal(t, []int{1}, subtask.BlockedBy)
+
+	assert.Equal(t, []*task.Task{epic, subtask}, report.Created)
+	assert.Equal(t, []render.ImportIssue{
+		{Record: 3, Title: "pending task 1", Reason: "дубликат задачи #1 (то же название и дата создания)"},
+		{Record: 6, Title: "subtask", Reason: "дубликат задачи #8 (то же название и дата создания)"},
+	}, report.Skipped)
+	require.Len(t, report.Failed, 2)
+	assert.Equal(t, render.ImportIssue{Record: 4, Reason: "поле due: некорректная дата"}, report.Failed[0])
+	assert.Equal(t, 5, report.Failed[1].Record)
+	assert.Contains(t, report.Failed[1].Reason, task.ErrTaskTitle.Error())
+	assert.Equal(t, []render.ImportIssue{{Record: 2, Title: "subtask", Reason: "поле recur: правило не поддерживается"}}, report.Warnings)
+
+	entries := mockStorage.history.Recent()
+	require.Len(t, entries, 2)
+	assert.Equal(t, journal.OpImport, entries[0].Op)
+	assert.Equal(t, 8, entries[0].TaskID)
+	assert.Nil(t, entries[0].Before)
+}
+
+func TestImportUndo(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	mockStorage := new(MockHistoryStorage)
+	mockRender := new(MockRender)
+
+	var saved []*task.Task
+	mockStorage.On("Load", mock.Anything).Return(tasksMany, nil).Once()
+	mockStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*task.Task)
+	}).Return(nil)
+	mockRender.On("RenderImport", mock.Anything).Return()
+	mockRender.On("RenderHistory", mock.Anything).Return()
+
+	codec := &stubCodec{tasks: []*task.Task{{Title: "first"}, {Title: "second"}, {Title: "third"}}, errs: make([]error, 3)}
+	manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+	require.NoError(t, manager.Import(codec, nil))
+	require.Len(t, saved, 9)
+
+	// весь импорт отменяется одной операцией
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	count, err := manager.Undo(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Len(t, saved, 6)
+	assert.Empty(t, mockStorage.history.Done)
+}
+
+func TestImportStopsSecondTimer(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	started := time.Now().Add(-time.Hour)
+	tasksMany[2].TimeLog = []task.Interval{{Start: started}}
+	mockStorage := new(MockStorage)
+	mockRender := new(MockRender)
+	mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+	mockStorage.On("Save", mock.Anything, mock.Anything).Return(nil)
+	mockRender.On("RenderImport", mock.Anything).Return()
+
+	imported := &task.Task{ID: 1, Title: "tracked elsewhere", Status: task.StatusProgress, TimeLog: []task.Interval{{Start: started}}}
+	manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+	require.NoError(t, manager.Import(&stubCodec{tasks: []*task.Task{imported}, errs: []error{nil}}, nil))
+
+	assert.True(t, tasksMany[2].IsTracking())
+	assert.False(t, imported.IsTracking())
+}
+
+func TestImportErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		codec   *stubCodec
+		loadErr error
+		saveErr error
+	}{
+		{"файл не разобран", &stubCodec{err: errors.New("decode error")}, nil, nil},
+		{"ошибка при загрузке", &stubCodec{tasks: []*task.Task{}, errs: []error{}}, errors.New("load error"), nil},
+		{"ошибка при записи", &stubCodec{tasks: []*task.Task{{Title: "new task"}}, errs: []error{nil}}, nil, errors.New("save error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockStorage)
+			mockStorage.On("Load", mock.Anything).Return(testutil.EmptyTasks(), tt.loadErr)
+			mockStorage.On("Save", mock.Anything, mock.Anything).Return(tt.saveErr)
+
+			manager := NewManager(mockStorage, &FilterTasks{}, new(MockRender))
+			assert.Error(t, manager.Import(tt.codec, nil))
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	deletedAt := time.Now()
+	tasksMany[5].DeletedAt = &deletedAt
+
+	tests := []struct {
+		name     string
+		deleted  bool
+		warnings []error
+		expected int
+	}{
+		{"без корзины", false, nil, 5},
+		{"с корзиной", true, nil, 6},
+		{"с замечаниями формата", false, []error{errors.New("приоритет critical выгружен как H")}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockStorage)
+			mockRender := new(MockRender)
+			mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+			for _, warning := range tt.warnings {
+				mockRender.On("RenderWarning", warning).Return().Once()
+			}
+			codec := &stubCodec{warnings: tt.warnings}
+
+			manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+			data, err := manager.Export(codec, tt.deleted)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("encoded"), data)
+			assert.Len(t, codec.encoded, tt.expected)
+			mockRender.AssertExpectations(t)
+		})
+	}
+}
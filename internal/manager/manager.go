@@ -1,7 +1,13 @@
 package manager
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	"todo_cli/internal/journal"
 	"todo_cli/internal/render"
 	"todo_cli/internal/task"
 )
@@ -10,25 +16,108 @@ type Storage interface {
 	Save(tasks []*task.Task, newFileName *string) error
 	Load(differentFileName *string) ([]*task.Task, error)
 	Clear(differentFileName *string) error
+	Lock(differentFileName *string) (func() error, error)
+}
+
+// ListStorage описывает хранилище с поддержкой именованных списков задач.
+// Реализуется хранилищем опционально: без него менеджер работает только со списком по умолчанию.
+type ListStorage interface {
+	OpenList(name string) (string, error)
+	Lists() ([]string, error)
+	CreateList(name string) error
+	RenameList(oldName, newName string) error
+	DeleteList(name string, force bool) error
+	DefaultList() (string, error)
+	SetDefaultList(name string) error
+}
+
+// HistoryStorage описывает хранилище журнала операций для отмены и повтора изменений.
+// Реализуется хранилищем опционально: без него изменения не записываются в историю.
+type HistoryStorage interface {
+	LoadHistory(differentFileName *string) (*journal.History, error)
+	SaveHistory(history *journal.History, newFileName *string) error
 }
 
 type ManagerTasks interface {
 	Show(id int) error
-	List(status task.Status) error
+	List(options ListOptions) error
 	Create(data map[string]string) (*int, error)
 	Edit(id int, data map[string]string) error
-	Start(id int) error
-	Complete(id int) error
-	Delete(id int) error
-	Stats() error
+	Start(id int, force bool) (*int, error)
+	Stop() (int, error)
+	Pomodoro(ctx context.Context, id int, options PomodoroOptions) (PomodoroResult, error)
+	Complete(id int, cascade bool) (*int, error)
+	Delete(id int, subtasks string) error
+	Restore(id int) error
+	Block(id int, by []int) error
+	Unblock(id int, by []int) error
+	Graph(format string) error
+	Trash() error
+	EmptyTrash(olderThan string) (int, error)
+	Stats(period string) error
+	Effort() error
+	Activity(days int) error
+	TimeLog(by string, days int) error
+	TimeReport(period string) error
 	Search(word string) error
+	Tags() error
+	UseList(name string) error
+	Lists() error
+	Undo(n int) (int, error)
+	Redo(n int) (int, error)
+	History() error
+	Export(codec Codec, deleted bool) ([]byte, error)
+	Import(codec Codec, data []byte) error
+}
+
+var (
+	ErrListsUnsupported   = errors.New("хранилище не поддерживает списки задач")
+	ErrHistoryUnsupported = errors.New("хранилище не поддерживает историю операций")
+)
+
+// допустимые значения ListOptions.SortBy
+const (
+	SortByID       = "id"
+	SortByPriority = "priority"
+)
+
+// способы обработки подзадач при удалении задачи (см. Delete).
+// Пустое значение запрещает удаление задачи с подзадачами.
+const (
+	SubtasksCascade = "cascade"
+	SubtasksDetach  = "detach"
+)
+
+// ListOptions описывает фильтры и сортировку для вывода списка задач.
+// Пустые значения (и "all" для статуса) означают отсутствие фильтра.
+// DueBefore принимает те же форматы, что и срок задачи (см. task.ParseDue).
+// Tags оставляет задачи со всеми указанными тегами, а при TagsAny = true - хотя бы с одним.
+// Tree выводит задачи деревом с подзадачами под родительскими задачами.
+// Blocked оставляет невыполненные задачи, которые ждут завершения других задач, Ready - готовые к работе.
+// Recurring выводит вместо списка серии повторяющихся задач со сроком следующего повтора.
+type ListOptions struct {
+	Status    string
+	Priority  string
+	SortBy    string
+	Overdue   bool
+	DueBefore string
+	DueToday  bool
+	Tags      []string
+	TagsAny   bool
+	Tree      bool
+	Blocked   bool
+	Ready     bool
+	Recurring bool
 }
 
 // добавим зависимость для использования во внутренних методах
+// fileName - файл текущего списка задач, nil означает файл хранилища по умолчанию
 type Manager struct {
-	store  Storage
-	filter Filter
-	render render.Render
+	store    Storage
+	filter   Filter
+	render   render.Render
+	list     string
+	fileName *string
 }
 
 // конструктор
@@ -40,6 +129,15 @@ func NewManager(s Storage, f Filter, r render.Render) *Manager {
 	}
 }
 
+// nextID возвращает ID для новой задачи: максимальный существующий + 1.
+func nextID(tasks []*task.Task) int {
+	id := 0
+	for _, value := range tasks {
+		id = max(id, value.ID)
+	}
+	return id + 1
+}
+
 // hasKeys проверяет наличие всех указанных ключей в карте data.
 // Возвращает true, если все ключи присутствуют, иначе false.
 func hasKeys(data map[string]string, keys ...string) bool {
@@ -51,14 +149,52 @@ func hasKeys(data map[string]string, keys ...string) bool {
 	return true
 }
 
-// editTask изменяет поля задачи по её ID и сохраняет изменения в хранилище.
-// Принимает менеджер, список задач, ID задачи и карту с новыми данными (title, description, status).
+// значение срока, которое снимает срок с задачи при редактировании
+const dueNone = "none"
+
+// parseDue разбирает срок задачи из пользовательского ввода.
+// Пустая строка и "none" означают отсутствие срока и возвращают nil.
+func parseDue(value string) (*time.Time, error) {
+	if value == "" || value == dueNone {
+		return nil, nil
+	}
+	due, err := task.ParseDue(value, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &due, nil
+}
+
+// editTask изменяет поля задачи по её ID (см. changeTask), сохраняет изменения в хранилище
+// и записывает операцию op в историю.
 // Возвращает индекс изменённой задачи или ошибку, если задача не найдена или данные невалидны.
-func editTask(m *Manager, tasks []*task.Task, id int, data map[string]string) (*int, error) {
-	indexTask := m.filter.GetIndexByID(tasks, id)
-	if indexTask == nil {
-		return nil, fmt.Errorf("не найдена задача с #%d", id)
+func editTask(m *Manager, tasks []*task.Task, id int, op journal.Op, data map[string]string) (*int, error) {
+	indexTask, entry, err := changeTask(m, tasks, id, op, data)
+	if err != nil {
+		return nil, err
+	}
+	err = m.store.Save(tasks, m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при записи: %w", err)
+	}
+	err = m.recordEntries(entry)
+	if err != nil {
+		return nil, err
+	}
+	return indexTask, nil
+}
+
+// changeTask изменяет поля задачи по её ID, не сохраняя изменения, и возвращает запись журнала об операции op.
+// Принимает менеджер, список задач, ID задачи и карту с новыми данными (title, description, status, priority, due,
+// estimate, tags, remove_tags, every, timer). Значения due, estimate и every = "none" снимают срок, оценку и повтор,
+// теги передаются через запятую, timer = "start" или "stop" запускает или останавливает учёт времени после смены статуса.
+// Возвращает индекс изменённой задачи или ошибку, если задача не найдена или данные невалидны.
+func changeTask(m *Manager, tasks []*task.Task, id int, op journal.Op, data map[string]string) (*int, journal.Entry, error) {
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return nil, journal.Entry{}, err
 	}
+	before := tasks[*indexTask].Clone()
 	if title, ok := data["title"]; ok {
 		tasks[*indexTask].Title = title
 	}
@@ -67,82 +203,351 @@ func editTask(m *Manager, tasks []*task.Task, id int, data map[string]string) (*
 	}
 	if status, ok := data["status"]; ok {
 		if !task.Status(string(status)).Valid() {
-			return nil, fmt.Errorf("неверный статус задачи: %v", status)
+			return nil, journal.Entry{}, fmt.Errorf("неверный статус задачи (%w): %v", task.ErrInvalidStatus, status)
 		}
-		tasks[*indexTask].Status = task.Status(status)
+		if task.Status(status) == task.StatusCompleted {
+			if open := openSubtasks(tasks, id); len(open) > 0 {
+				return nil, journal.Entry{}, fmt.Errorf("%w: невыполненных у #%d - %d, завершите их или используйте todo complete --cascade",
+					task.ErrHasSubtasks, id, len(open))
+			}
+		}
+		tasks[*indexTask].SetStatus(task.Status(status), time.Now())
 	}
-	err := m.store.Save(tasks, nil)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка при записи: %w", err)
+	if value, ok := data["priority"]; ok {
+		priority, err := task.ParsePriority(value)
+		if err != nil {
+			return nil, journal.Entry{}, err
+		}
+		tasks[*indexTask].Priority = priority
+	}
+	if value, ok := data["due"]; ok {
+		due, err := parseDue(value)
+		if err != nil {
+			return nil, journal.Entry{}, err
+		}
+		tasks[*indexTask].Due = due
+	}
+	if value, ok := data["estimate"]; ok {
+		estimate, err := parseEstimate(value)
+		if err != nil {
+			return nil, journal.Entry{}, err
+		}
+		tasks[*indexTask].Estimate = estimate
+	}
+	if value, ok := data["tags"]; ok {
+		tags, err := task.ParseTags(value)
+		if err != nil {
+			return nil, journal.Entry{}, err
+		}
+		tasks[*indexTask].AddTags(tags...)
+	}
+	if value, ok := data["every"]; ok {
+		err := setRecurrence(tasks[*indexTask], value)
+		if err != nil {
+			return nil, journal.Entry{}, err
+		}
+	}
+	if value, ok := data["remove_tags"]; ok {
+		tags, err := task.ParseTags(value)
+		if err != nil {
+			return nil, journal.Entry{}, err
+		}
+		tasks[*indexTask].RemoveTags(tags...)
+	}
+	switch data["timer"] {
+	case timerStart:
+		tasks[*indexTask].StartTracking(time.Now())
+	case timerStop:
+		tasks[*indexTask].StopTracking(time.Now())
+	}
+	return indexTask, journal.NewEntry(op, before, tasks[*indexTask], time.Now()), nil
+}
+
+// openSubtasks возвращает невыполненные подзадачи задачи id на любой глубине (см. task.Descendants).
+func openSubtasks(tasks []*task.Task, id int) []*task.Task {
+	open := make([]*task.Task, 0)
+	for _, value := range task.Descendants(tasks, id) {
+		if value.Status != task.StatusCompleted {
+			open = append(open, value)
+		}
+	}
+	return open
+}
+
+// activeIndex возвращает индекс задачи по ID, если задача не находится в корзине.
+// Задачи из корзины нельзя просматривать и изменять - их сначала нужно восстановить.
+func activeIndex(m *Manager, tasks []*task.Task, id int) (*int, error) {
+	indexTask := m.filter.GetIndexByID(tasks, id)
+	if indexTask == nil {
+		return nil, fmt.Errorf("%w: #%d", task.ErrTaskNotFound, id)
+	}
+	if tasks[*indexTask].IsDeleted() {
+		return nil, fmt.Errorf("%w: #%d находится в корзине, восстановите её командой todo restore", task.ErrTaskNotFound, id)
 	}
 	return indexTask, nil
 }
 
+// record записывает операцию над задачей в журнал текущего списка.
+// Вызывается после успешного сохранения задач под той же блокировкой файла.
+// Если хранилище не поддерживает историю, операция не записывается.
+func (m *Manager) record(op journal.Op, before, after *task.Task) error {
+	return m.recordEntries(journal.NewEntry(op, before, after, time.Now()))
+}
+
+// recordEntries записывает изменения задач, сделанные одной командой, в журнал текущего списка
+// одной операцией: Undo и Redo отменяют и повторяют их вместе.
+func (m *Manager) recordEntries(records ...journal.Entry) error {
+	history, ok := m.store.(HistoryStorage)
+	if !ok {
+		return nil
+	}
+	entries, err := history.LoadHistory(m.fileName)
+	if err != nil {
+		return fmt.Errorf("задачи сохранены, но не удалось записать историю: %w", err)
+	}
+	entries.Record(records...)
+	err = history.SaveHistory(entries, m.fileName)
+	if err != nil {
+		return fmt.Errorf("задачи сохранены, но не удалось записать историю: %w", err)
+	}
+	return nil
+}
+
 // Create создаёт новую задачу со статусом "pending".
-// Принимает карту data с обязательными ключами "title" и "description".
+// Принимает карту data с обязательными ключами "title" и "description" и опциональными "priority", "due", "estimate",
+// "tags", "parent" и "every". Слова вида +tag в заголовке вырезаются из него и добавляются к тегам задачи.
+// Задача с ключом "every" повторяется по правилу (см. task.ParseRecurrence) и начинает новую серию.
+// Задача с ключом "parent" создаётся подзадачей указанной задачи, которая не должна быть в корзине или выполнена.
 // Автоматически назначает новый уникальный ID (максимальный существующий + 1).
 // Сохраняет задачу в хранилище и выводит детальную информацию.
 // Возвращает указатель на ID созданной задачи или ошибку при невалидных данных.
 func (m *Manager) Create(data map[string]string) (*int, error) {
-	var idTask int = 0
-	tasks, err := m.store.Load(nil)
+	unlock, err := m.store.Lock(m.fileName)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при создании: %w", err)
 	}
-	for _, task := range tasks {
-		if idTask < task.ID {
-			idTask = task.ID
-		}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при создании: %w", err)
 	}
-	idTask += 1
+	idTask := nextID(tasks)
 
 	if hasKeys(data, "title", "description") {
-		newTask, err := task.NewTask(idTask, data["title"], data["description"], task.StatusPending.String())
+		title, tags := task.ExtractTags(data["title"])
+		newTask, err := task.NewTask(idTask, title, data["description"], task.StatusPending.String())
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при создании задачи: %w", err)
+		}
+		if value, ok := data["priority"]; ok {
+			newTask.Priority, err = task.ParsePriority(value)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка при создании задачи: %w", err)
+			}
+		}
+		newTask.Due, err = parseDue(data["due"])
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при создании задачи: %w", err)
+		}
+		newTask.Estimate, err = parseEstimate(data["estimate"])
 		if err != nil {
 			return nil, fmt.Errorf("ошибка при создании задачи: %w", err)
 		}
+		extraTags, err := task.ParseTags(data["tags"])
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при создании задачи: %w", err)
+		}
+		newTask.AddTags(append(tags, extraTags...)...)
+		if value, ok := data["parent"]; ok {
+			newTask.ParentID, err = parentID(m, tasks, value)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка при создании задачи: %w", err)
+			}
+		}
+		if value, ok := data["every"]; ok {
+			err = setRecurrence(newTask, value)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка при создании задачи: %w", err)
+			}
+		}
 		tasks = append(tasks, newTask)
-		m.store.Save(tasks, nil)
+		err = m.store.Save(tasks, m.fileName)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при записи: %w", err)
+		}
+		err = m.record(journal.OpCreate, nil, newTask)
+		if err != nil {
+			return nil, err
+		}
 		m.render.RenderDetailed(newTask)
 		return &idTask, nil
 	}
 	return nil, fmt.Errorf("получены некорректные данные при создании задачи: %v", data)
 }
 
-// Start переводит задачу в статус "in_progress" (в работе).
+// parentID проверяет родительскую задачу для новой подзадачи и возвращает её ID.
+// Возвращает ошибку task.ErrInvalidID для некорректного ID, task.ErrTaskNotFound,
+// если задачи нет или она в корзине, и task.ErrInvalidParent, если задача уже выполнена.
+func parentID(m *Manager, tasks []*task.Task, value string) (int, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w родительской задачи: %s", task.ErrInvalidID, value)
+	}
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return 0, fmt.Errorf("родительская задача: %w", err)
+	}
+	if tasks[*indexTask].Status == task.StatusCompleted {
+		return 0, fmt.Errorf("%w: #%d уже выполнена, переоткройте её, чтобы добавить подзадачу", task.ErrInvalidParent, id)
+	}
+	return id, nil
+}
+
+// Start переводит задачу в статус "in_progress" (в работе) и запускает по ней учёт времени.
 // Находит задачу по ID, изменяет её статус и сохраняет изменения.
+// Учёт времени ведётся только по одной задаче: если он шёл по другой задаче, он останавливается
+// (статус той задачи не меняется). Остановка и начало записываются в историю одной операцией.
+// Задачу, которая ждёт завершения других задач, можно начать только с force = true.
 // Выводит детальную информацию об обновлённой задаче.
-// Возвращает ошибку, если задача не найдена или произошла ошибка при сохранении.
-func (m *Manager) Start(id int) error {
-	data := map[string]string{"status": task.StatusProgress.String()}
-	tasks, err := m.store.Load(nil)
+// Возвращает ID задачи, по которой был остановлен учёт времени (nil, если такой не было),
+// ошибку task.ErrBlocked, если задача заблокирована и force = false,
+// ошибку, если задача не найдена или произошла ошибка при сохранении.
+func (m *Manager) Start(id int, force bool) (*int, error) {
+	unlock, err := m.store.Lock(m.fileName)
 	if err != nil {
-		return fmt.Errorf("ошибка при получении: %w", err)
+		return nil, fmt.Errorf("ошибка при блокировке: %w", err)
 	}
-	indexTask, err := editTask(m, tasks, id, data)
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
 	if err != nil {
-		return fmt.Errorf("не удалось создать задачу: %w", err)
+		return nil, fmt.Errorf("ошибка при получении: %w", err)
+	}
+	indexTask, stopped, err := startTask(m, tasks, id, force)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось начать задачу: %w", err)
 	}
 	m.render.RenderDetailed(tasks[*indexTask])
-	return nil
+	return stopped, nil
+}
+
+// startTask переводит задачу в работу и запускает по ней учёт времени, останавливая его по другой задаче,
+// сохраняет изменения и записывает их в историю одной операцией.
+// Возвращает индекс задачи и ID задачи, по которой был остановлен учёт времени (nil, если такой не было).
+func startTask(m *Manager, tasks []*task.Task, id int, force bool) (*int, *int, error) {
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if blockers := task.OpenBlockers(tasks, tasks[*indexTask]); len(blockers) > 0 && !force {
+		ids := make([]int, 0, len(blockers))
+		for _, blocker := range blockers {
+			ids = append(ids, blocker.ID)
+		}
+		return nil, nil, fmt.Errorf("%w: #%d ждёт завершения %s, используйте --force", task.ErrBlocked, id, idsLabel(ids, ", "))
+	}
+	var stopped *int
+	entries := make([]journal.Entry, 0, 2)
+	if tracking := trackingIndex(tasks); tracking != nil && tasks[*tracking].ID != id {
+		stoppedID := tasks[*tracking].ID
+		_, entry, err := changeTask(m, tasks, stoppedID, journal.OpStop, map[string]string{"timer": timerStop})
+		if err != nil {
+			return nil, nil, fmt.Errorf("не удалось остановить учёт времени по задаче #%d: %w", stoppedID, err)
+		}
+		entries = append(entries, entry)
+		stopped = &stoppedID
+	}
+	data := map[string]string{"status": task.StatusProgress.String(), "timer": timerStart}
+	indexTask, entry, err := changeTask(m, tasks, id, journal.OpStart, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = m.store.Save(tasks, m.fileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка при записи: %w", err)
+	}
+	err = m.recordEntries(append(entries, entry)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return indexTask, stopped, nil
 }
 
 // Complete переводит задачу в статус "completed" (выполнена).
 // Находит задачу по ID, изменяет её статус на завершённый и сохраняет изменения.
+// Задачу с невыполненными подзадачами можно завершить только с cascade = true: тогда сначала
+// завершаются все её подзадачи (от самых вложенных).
+// Для повторяющейся задачи создаётся следующая задача серии со сдвинутым сроком (см. nextInstance).
+// Все изменения записываются в историю одной операцией.
 // Выводит детальную информацию об обновлённой задаче.
-// Возвращает ошибку, если задача не найдена или произошла ошибка при сохранении.
-func (m *Manager) Complete(id int) error {
+// Возвращает ID следующей задачи серии (nil для задачи без повтора), ошибку task.ErrHasSubtasks,
+// если есть невыполненные подзадачи и cascade = false, ошибку, если задача не найдена
+// или произошла ошибка при сохранении.
+func (m *Manager) Complete(id int, cascade bool) (*int, error) {
 	data := map[string]string{"status": task.StatusCompleted.String()}
-	tasks, err := m.store.Load(nil)
+	unlock, err := m.store.Lock(m.fileName)
 	if err != nil {
-		return fmt.Errorf("ошибка при получении: %w", err)
+		return nil, fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении: %w", err)
+	}
+	entries := make([]journal.Entry, 0)
+	if cascade {
+		if _, err := activeIndex(m, tasks, id); err != nil {
+			return nil, fmt.Errorf("не удалось завершить задачу: %w", err)
+		}
+		open := openSubtasks(tasks, id)
+		for i := len(open) - 1; i >= 0; i-- {
+			var completed []journal.Entry
+			tasks, _, completed, err = completeTask(m, tasks, open[i].ID, data)
+			if err != nil {
+				return nil, fmt.Errorf("не удалось завершить подзадачу #%d: %w", open[i].ID, err)
+			}
+			entries = append(entries, completed...)
+		}
+	}
+	tasks, next, completed, err := completeTask(m, tasks, id, data)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось завершить задачу: %w", err)
+	}
+	err = m.store.Save(tasks, m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при записи: %w", err)
 	}
-	indexTask, err := editTask(m, tasks, id, data)
+	err = m.recordEntries(append(entries, completed...)...)
 	if err != nil {
-		return fmt.Errorf("не удалось завершить задачу: %w", err)
+		return nil, err
 	}
+	indexTask := m.filter.GetIndexByID(tasks, id)
 	m.render.RenderDetailed(tasks[*indexTask])
-	return nil
+	return next, nil
+}
+
+// completeTask завершает задачу и, если она повторяющаяся и до этого не была выполнена,
+// добавляет следующую задачу серии. Изменения не сохраняются. Возвращает обновлённый список задач,
+// ID следующей задачи серии (nil, если она не создавалась) и записи журнала об изменениях.
+func completeTask(m *Manager, tasks []*task.Task, id int, data map[string]string) ([]*task.Task, *int, []journal.Entry, error) {
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	wasCompleted := tasks[*indexTask].Status == task.StatusCompleted
+	indexTask, entry, err := changeTask(m, tasks, id, journal.OpComplete, data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if wasCompleted || tasks[*indexTask].Recur == "" {
+		return tasks, nil, []journal.Entry{entry}, nil
+	}
+	now := time.Now()
+	next, err := nextInstance(tasks, tasks[*indexTask], now)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tasks = append(tasks, next)
+	return tasks, &next.ID, []journal.Entry{entry, journal.NewEntry(journal.OpCreate, nil, next, now)}, nil
 }
 
 // Edit изменяет данные существующей задачи.
@@ -151,11 +556,16 @@ func (m *Manager) Complete(id int) error {
 // Выводит детальную информацию об обновлённой задаче.
 // Возвращает ошибку, если задача не найдена, статус невалиден или ошибка при сохранении.
 func (m *Manager) Edit(id int, data map[string]string) error {
-	tasks, err := m.store.Load(nil)
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
 	if err != nil {
 		return fmt.Errorf("ошибка при получении: %w", err)
 	}
-	indexTask, err := editTask(m, tasks, id, data)
+	indexTask, err := editTask(m, tasks, id, journal.OpEdit, data)
 	if err != nil {
 		return fmt.Errorf("не удалось отредактировать задачу: %w", err)
 	}
@@ -163,102 +573,552 @@ func (m *Manager) Edit(id int, data map[string]string) error {
 	return nil
 }
 
-// Delete удаляет задачу по её ID.
-// Находит задачу в списке, удаляет её из слайса и сохраняет изменения в хранилище.
-// Возвращает ошибку, если задача не найдена или произошла ошибка при сохранении.
-func (m *Manager) Delete(id int) error {
-	tasks, err := m.store.Load(nil)
+// Delete перемещает задачу в корзину по её ID: задаче проставляется время удаления DeletedAt,
+// и она перестаёт выводиться в списке, поиске и статистике.
+// Задачу с подзадачами можно удалить, только указав, что делать с подзадачами:
+// SubtasksCascade перемещает в корзину и все подзадачи, SubtasksDetach делает прямые подзадачи
+// задачами верхнего уровня. Все изменения записываются в историю одной операцией.
+// Задачу можно вернуть через Restore с тем же ID или отменить удаление через Undo.
+// Учёт времени по удаляемым задачам останавливается.
+// Возвращает ошибку task.ErrHasSubtasks, если у задачи есть подзадачи, а способ их обработки не указан,
+// ошибку, если задача не найдена, уже в корзине или произошла ошибка при сохранении.
+func (m *Manager) Delete(id int, subtasks string) error {
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
 	if err != nil {
 		return fmt.Errorf("ошибка при получении: %w", err)
 	}
-	indexTask := m.filter.GetIndexByID(tasks, id)
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	entries := make([]journal.Entry, 0)
 
-	if indexTask == nil {
-		return fmt.Errorf("не найдена задача с #%d", id)
+	switch children := task.Children(tasks, id); {
+	case len(children) == 0:
+	case subtasks == SubtasksCascade:
+		descendants := task.Descendants(tasks, id)
+		for i := len(descendants) - 1; i >= 0; i-- {
+			before := descendants[i].Clone()
+			descendants[i].DeletedAt = &now
+			descendants[i].StopTracking(now)
+			entries = append(entries, journal.NewEntry(journal.OpDelete, before, descendants[i], now))
+		}
+	case subtasks == SubtasksDetach:
+		for _, child := range children {
+			before := child.Clone()
+			child.ParentID = 0
+			entries = append(entries, journal.NewEntry(journal.OpEdit, before, child, now))
+		}
+	default:
+		return fmt.Errorf("%w: у #%d их %d, используйте --cascade, чтобы удалить их вместе с задачей, "+
+			"или --detach, чтобы сделать их задачами верхнего уровня", task.ErrHasSubtasks, id, len(children))
+	}
+	before := tasks[*indexTask].Clone()
+	tasks[*indexTask].DeletedAt = &now
+	tasks[*indexTask].StopTracking(now)
+	entries = append(entries, journal.NewEntry(journal.OpDelete, before, tasks[*indexTask], now))
+
+	err = m.store.Save(tasks, m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при записи: %w", err)
 	}
+	return m.recordEntries(entries...)
+}
 
-	// удаляем из слайса tasks элемент под индексом indexTask
-	if len(tasks) != 0 && *indexTask < len(tasks) {
-		tasks = append(tasks[:*indexTask], tasks[*indexTask+1:]...)
+// Restore возвращает задачу из корзины с её исходным ID и выводит её.
+// Возвращает ошибку task.ErrTaskNotFound, если в корзине нет задачи с таким ID.
+func (m *Manager) Restore(id int) error {
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	indexTask := m.filter.GetIndexByID(tasks, id)
+	if indexTask == nil || !tasks[*indexTask].IsDeleted() {
+		return fmt.Errorf("%w: #%d в корзине", task.ErrTaskNotFound, id)
 	}
+	before := tasks[*indexTask].Clone()
+	tasks[*indexTask].DeletedAt = nil
 
-	err = m.store.Save(tasks, nil)
+	err = m.store.Save(tasks, m.fileName)
 	if err != nil {
 		return fmt.Errorf("ошибка при записи: %w", err)
 	}
+	err = m.record(journal.OpRestore, before, tasks[*indexTask])
+	if err != nil {
+		return err
+	}
+	m.render.RenderDetailed(tasks[*indexTask])
+	return nil
+}
 
+// Trash выводит задачи, находящиеся в корзине.
+// Возвращает ошибку, если произошла ошибка при загрузке.
+func (m *Manager) Trash() error {
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	m.render.RenderList(m.filter.GetTasksByDeleted(tasks, true))
 	return nil
 }
 
+// EmptyTrash окончательно удаляет задачи из корзины.
+// Если olderThan не пустой (12h, 30d, 2w - см. task.ParsePeriod), удаляются только задачи,
+// перемещённые в корзину раньше этого срока. Очистку корзины нельзя отменить через Undo,
+// а операции над удалёнными задачами убираются из истории. Оставшиеся подзадачи
+// удалённых задач становятся задачами верхнего уровня, а зависимости от удалённых задач снимаются.
+// Возвращает количество удалённых задач или ошибку при некорректном периоде или сохранении.
+func (m *Manager) EmptyTrash(olderThan string) (int, error) {
+	var period time.Duration
+	if olderThan != "" {
+		var err error
+		period, err = task.ParsePeriod(olderThan)
+		if err != nil {
+			return 0, fmt.Errorf("передан некорректный период очистки: %w", err)
+		}
+	}
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при получении: %w", err)
+	}
+	deadline := time.Now().Add(-period)
+	kept := make([]*task.Task, 0, len(tasks))
+	purged := make([]int, 0)
+	for _, value := range tasks {
+		if value.IsDeleted() && !value.DeletedAt.After(deadline) {
+			purged = append(purged, value.ID)
+			continue
+		}
+		kept = append(kept, value)
+	}
+	if len(purged) == 0 {
+		return 0, nil
+	}
+	// подзадачи окончательно удалённых задач становятся задачами верхнего уровня,
+	// а зависимости от удалённых задач снимаются
+	for _, value := range kept {
+		if slices.Contains(purged, value.ParentID) {
+			value.ParentID = 0
+		}
+		value.RemoveBlockers(purged...)
+	}
+	err = m.store.Save(kept, m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при записи: %w", err)
+	}
+	if history, ok := m.store.(HistoryStorage); ok {
+		entries, err := history.LoadHistory(m.fileName)
+		if err != nil {
+			return 0, fmt.Errorf("задачи удалены, но не удалось обновить историю: %w", err)
+		}
+		entries.Forget(purged...)
+		err = history.SaveHistory(entries, m.fileName)
+		if err != nil {
+			return 0, fmt.Errorf("задачи удалены, но не удалось обновить историю: %w", err)
+		}
+	}
+	return len(purged), nil
+}
+
 // Show выводит детальную информацию о конкретной задаче.
-// Загружает задачи из хранилища, находит задачу по ID и отображает её данные.
+// Загружает задачи из хранилища, находит задачу по ID и отображает её данные,
+// а для задачи с подзадачами - и список подзадач с процентом выполнения.
 // Возвращает ошибку, если задача не найдена или произошла ошибка при загрузке.
 func (m *Manager) Show(id int) error {
-	tasks, err := m.store.Load(nil)
+	tasks, err := m.store.Load(m.fileName)
 	if err != nil {
 		return fmt.Errorf("ошибка при получении: %w", err)
 	}
-	indexTask := m.filter.GetIndexByID(tasks, id)
-	if indexTask == nil {
-		return fmt.Errorf("не найдена задача с #%d", id)
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return err
+	}
+	if subtasks := task.Children(tasks, id); len(subtasks) > 0 {
+		m.render.RenderWithSubtasks(tasks[*indexTask], subtasks)
+		return nil
 	}
 	m.render.RenderDetailed(tasks[*indexTask])
 	return nil
 }
 
-// List выводит список задач с опциональной фильтрацией по статусу.
-// Если status = "all", выводит все задачи без фильтрации.
-// Иначе фильтрует задачи по указанному статусу (pending, in_progress, completed).
-// Возвращает ошибку, если передан некорректный статус или ошибка при загрузке.
-func (m *Manager) List(status string) error {
-	tasks, err := m.store.Load(nil)
+// List выводит список задач с опциональной фильтрацией и сортировкой.
+// Если options.Status = "all" (или пусто), фильтр по статусу не применяется,
+// иначе задачи фильтруются по указанному статусу (pending, in_progress, completed).
+// Если указан options.Priority, остаются только задачи с этим приоритетом.
+// Флаги Overdue, DueToday и DueBefore оставляют просроченные задачи, задачи со сроком сегодня
+// и задачи со сроком раньше указанной даты, options.Tags - задачи с указанными тегами,
+// Blocked и Ready - заблокированные и готовые к работе задачи.
+// При options.SortBy = "priority" задачи сортируются от критичных к задачам без приоритета.
+// При options.Tree = true задачи выводятся деревом, порядок сохраняется среди задач одного уровня,
+// при options.Recurring = true - серии повторяющихся задач (см. Filter.GetRecurring).
+// Задачи из корзины не выводятся (см. Trash).
+// Возвращает ошибку, если передан некорректный фильтр или ошибка при загрузке.
+func (m *Manager) List(options ListOptions) error {
+	tasks, err := m.store.Load(m.fileName)
 	if err != nil {
 		return fmt.Errorf("ошибка при получении: %w", err)
 	}
-	if status == "all" {
-		m.render.RenderList(tasks)
-	} else {
-		if !task.Status(status).Valid() {
-			return fmt.Errorf("передан некорректный статус для фильтрации: %s", status)
+	tasks = m.filter.GetTasksByDeleted(tasks, false)
+	all := tasks
+	if options.Status != "" && options.Status != "all" {
+		if !task.Status(options.Status).Valid() {
+			return fmt.Errorf("передан некорректный статус для фильтрации (%w): %s", task.ErrInvalidStatus, options.Status)
+		}
+		tasks, err = m.filter.GetTasksByStatus(tasks, task.Status(options.Status))
+		if err != nil {
+			return fmt.Errorf("ошибка при фильтрации задач: %w", err)
 		}
-		tasksFiltered, err := m.filter.GetTasksByStatus(tasks, task.Status(status))
+	}
+	if options.Priority != "" {
+		priority, err := task.ParsePriority(options.Priority)
+		if err != nil {
+			return fmt.Errorf("передан некорректный приоритет для фильтрации: %w", err)
+		}
+		tasks, err = m.filter.GetTasksByPriority(tasks, priority)
 		if err != nil {
 			return fmt.Errorf("ошибка при фильтрации задач: %w", err)
 		}
-		m.render.RenderList(tasksFiltered)
 	}
+	now := time.Now()
+	if options.Overdue {
+		tasks = m.filter.GetOverdueTasks(tasks, now)
+	}
+	if options.DueToday {
+		tasks = m.filter.GetTasksDueOn(tasks, now)
+	}
+	if options.DueBefore != "" {
+		date, err := task.ParseDue(options.DueBefore, now)
+		if err != nil {
+			return fmt.Errorf("передана некорректная дата для фильтрации: %w", err)
+		}
+		tasks = m.filter.GetTasksDueBefore(tasks, date)
+	}
+	if len(options.Tags) > 0 {
+		tags := make([]string, 0, len(options.Tags))
+		for _, value := range options.Tags {
+			tag, err := task.NormalizeTag(value)
+			if err != nil {
+				return fmt.Errorf("передан некорректный тег для фильтрации: %w", err)
+			}
+			tags = append(tags, tag)
+		}
+		tasks = m.filter.GetTasksByTags(tasks, tags, !options.TagsAny)
+	}
+	if options.Blocked {
+		tasks = m.filter.GetTasksByBlocked(tasks, all, true)
+	}
+	if options.Ready {
+		tasks = m.filter.GetTasksByBlocked(tasks, all, false)
+	}
+	switch options.SortBy {
+	case "", SortByID:
+	case SortByPriority:
+		tasks = m.filter.SortTasksByPriority(tasks)
+	default:
+		return fmt.Errorf("передана некорректная сортировка: %s", options.SortBy)
+	}
+	if options.Recurring {
+		m.render.RenderRecurring(m.filter.GetRecurring(tasks, all, now))
+		return nil
+	}
+	if options.Tree {
+		m.render.RenderTree(buildTree(tasks))
+		return nil
+	}
+	m.render.RenderList(tasks)
 	return nil
 }
 
 // Stats выводит статистику по задачам.
-// Собирает и отображает количество задач по каждому статусу и общее количество.
-// Формат вывода: всего задач, выполнено, в работе, ожидает.
-// Возвращает ошибку, если произошла ошибка при загрузке задач.
-func (m *Manager) Stats() error {
-	tasks, err := m.store.Load(nil)
+// Без периода собирает и отображает количество задач по каждому статусу и общее количество.
+// Формат вывода: всего задач, выполнено, в работе, ожидает. Задачи из корзины не учитываются.
+// С периодом (PeriodWeek или PeriodMonth) выводит показатели потока задач: выполненные задачи
+// по периодам, lead time, cycle time и самые старые открытые задачи (см. Filter.GetFlowStats).
+// Возвращает ошибку, если передан некорректный период или произошла ошибка при загрузке задач.
+func (m *Manager) Stats(period string) error {
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	tasks = m.filter.GetTasksByDeleted(tasks, false)
+	if period == "" {
+		m.render.RenderStats(m.filter.GetStatsTasksByStatus(tasks))
+		return nil
+	}
+	stats, err := m.filter.GetFlowStats(tasks, period, time.Now())
+	if err != nil {
+		return fmt.Errorf("передан некорректный период статистики: %w", err)
+	}
+	m.render.RenderFlowStats(stats)
+	return nil
+}
+
+// Activity выводит графики активности за последние days дней: созданные и выполненные задачи по дням,
+// открытые задачи на конец дня и тепловую карту выполненных задач. Задачи из корзины не учитываются.
+// Возвращает ошибку, если days не положительное или произошла ошибка при загрузке задач.
+func (m *Manager) Activity(days int) error {
+	tasks, err := m.store.Load(m.fileName)
 	if err != nil {
 		return fmt.Errorf("ошибка при получении: %w", err)
 	}
-	data := m.filter.GetStatsTasksByStatus(tasks)
-	m.render.RenderMap(data)
+	activity, err := m.filter.GetActivity(m.filter.GetTasksByDeleted(tasks, false), days, time.Now())
+	if err != nil {
+		return fmt.Errorf("не удалось построить графики: %w", err)
+	}
+	m.render.RenderActivity(activity)
 	return nil
 }
 
 // Search выполняет поиск задач по ключевому слову.
 // Ищет совпадения в заголовке и описании задач (регистронезависимый поиск).
-// Выводит список найденных задач.
+// Выводит список найденных задач. Задачи из корзины не ищутся.
 // Возвращает ошибку, если задачи не найдены или произошла ошибка при загрузке.
 func (m *Manager) Search(word string) error {
-	tasks, err := m.store.Load(nil)
+	tasks, err := m.store.Load(m.fileName)
 	if err != nil {
 		return fmt.Errorf("ошибка при получении: %w", err)
 	}
-	foundTasks := m.filter.GetTasksBySearchWord(tasks, word)
+	foundTasks := m.filter.GetTasksBySearchWord(m.filter.GetTasksByDeleted(tasks, false), word)
 
 	if len(foundTasks) >= 1 {
 		m.render.RenderList(foundTasks)
 	} else {
-		return fmt.Errorf("задачи по фразе %s - не найдены", word)
+		return fmt.Errorf("%w: по фразе %s", task.ErrTaskNotFound, word)
+	}
+	return nil
+}
+
+// Tags выводит все теги с количеством задач в каждом статусе. Задачи из корзины не учитываются.
+// Возвращает ошибку, если ни у одной задачи нет тегов или произошла ошибка при загрузке.
+func (m *Manager) Tags() error {
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	data := m.filter.GetStatsTasksByTag(m.filter.GetTasksByDeleted(tasks, false))
+	if len(data) == 0 {
+		return fmt.Errorf("у задач нет тегов")
+	}
+	m.render.RenderTagStats(data)
+	return nil
+}
+
+// listStorage возвращает хранилище как ListStorage или ошибку ErrListsUnsupported.
+func (m *Manager) listStorage() (ListStorage, error) {
+	lists, ok := m.store.(ListStorage)
+	if !ok {
+		return nil, ErrListsUnsupported
+	}
+	return lists, nil
+}
+
+// UseList переключает менеджер на именованный список задач.
+// Пустое имя означает список по умолчанию из настроек хранилища.
+// Если хранилище не поддерживает списки, пустое имя оставляет файл по умолчанию.
+// Возвращает ошибку, если список не существует или имя некорректно.
+func (m *Manager) UseList(name string) error {
+	lists, err := m.listStorage()
+	if err != nil {
+		if name == "" {
+			return nil
+		}
+		return err
+	}
+	if name == "" {
+		name, err = lists.DefaultList()
+		if err != nil {
+			return fmt.Errorf("ошибка при получении списка по умолчанию: %w", err)
+		}
+	}
+	fileName, err := lists.OpenList(name)
+	if err != nil {
+		return fmt.Errorf("не удалось открыть список %s: %w", name, err)
+	}
+	m.list = name
+	m.fileName = &fileName
+	return nil
+}
+
+// Lists выводит все списки задач с количеством задач (без учёта корзины) в каждом,
+// отмечая список по умолчанию и текущий список.
+// Возвращает ошибку, если хранилище не поддерживает списки или произошла ошибка при загрузке.
+func (m *Manager) Lists() error {
+	lists, err := m.listStorage()
+	if err != nil {
+		return err
+	}
+	names, err := lists.Lists()
+	if err != nil {
+		return fmt.Errorf("ошибка при получении списков: %w", err)
+	}
+	defaultList, err := lists.DefaultList()
+	if err != nil {
+		return fmt.Errorf("ошибка при получении списка по умолчанию: %w", err)
+	}
+	infos := make([]render.ListInfo, 0, len(names))
+	for _, name := range names {
+		fileName, err := lists.OpenList(name)
+		if err != nil {
+			return fmt.Errorf("не удалось открыть список %s: %w", name, err)
+		}
+		tasks, err := m.store.Load(&fileName)
+		if err != nil {
+			return fmt.Errorf("ошибка при получении списка %s: %w", name, err)
+		}
+		infos = append(infos, render.ListInfo{
+			Name:    name,
+			Tasks:   len(m.filter.GetTasksByDeleted(tasks, false)),
+			Default: name == defaultList,
+			Current: name == m.list,
+		})
+	}
+	m.render.RenderLists(infos)
+	return nil
+}
+
+// CreateList создаёт новый пустой список задач.
+func (m *Manager) CreateList(name string) error {
+	lists, err := m.listStorage()
+	if err != nil {
+		return err
+	}
+	err = lists.CreateList(name)
+	if err != nil {
+		return fmt.Errorf("не удалось создать список: %w", err)
+	}
+	return nil
+}
+
+// RenameList переименовывает список задач.
+func (m *Manager) RenameList(oldName, newName string) error {
+	lists, err := m.listStorage()
+	if err != nil {
+		return err
+	}
+	err = lists.RenameList(oldName, newName)
+	if err != nil {
+		return fmt.Errorf("не удалось переименовать список: %w", err)
+	}
+	return nil
+}
+
+// DeleteList удаляет список задач. Непустой список удаляется только при force = true.
+func (m *Manager) DeleteList(name string, force bool) error {
+	lists, err := m.listStorage()
+	if err != nil {
+		return err
+	}
+	err = lists.DeleteList(name, force)
+	if err != nil {
+		return fmt.Errorf("не удалось удалить список: %w", err)
+	}
+	return nil
+}
+
+// SetDefaultList делает список задач списком по умолчанию для следующих запусков.
+func (m *Manager) SetDefaultList(name string) error {
+	lists, err := m.listStorage()
+	if err != nil {
+		return err
+	}
+	err = lists.SetDefaultList(name)
+	if err != nil {
+		return fmt.Errorf("не удалось выбрать список по умолчанию: %w", err)
+	}
+	return nil
+}
+
+// historyStorage возвращает хранилище как HistoryStorage или ошибку ErrHistoryUnsupported.
+func (m *Manager) historyStorage() (HistoryStorage, error) {
+	history, ok := m.store.(HistoryStorage)
+	if !ok {
+		return nil, ErrHistoryUnsupported
+	}
+	return history, nil
+}
+
+// Undo отменяет последние n операций над задачами текущего списка
+// и выводит отменённые операции от последней к первой.
+// Если операций в истории меньше n, отменяются все. Отменённые операции можно повторить через Redo.
+// Возвращает количество отменённых операций или ошибку, если отменять нечего
+// или задачи изменены в обход истории (journal.ErrConflict).
+func (m *Manager) Undo(n int) (int, error) {
+	return m.replay(n, (*journal.History).Undo)
+}
+
+// Redo повторяет последние n отменённых операций и выводит их в порядке выполнения.
+// Новая операция над задачами сбрасывает отменённые - после неё повторять нечего.
+// Возвращает количество повторённых операций или ошибку, если повторять нечего.
+func (m *Manager) Redo(n int) (int, error) {
+	return m.replay(n, (*journal.History).Redo)
+}
+
+// replay применяет к задачам отмену или повтор операций из журнала
+// и сохраняет задачи и журнал под блокировкой файла задач.
+func (m *Manager) replay(n int, step func(*journal.History, []*task.Task, int) ([]*task.Task, []journal.Entry, error)) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("количество операций должно быть положительным: %d", n)
+	}
+	history, err := m.historyStorage()
+	if err != nil {
+		return 0, err
+	}
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при получении: %w", err)
+	}
+	entries, err := history.LoadHistory(m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при получении истории: %w", err)
+	}
+	tasks, replayed, err := step(entries, tasks, n)
+	if err != nil {
+		return 0, err
+	}
+	err = m.store.Save(tasks, m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при записи: %w", err)
+	}
+	err = history.SaveHistory(entries, m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("задачи сохранены, но не удалось записать историю: %w", err)
+	}
+	m.render.RenderHistory(replayed)
+	return journal.Operations(replayed), nil
+}
+
+// History выводит операции текущего списка от последней к первой - в порядке, в котором их отменит Undo.
+// Возвращает ошибку, если хранилище не поддерживает историю или произошла ошибка при загрузке.
+func (m *Manager) History() error {
+	history, err := m.historyStorage()
+	if err != nil {
+		return err
+	}
+	entries, err := history.LoadHistory(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении истории: %w", err)
 	}
+	m.render.RenderHistory(entries.Recent())
 	return nil
 }
@@ -5,6 +5,9 @@ package manager
 import (
 	"errors"
 	"testing"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/render"
 	"todo_cli/internal/task"
 	"todo_cli/internal/testutil"
 
@@ -15,6 +18,7 @@ import (
 
 type MockStorage struct {
 	mock.Mock
+	lockErr error
 }
 
 func (m *MockStorage) Save(tasks []*task.Task, newFileName *string) error {
@@ -35,6 +39,15 @@ func (m *MockStorage) Clear(differentFileName *string) error {
 	return args.Error(0)
 }
 
+// Lock не регистрирует вызов в mock, чтобы не дублировать настройку в каждом тесте.
+// Ошибку блокировки можно задать через поле lockErr.
+func (m *MockStorage) Lock(differentFileName *string) (func() error, error) {
+	if m.lockErr != nil {
+		return nil, m.lockErr
+	}
+	return func() error { return nil }, nil
+}
+
 type MockFilter struct {
 	mock.Mock
 }
@@ -60,9 +73,87 @@ func (m *MockFilter) GetTasksBySearchWord(tasks []*task.Task, word string) []*ta
 	return args.Get(0).([]*task.Task)
 }
 
-func (m *MockFilter) GetStatsTasksByStatus(tasks []*task.Task) map[string]interface{} {
+func (m *MockFilter) GetTasksByPriority(tasks []*task.Task, priority task.Priority) ([]*task.Task, error) {
+	args := m.Called(tasks, priority)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*task.Task), args.Error(1)
+}
+
+func (m *MockFilter) SortTasksByPriority(tasks []*task.Task) []*task.Task {
+	args := m.Called(tasks)
+	return args.Get(0).([]*task.Task)
+}
+
+func (m *MockFilter) GetOverdueTasks(tasks []*task.Task, now time.Time) []*task.Task {
+	args := m.Called(tasks, now)
+	return args.Get(0).([]*task.Task)
+}
+
+func (m *MockFilter) GetTasksDueBefore(tasks []*task.Task, date time.Time) []*task.Task {
+	args := m.Called(tasks, date)
+	return args.Get(0).([]*task.Task)
+}
+
+func (m *MockFilter) GetTasksDueOn(tasks []*task.Task, date time.Time) []*task.Task {
+	args := m.Called(tasks, date)
+	return args.Get(0).([]*task.Task)
+}
+
+func (m *MockFilter) GetTasksByTags(tasks []*task.Task, tags []string, matchAll bool) []*task.Task {
+	args := m.Called(tasks, tags, matchAll)
+	return args.Get(0).([]*task.Task)
+}
+
+func (m *MockFilter) GetStatsTasksByTag(tasks []*task.Task) []render.TagStats {
+	args := m.Called(tasks)
+	return args.Get(0).([]render.TagStats)
+}
+
+func (m *MockFilter) GetTasksByBlocked(tasks []*task.Task, all []*task.Task, blocked bool) []*task.Task {
+	args := m.Called(tasks, all, blocked)
+	return args.Get(0).([]*task.Task)
+}
+
+func (m *MockFilter) GetRecurring(tasks []*task.Task, all []*task.Task, now time.Time) []render.Series {
+	args := m.Called(tasks, all, now)
+	return args.Get(0).([]render.Series)
+}
+
+func (m *MockFilter) GetTasksByDeleted(tasks []*task.Task, deleted bool) []*task.Task {
+	args := m.Called(tasks, deleted)
+	return args.Get(0).([]*task.Task)
+}
+
+func (m *MockFilter) GetFlowStats(tasks []*task.Task, period string, now time.Time) (render.FlowStats, error) {
+	args := m.Called(tasks, period, now)
+	return args.Get(0).(render.FlowStats), args.Error(1)
+}
+
+func (m *MockFilter) GetActivity(tasks []*task.Task, days int, now time.Time) (render.Activity, error) {
+	args := m.Called(tasks, days, now)
+	return args.Get(0).(render.Activity), args.Error(1)
+}
+
+func (m *MockFilter) GetTimeLog(tasks []*task.Task, by string, days int, now time.Time) (render.TimeLog, error) {
+	args := m.Called(tasks, by, days, now)
+	return args.Get(0).(render.TimeLog), args.Error(1)
+}
+
+func (m *MockFilter) GetTimeReport(tasks []*task.Task, period string, now time.Time) (render.TimeReport, error) {
+	args := m.Called(tasks, period, now)
+	return args.Get(0).(render.TimeReport), args.Error(1)
+}
+
+func (m *MockFilter) GetEffortStats(tasks []*task.Task, now time.Time) render.EffortStats {
+	args := m.Called(tasks, now)
+	return args.Get(0).(render.EffortStats)
+}
+
+func (m *MockFilter) GetStatsTasksByStatus(tasks []*task.Task) render.StatusStats {
 	args := m.Called(tasks)
-	return args.Get(0).(map[string]interface{})
+	return args.Get(0).(render.StatusStats)
 }
 
 type MockRender struct {
@@ -73,12 +164,137 @@ func (m *MockRender) RenderDetailed(t *task.Task) {
 	m.Called(t)
 }
 
+func (m *MockRender) RenderWithSubtasks(parent *task.Task, subtasks []*task.Task) {
+	m.Called(parent, subtasks)
+}
+
+func (m *MockRender) RenderTree(nodes []render.TaskNode) {
+	m.Called(nodes)
+}
+
+func (m *MockRender) RenderGraph(graph render.Graph, format string) {
+	m.Called(graph, format)
+}
+
+func (m *MockRender) RenderRecurring(series []render.Series) {
+	m.Called(series)
+}
+
 func (m *MockRender) RenderList(tasks []*task.Task) {
 	m.Called(tasks)
 }
 
-func (m *MockRender) RenderMap(data map[string]interface{}) {
-	m.Called(data)
+func (m *MockRender) RenderStats(stats render.StatusStats) {
+	m.Called(stats)
+}
+
+func (m *MockRender) RenderVersion(info render.VersionInfo) {
+	m.Called(info)
+}
+
+func (m *MockRender) RenderTagStats(stats []render.TagStats) {
+	m.Called(stats)
+}
+
+func (m *MockRender) RenderLists(lists []render.ListInfo) {
+	m.Called(lists)
+}
+
+func (m *MockRender) RenderFlowStats(stats render.FlowStats) {
+	m.Called(stats)
+}
+
+func (m *MockRender) RenderActivity(activity render.Activity) {
+	m.Called(activity)
+}
+
+func (m *MockRender) RenderTimeLog(log render.TimeLog) {
+	m.Called(log)
+}
+
+func (m *MockRender) RenderTimeReport(report render.TimeReport) {
+	m.Called(report)
+}
+
+func (m *MockRender) RenderPomodoro(progress render.PomodoroProgress) {
+	m.Called(progress)
+}
+
+func (m *MockRender) RenderEffortStats(stats render.EffortStats) {
+	m.Called(stats)
+}
+
+func (m *MockRender) RenderImport(report render.ImportReport) {
+	m.Called(report)
+}
+
+func (m *MockRender) RenderHistory(entries []journal.Entry) {
+	m.Called(entries)
+}
+
+func (m *MockRender) RenderMessage(message string) {
+	m.Called(message)
+}
+
+func (m *MockRender) RenderWarning(err error) {
+	m.Called(err)
+}
+
+func (m *MockRender) RenderError(err error) {
+	m.Called(err)
+}
+
+// MockListStorage - хранилище с поддержкой именованных списков
+type MockListStorage struct {
+	MockStorage
+}
+
+func (m *MockListStorage) OpenList(name string) (string, error) {
+	args := m.Called(name)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockListStorage) Lists() ([]string, error) {
+	args := m.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockListStorage) CreateList(name string) error {
+	return m.Called(name).Error(0)
+}
+
+func (m *MockListStorage) RenameList(oldName, newName string) error {
+	return m.Called(oldName, newName).Error(0)
+}
+
+func (m *MockListStorage) DeleteList(name string, force bool) error {
+	return m.Called(name, force).Error(0)
+}
+
+func (m *MockListStorage) DefaultList() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockListStorage) SetDefaultList(name string) error {
+	return m.Called(name).Error(0)
+}
+
+// MockHistoryStorage - хранилище с журналом операций.
+// Журнал хранится в памяти, чтобы проверять запись операций и их отмену последовательно.
+type MockHistoryStorage struct {
+	MockStorage
+	history journal.History
+}
+
+func (m *MockHistoryStorage) LoadHistory(differentFileName *string) (*journal.History, error) {
+	history := m.history
+	return &history, nil
+}
+
+func (m *MockHistoryStorage) SaveHistory(history *journal.History, newFileName *string) error {
+	m.history = *history
+	return nil
 }
 
 func TestCreate(t *testing.T) {
@@ -161,7 +377,7 @@ func TestStart(t *testing.T) {
 			}
 
 			manager := NewManager(mockStorage, mockFilter, mockRender)
-			err := manager.Start(tt.taskID)
+			_, err := manager.Start(tt.taskID, false)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -204,7 +420,7 @@ func TestComplete(t *testing.T) {
 			}
 
 			manager := NewManager(mockStorage, mockFilter, mockRender)
-			err := manager.Complete(tt.taskID)
+			_, err := manager.Complete(tt.taskID, false)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -233,6 +449,12 @@ func TestEdit(t *testing.T) {
 		{"редактирование description", 1, map[string]string{"description": "New desc"}, tasksMany, nil, testutil.IntPtr(0), nil, false},
 		{"редактирование status", 1, map[string]string{"status": "completed"}, tasksMany, nil, testutil.IntPtr(0), nil, false},
 		{"некорректный status", 1, map[string]string{"status": "invalid"}, tasksMany, nil, testutil.IntPtr(0), nil, true},
+		{"редактирование priority", 1, map[string]string{"priority": "critical"}, tasksMany, nil, testutil.IntPtr(0), nil, false},
+		{"некорректный priority", 1, map[string]string{"priority": "invalid"}, tasksMany, nil, testutil.IntPtr(0), nil, true},
+		{"редактирование due", 1, map[string]string{"due": "tomorrow"}, tasksMany, nil, testutil.IntPtr(0), nil, false},
+		{"снятие due", 1, map[string]string{"due": "none"}, tasksMany, nil, testutil.IntPtr(0), nil, false},
+		{"добавление и удаление тегов", 1, map[string]string{"tags": "api,+db", "remove_tags": "ui"}, tasksMany, nil, testutil.IntPtr(0), nil, false},
+		{"некорректный due", 1, map[string]string{"due": "invalid"}, tasksMany, nil, testutil.IntPtr(0), nil, true},
 		{"задача не найдена", 99, map[string]string{"title": "Test"}, tasksMany, nil, nil, nil, true},
 		{"ошибка при загрузке", 1, map[string]string{"title": "Test"}, nil, errors.New("load error"), nil, nil, true},
 	}
@@ -245,7 +467,7 @@ func TestEdit(t *testing.T) {
 
 			mockStorage.On("Load", mock.Anything).Return(tt.loadTasks, tt.loadErr)
 			mockFilter.On("GetIndexByID", mock.Anything, tt.taskID).Return(tt.indexResult)
-			if tt.indexResult != nil && tt.loadErr == nil && tt.data["status"] != "invalid" {
+			if tt.indexResult != nil && tt.loadErr == nil && tt.data["status"] != "invalid" && tt.data["priority"] != "invalid" && tt.data["due"] != "invalid" {
 				mockStorage.On("Save", mock.Anything, mock.Anything).Return(tt.saveErr)
 				mockRender.On("RenderDetailed", mock.Anything).Return()
 			}
@@ -294,7 +516,7 @@ func TestDelete(t *testing.T) {
 			}
 
 			manager := NewManager(mockStorage, mockFilter, mockRender)
-			err := manager.Delete(tt.taskID)
+			err := manager.Delete(tt.taskID, "")
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -352,18 +574,29 @@ func TestList(t *testing.T) {
 
 	tests := []struct {
 		name          string
-		status        string
+		options       ListOptions
 		loadTasks     []*task.Task
 		loadErr       error
 		filteredTasks []*task.Task
 		filterErr     error
 		expectedErr   bool
 	}{
-		{"список всех задач", "all", tasksMany, nil, nil, nil, false},
-		{"фильтр по pending", "pending", tasksMany, nil, tasksMany[:2], nil, false},
-		{"невалидный статус", "invalid", tasksMany, nil, nil, nil, true},
-		{"ошибка при загрузке", "all", nil, errors.New("load error"), nil, nil, true},
-		{"ошибка при фильтрации", "pending", tasksMany, nil, nil, errors.New("filter error"), true},
+		{"список всех задач", ListOptions{Status: "all"}, tasksMany, nil, nil, nil, false},
+		{"фильтр по pending", ListOptions{Status: "pending"}, tasksMany, nil, tasksMany[:2], nil, false},
+		{"невалидный статус", ListOptions{Status: "invalid"}, tasksMany, nil, nil, nil, true},
+		{"ошибка при загрузке", ListOptions{Status: "all"}, nil, errors.New("load error"), nil, nil, true},
+		{"ошибка при фильтрации", ListOptions{Status: "pending"}, tasksMany, nil, nil, errors.New("filter error"), true},
+		{"фильтр по приоритету", ListOptions{Priority: "HIGH"}, tasksMany, nil, tasksMany[1:2], nil, false},
+		{"невалидный приоритет", ListOptions{Priority: "urgent"}, tasksMany, nil, nil, nil, true},
+		{"сортировка по приоритету", ListOptions{SortBy: SortByPriority}, tasksMany, nil, nil, nil, false},
+		{"невалидная сортировка", ListOptions{SortBy: "title"}, tasksMany, nil, nil, nil, true},
+		{"просроченные задачи", ListOptions{Overdue: true}, tasksMany, nil, nil, nil, false},
+		{"задачи со сроком сегодня", ListOptions{DueToday: true}, tasksMany, nil, nil, nil, false},
+		{"задачи со сроком до даты", ListOptions{DueBefore: "+3d"}, tasksMany, nil, nil, nil, false},
+		{"некорректная дата для фильтра", ListOptions{DueBefore: "someday"}, tasksMany, nil, nil, nil, true},
+		{"фильтр по тегам", ListOptions{Tags: []string{"+Auth", "backend"}}, tasksMany, nil, nil, nil, false},
+		{"фильтр по любому из тегов", ListOptions{Tags: []string{"auth"}, TagsAny: true}, tasksMany, nil, nil, nil, false},
+		{"некорректный тег для фильтра", ListOptions{Tags: []string{"two words"}}, tasksMany, nil, nil, nil, true},
 	}
 
 	for _, tt := range tests {
@@ -373,18 +606,144 @@ func TestList(t *testing.T) {
 			mockRender := new(MockRender)
 
 			mockStorage.On("Load", mock.Anything).Return(tt.loadTasks, tt.loadErr)
-			if tt.status != "all" && tt.status != "invalid" && tt.loadErr == nil {
-				mockFilter.On("GetTasksByStatus", mock.Anything, task.Status(tt.status)).Return(tt.filteredTasks, tt.filterErr)
+			if tt.loadErr == nil {
+				mockFilter.On("GetTasksByDeleted", mock.Anything, false).Return(tt.loadTasks)
+			}
+			if tt.options.Status != "" && tt.options.Status != "all" && tt.options.Status != "invalid" && tt.loadErr == nil {
+				mockFilter.On("GetTasksByStatus", mock.Anything, task.Status(tt.options.Status)).Return(tt.filteredTasks, tt.filterErr)
+			}
+			if tt.options.Priority != "" && tt.filteredTasks != nil {
+				mockFilter.On("GetTasksByPriority", mock.Anything, task.PriorityHigh).Return(tt.filteredTasks, tt.filterErr)
+			}
+			if tt.options.Overdue {
+				mockFilter.On("GetOverdueTasks", mock.Anything, mock.Anything).Return(tt.loadTasks)
+			}
+			if tt.options.DueToday {
+				mockFilter.On("GetTasksDueOn", mock.Anything, mock.Anything).Return(tt.loadTasks)
+			}
+			if tt.options.DueBefore != "" && !tt.expectedErr {
+				mockFilter.On("GetTasksDueBefore", mock.Anything, mock.Anything).Return(tt.loadTasks)
+			}
+			if len(tt.options.Tags) > 0 && !tt.expectedErr {
+				mockFilter.On("GetTasksByTags", mock.Anything, mock.Anything, !tt.options.TagsAny).Return(tt.loadTasks)
+			}
+			if tt.options.SortBy == SortByPriority {
+				mockFilter.On("SortTasksByPriority", mock.Anything).Return(tt.loadTasks)
 			}
 			if tt.loadErr == nil && !tt.expectedErr {
 				mockRender.On("RenderList", mock.Anything).Return()
 			}
 
 			manager := NewManager(mockStorage, mockFilter, mockRender)
-			err := manager.List(tt.status)
+			err := manager.List(tt.options)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				mockFilter.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestCreateWithPriority(t *testing.T) {
+	tests := []struct {
+		name             string
+		priority         string
+		expectedPriority task.Priority
+		expectedErr      error
+	}{
+		{"приоритет high", "high", task.PriorityHigh, nil},
+		{"приоритет в верхнем регистре", "Critical", task.PriorityCritical, nil},
+		{"некорректный приоритет", "urgent", task.PriorityNone, task.ErrInvalidPriority},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockStorage)
+			mockFilter := new(MockFilter)
+			mockRender := new(MockRender)
+
+			mockStorage.On("Load", mock.Anything).Return(testutil.EmptyTasks(), nil)
+			mockStorage.On("Save", mock.Anything, mock.Anything).Return(nil)
+			mockRender.On("RenderDetailed", mock.Anything).Return()
+
+			manager := NewManager(mockStorage, mockFilter, mockRender)
+			_, err := manager.Create(map[string]string{"title": "Task", "description": "", "priority": tt.priority})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				mockStorage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			saved := mockStorage.Calls[1].Arguments.Get(0).([]*task.Task)
+			assert.Equal(t, tt.expectedPriority, saved[0].Priority)
+		})
+	}
+}
+
+func TestCreateWithTags(t *testing.T) {
+	mockStorage := new(MockStorage)
+	mockFilter := new(MockFilter)
+	mockRender := new(MockRender)
+
+	mockStorage.On("Load", mock.Anything).Return(testutil.EmptyTasks(), nil)
+	mockStorage.On("Save", mock.Anything, mock.Anything).Return(nil)
+	mockRender.On("RenderDetailed", mock.Anything).Return()
+
+	manager := NewManager(mockStorage, mockFilter, mockRender)
+	_, err := manager.Create(map[string]string{
+		"title":       "Починить логин +Auth +backend",
+		"description": "",
+		"tags":        "backend, ui",
+	})
+	require.NoError(t, err)
+
+	saved := mockStorage.Calls[1].Arguments.Get(0).([]*task.Task)
+	assert.Equal(t, "Починить логин", saved[0].Title)
+	assert.Equal(t, []string{"auth", "backend", "ui"}, saved[0].Tags)
+}
+
+func TestTags(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	tagStats := []render.TagStats{{Tag: "auth", Stats: testutil.StatsTask(1, 0, 0, 1, 0)}}
+
+	tests := []struct {
+		name        string
+		loadTasks   []*task.Task
+		loadErr     error
+		stats       []render.TagStats
+		expectedErr bool
+	}{
+		{"статистика по тегам", tasksMany, nil, tagStats, false},
+		{"у задач нет тегов", tasksMany, nil, []render.TagStats{}, true},
+		{"ошибка при загрузке", nil, errors.New("load error"), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockStorage)
+			mockFilter := new(MockFilter)
+			mockRender := new(MockRender)
+
+			mockStorage.On("Load", mock.Anything).Return(tt.loadTasks, tt.loadErr)
+			if tt.loadErr == nil {
+				mockFilter.On("GetTasksByDeleted", mock.Anything, false).Return(tt.loadTasks)
+				mockFilter.On("GetStatsTasksByTag", mock.Anything).Return(tt.stats)
+			}
+			if !tt.expectedErr {
+				mockRender.On("RenderTagStats", tt.stats).Return()
+			}
+
+			manager := NewManager(mockStorage, mockFilter, mockRender)
+			err := manager.Tags()
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				mockRender.AssertNotCalled(t, "RenderTagStats", mock.Anything)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -395,17 +754,17 @@ func TestList(t *testing.T) {
 func TestStats(t *testing.T) {
 	tasksMany, err := testutil.ManyTasks()
 	require.NoError(t, err)
-	stats := testutil.StatsTask(6, 3, 1, 2)
+	stats := testutil.StatsTask(6, 3, 1, 2, 0)
 
 	tests := []struct {
 		name        string
 		loadTasks   []*task.Task
 		loadErr     error
-		stats       map[string]interface{}
+		stats       render.StatusStats
 		expectedErr bool
 	}{
 		{"успешная статистика", tasksMany, nil, stats, false},
-		{"ошибка при загрузке", nil, errors.New("load error"), nil, true},
+		{"ошибка при загрузке", nil, errors.New("load error"), render.StatusStats{}, true},
 	}
 
 	for _, tt := range tests {
@@ -416,12 +775,13 @@ func TestStats(t *testing.T) {
 
 			mockStorage.On("Load", mock.Anything).Return(tt.loadTasks, tt.loadErr)
 			if tt.loadErr == nil {
+				mockFilter.On("GetTasksByDeleted", mock.Anything, false).Return(tt.loadTasks)
 				mockFilter.On("GetStatsTasksByStatus", mock.Anything).Return(tt.stats)
-				mockRender.On("RenderMap", tt.stats).Return()
+				mockRender.On("RenderStats", tt.stats).Return()
 			}
 
 			manager := NewManager(mockStorage, mockFilter, mockRender)
-			err := manager.Stats()
+			err := manager.Stats("")
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -432,6 +792,66 @@ func TestStats(t *testing.T) {
 	}
 }
 
+func TestStatsPeriod(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	stats := render.FlowStats{Period: PeriodWeek}
+
+	tests := []struct {
+		name        string
+		period      string
+		filterErr   error
+		expectedErr bool
+	}{
+		{"статистика за неделю", PeriodWeek, nil, false},
+		{"некорректный период", "year", task.ErrInvalidPeriod, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockStorage)
+			mockFilter := new(MockFilter)
+			mockRender := new(MockRender)
+
+			mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+			mockFilter.On("GetTasksByDeleted", mock.Anything, false).Return(tasksMany)
+			mockFilter.On("GetFlowStats", tasksMany, tt.period, mock.Anything).Return(stats, tt.filterErr)
+			mockRender.On("RenderFlowStats", stats).Return()
+
+			manager := NewManager(mockStorage, mockFilter, mockRender)
+			err := manager.Stats(tt.period)
+
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, tt.filterErr)
+				mockRender.AssertNotCalled(t, "RenderFlowStats", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockRender.AssertExpectations(t)
+				mockFilter.AssertNotCalled(t, "GetStatsTasksByStatus", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestActivity(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	activity := render.Activity{Days: []render.DayActivity{{Created: 1}}}
+
+	mockStorage := new(MockStorage)
+	mockFilter := new(MockFilter)
+	mockRender := new(MockRender)
+
+	mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+	mockFilter.On("GetTasksByDeleted", tasksMany, false).Return(tasksMany)
+	mockFilter.On("GetActivity", tasksMany, 7, mock.Anything).Return(activity, nil)
+	mockRender.On("RenderActivity", activity).Return()
+
+	manager := NewManager(mockStorage, mockFilter, mockRender)
+	require.NoError(t, manager.Activity(7))
+	mockRender.AssertExpectations(t)
+}
+
 func TestSearch(t *testing.T) {
 	tasksMany, err := testutil.ManyTasks()
 	require.NoError(t, err)
@@ -457,6 +877,7 @@ func TestSearch(t *testing.T) {
 
 			mockStorage.On("Load", mock.Anything).Return(tt.loadTasks, tt.loadErr)
 			if tt.loadErr == nil {
+				mockFilter.On("GetTasksByDeleted", mock.Anything, false).Return(tt.loadTasks)
 				mockFilter.On("GetTasksBySearchWord", mock.Anything, tt.word).Return(tt.foundTasks)
 				if len(tt.foundTasks) > 0 {
 					mockRender.On("RenderList", tt.foundTasks).Return()
@@ -475,6 +896,310 @@ func TestSearch(t *testing.T) {
 	}
 }
 
+func TestErrorSentinels(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		call        func(m *Manager) error
+		expectedErr error
+	}{
+		{"показ несуществующей задачи", func(m *Manager) error { return m.Show(99) }, task.ErrTaskNotFound},
+		{"удаление несуществующей задачи", func(m *Manager) error { return m.Delete(99, "") }, task.ErrTaskNotFound},
+		{"старт несуществующей задачи", func(m *Manager) error { _, err := m.Start(99, false); return err }, task.ErrTaskNotFound},
+		{"некорректный статус", func(m *Manager) error { return m.Edit(1, map[string]string{"status": "done"}) }, task.ErrInvalidStatus},
+		{"некорректный статус в фильтре", func(m *Manager) error { return m.List(ListOptions{Status: "done"}) }, task.ErrInvalidStatus},
+		{"поиск без результатов", func(m *Manager) error { return m.Search("nothing") }, task.ErrTaskNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockStorage)
+			filter := &FilterTasks{}
+			mockRender := new(MockRender)
+
+			mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+
+			manager := NewManager(mockStorage, filter, mockRender)
+			assert.ErrorIs(t, tt.call(manager), tt.expectedErr)
+		})
+	}
+}
+
+func TestLockError(t *testing.T) {
+	lockErr := errors.New("lock error")
+
+	tests := []struct {
+		name string
+		call func(m *Manager) error
+	}{
+		{"создание", func(m *Manager) error {
+			_, err := m.Create(map[string]string{"title": "Task", "description": "Desc"})
+			return err
+		}},
+		{"редактирование", func(m *Manager) error { return m.Edit(1, map[string]string{"title": "Task"}) }},
+		{"старт", func(m *Manager) error { _, err := m.Start(1, false); return err }},
+		{"завершение", func(m *Manager) error { _, err := m.Complete(1, false); return err }},
+		{"удаление", func(m *Manager) error { return m.Delete(1, "") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := &MockStorage{lockErr: lockErr}
+			mockFilter := new(MockFilter)
+			mockRender := new(MockRender)
+
+			manager := NewManager(mockStorage, mockFilter, mockRender)
+			err := tt.call(manager)
+
+			assert.ErrorIs(t, err, lockErr)
+			mockStorage.AssertNotCalled(t, "Load", mock.Anything)
+			mockStorage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUseList(t *testing.T) {
+	notFound := errors.New("not found")
+
+	tests := []struct {
+		name         string
+		list         string
+		defaultList  string
+		openErr      error
+		expectedFile string
+		expectedErr  bool
+	}{
+		{"список по умолчанию", "", "tasks", nil, "/todo/tasks.json", false},
+		{"явно выбранный список", "work", "tasks", nil, "/todo/work.json", false},
+		{"список не существует", "nope", "tasks", notFound, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockListStorage)
+			mockFilter := new(MockFilter)
+			mockRender := new(MockRender)
+
+			expectedList := tt.list
+			if expectedList == "" {
+				expectedList = tt.defaultList
+				mockStorage.On("DefaultList").Return(tt.defaultList, nil)
+			}
+			mockStorage.On("OpenList", expectedList).Return(tt.expectedFile, tt.openErr)
+			mockStorage.On("Load", mock.Anything).Return(testutil.EmptyTasks(), nil)
+			mockFilter.On("GetTasksByDeleted", mock.Anything, false).Return(testutil.EmptyTasks())
+			mockRender.On("RenderList", mock.Anything).Return()
+
+			manager := NewManager(mockStorage, mockFilter, mockRender)
+			err := manager.UseList(tt.list)
+
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, tt.openErr)
+				return
+			}
+			require.NoError(t, err)
+			// все обращения к хранилищу идут в файл выбранного списка
+			require.NoError(t, manager.List(ListOptions{}))
+			mockStorage.AssertCalled(t, "Load", &tt.expectedFile)
+		})
+	}
+}
+
+func TestUseListUnsupported(t *testing.T) {
+	manager := NewManager(new(MockStorage), new(MockFilter), new(MockRender))
+
+	assert.NoError(t, manager.UseList(""))
+	assert.ErrorIs(t, manager.UseList("work"), ErrListsUnsupported)
+	assert.ErrorIs(t, manager.Lists(), ErrListsUnsupported)
+}
+
+func TestLists(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	mockStorage := new(MockListStorage)
+	mockFilter := new(MockFilter)
+	mockRender := new(MockRender)
+
+	mockStorage.On("Lists").Return([]string{"tasks", "work"}, nil)
+	mockStorage.On("DefaultList").Return("tasks", nil)
+	mockStorage.On("OpenList", "tasks").Return("/todo/tasks.json", nil)
+	mockStorage.On("OpenList", "work").Return("/todo/work.json", nil)
+	mockStorage.On("Load", testutil.StrPtr("/todo/tasks.json")).Return(tasksMany, nil)
+	mockStorage.On("Load", testutil.StrPtr("/todo/work.json")).Return(testutil.EmptyTasks(), nil)
+	mockFilter.On("GetTasksByDeleted", tasksMany, false).Return(tasksMany)
+	mockFilter.On("GetTasksByDeleted", testutil.EmptyTasks(), false).Return(testutil.EmptyTasks())
+	mockRender.On("RenderLists", []render.ListInfo{
+		{Name: "tasks", Tasks: 6, Default: true, Current: true},
+		{Name: "work", Tasks: 0},
+	}).Return()
+
+	manager := NewManager(mockStorage, mockFilter, mockRender)
+	require.NoError(t, manager.UseList(""))
+	require.NoError(t, manager.Lists())
+	mockRender.AssertExpectations(t)
+}
+
+func TestUndoRedo(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	mockStorage := new(MockHistoryStorage)
+	filter := &FilterTasks{}
+	mockRender := new(MockRender)
+
+	var saved []*task.Task
+	mockStorage.On("Load", mock.Anything).Return(tasksMany, nil).Once()
+	mockStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*task.Task)
+	}).Return(nil)
+	mockRender.On("RenderDetailed", mock.Anything).Return()
+	mockRender.On("RenderHistory", mock.Anything).Return()
+
+	manager := NewManager(mockStorage, filter, mockRender)
+	require.NoError(t, manager.Edit(1, map[string]string{"title": "renamed task"}))
+	require.Len(t, mockStorage.history.Done, 1)
+	assert.Equal(t, "pending task 1", mockStorage.history.Done[0].Before.Title)
+	assert.Equal(t, "renamed task", mockStorage.history.Done[0].After.Title)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	require.NoError(t, manager.Delete(1, ""))
+	require.Len(t, saved, 6)
+	assert.True(t, saved[0].IsDeleted())
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	count, err := manager.Undo(5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	require.Len(t, saved, 6)
+	assert.False(t, saved[0].IsDeleted())
+	assert.Equal(t, "pending task 1", saved[0].Title)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	count, err = manager.Redo(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "renamed task", saved[0].Title)
+	assert.Len(t, mockStorage.history.Undone, 1)
+
+	require.NoError(t, manager.History())
+	mockRender.AssertCalled(t, "RenderHistory", mockStorage.history.Recent())
+}
+
+func TestUndoErrors(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		store       Storage
+		n           int
+		expectedErr error
+	}{
+		{"хранилище без истории", new(MockStorage), 1, ErrHistoryUnsupported},
+		{"пустая история", new(MockHistoryStorage), 1, journal.ErrNothingToUndo},
+		{"некорректное количество", new(MockHistoryStorage), 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if mockStorage, ok := tt.store.(*MockHistoryStorage); ok {
+				mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+			}
+			manager := NewManager(tt.store, &FilterTasks{}, new(MockRender))
+			_, err := manager.Undo(tt.n)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestStatusTimestamps(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	mockStorage := new(MockStorage)
+	mockRender := new(MockRender)
+	mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+	mockStorage.On("Save", mock.Anything, mock.Anything).Return(nil)
+	mockRender.On("RenderDetailed", mock.Anything).Return()
+
+	manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+	_, err = manager.Start(1, false)
+	require.NoError(t, err)
+	require.NotNil(t, tasksMany[0].StartedAt)
+	assert.Nil(t, tasksMany[0].CompletedAt)
+
+	_, err = manager.Complete(1, false)
+	require.NoError(t, err)
+	require.NotNil(t, tasksMany[0].CompletedAt)
+	_, ok := tasksMany[0].CycleTime()
+	assert.True(t, ok)
+
+	require.NoError(t, manager.Edit(1, map[string]string{"status": task.StatusPending.String()}))
+	assert.Nil(t, tasksMany[0].StartedAt)
+	assert.Nil(t, tasksMany[0].CompletedAt)
+	assert.Len(t, tasksMany[0].StatusHistory, 4)
+}
+
+func TestTrash(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	mockStorage := new(MockStorage)
+	filter := &FilterTasks{}
+	mockRender := new(MockRender)
+
+	var saved []*task.Task
+	mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+	mockStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*task.Task)
+	}).Return(nil)
+	mockRender.On("RenderList", mock.Anything).Return()
+	mockRender.On("RenderDetailed", mock.Anything).Return()
+	mockRender.On("RenderStats", mock.Anything).Return()
+
+	manager := NewManager(mockStorage, filter, mockRender)
+	require.NoError(t, manager.Delete(1, ""))
+	require.NoError(t, manager.Delete(5, ""))
+	assert.True(t, tasksMany[0].IsDeleted())
+
+	// задачи из корзины не выводятся, не ищутся и не учитываются в статистике
+	require.NoError(t, manager.List(ListOptions{}))
+	mockRender.AssertCalled(t, "RenderList", []*task.Task{tasksMany[1], tasksMany[2], tasksMany[3], tasksMany[5]})
+	require.NoError(t, manager.Stats(""))
+	mockRender.AssertCalled(t, "RenderStats", testutil.StatsTask(4, 2, 1, 1, 0))
+	assert.ErrorIs(t, manager.Search("pending task 1"), task.ErrTaskNotFound)
+	assert.ErrorIs(t, manager.Show(1), task.ErrTaskNotFound)
+	assert.ErrorIs(t, manager.Delete(1, ""), task.ErrTaskNotFound)
+
+	require.NoError(t, manager.Trash())
+	mockRender.AssertCalled(t, "RenderList", []*task.Task{tasksMany[0], tasksMany[4]})
+
+	require.NoError(t, manager.Restore(1))
+	assert.False(t, tasksMany[0].IsDeleted())
+	assert.ErrorIs(t, manager.Restore(2), task.ErrTaskNotFound)
+
+	// задача удалена только что - в корзине она меньше суток
+	count, err := manager.EmptyTrash("1d")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = manager.EmptyTrash("")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Len(t, saved, 5)
+	assert.NotContains(t, saved, tasksMany[4])
+
+	_, err = manager.EmptyTrash("month")
+	assert.ErrorIs(t, err, task.ErrInvalidPeriod)
+}
+
 func TestHasKeys(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -495,3 +1220,432 @@ func TestHasKeys(t *testing.T) {
 		})
 	}
 }
+
+// subtaskTasks возвращает задачи ManyTasks с иерархией: #1 -> #2 -> #3, #1 -> #4 (выполнена).
+func subtaskTasks(t *testing.T) []*task.Task {
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	tasks[1].ParentID = 1
+	tasks[2].ParentID = 2
+	tasks[3].ParentID = 1
+	return tasks
+}
+
+func TestSubtasks(t *testing.T) {
+	t.Run("создание подзадачи", func(t *testing.T) {
+		tasks := subtaskTasks(t)
+		mockStorage := new(MockStorage)
+		mockRender := new(MockRender)
+		mockStorage.On("Load", mock.Anything).Return(tasks, nil)
+		mockStorage.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRender.On("RenderDetailed", mock.Anything).Return()
+		manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+
+		id, err := manager.Create(map[string]string{"title": "write tests", "description": "", "parent": "2"})
+		require.NoError(t, err)
+		created := mockStorage.Calls[1].Arguments.Get(0).([]*task.Task)[6]
+		assert.Equal(t, 7, *id)
+		assert.Equal(t, 2, created.ParentID)
+
+		_, err = manager.Create(map[string]string{"title": "write docs", "description": "", "parent": "99"})
+		assert.ErrorIs(t, err, task.ErrTaskNotFound)
+		_, err = manager.Create(map[string]string{"title": "write docs", "description": "", "parent": "x"})
+		assert.ErrorIs(t, err, task.ErrInvalidID)
+		_, err = manager.Create(map[string]string{"title": "write docs", "description": "", "parent": "4"})
+		assert.ErrorIs(t, err, task.ErrInvalidParent)
+	})
+
+	t.Run("завершение задачи с подзадачами", func(t *testing.T) {
+		tasks := subtaskTasks(t)
+		mockStorage := new(MockStorage)
+		mockRender := new(MockRender)
+		mockStorage.On("Load", mock.Anything).Return(tasks, nil)
+		mockStorage.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRender.On("RenderDetailed", mock.Anything).Return()
+		manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+
+		_, err := manager.Complete(1, false)
+		assert.ErrorIs(t, err, task.ErrHasSubtasks)
+		assert.ErrorIs(t, manager.Edit(2, map[string]string{"status": "completed"}), task.ErrHasSubtasks)
+		assert.Equal(t, task.StatusPending, tasks[0].Status)
+
+		_, err = manager.Complete(1, true)
+		require.NoError(t, err)
+		for _, value := range tasks[:4] {
+			assert.Equal(t, task.StatusCompleted, value.Status)
+		}
+	})
+
+	t.Run("удаление задачи с подзадачами", func(t *testing.T) {
+		tests := []struct {
+			name            string
+			subtasks        string
+			expectedErr     error
+			expectedDeleted []bool
+			expectedParents []int
+		}{
+			{"без флага", "", task.ErrHasSubtasks, []bool{false, false, false, false}, []int{0, 1, 2, 1}},
+			{"каскадно", SubtasksCascade, nil, []bool{true, true, true, true}, []int{0, 1, 2, 1}},
+			{"с отвязкой подзадач", SubtasksDetach, nil, []bool{true, false, false, false}, []int{0, 0, 2, 0}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tasks := subtaskTasks(t)
+				mockStorage := new(MockHistoryStorage)
+				mockRender := new(MockRender)
+				var saved []*task.Task
+				mockStorage.On("Load", mock.Anything).Return(tasks, nil)
+				mockStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					saved = args.Get(0).([]*task.Task)
+				}).Return(nil)
+				mockRender.On("RenderHistory", mock.Anything).Return()
+				manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+
+				err := manager.Delete(1, tt.subtasks)
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				} else {
+					require.NoError(t, err)
+				}
+				for i, value := range tasks[:4] {
+					assert.Equal(t, tt.expectedDeleted[i], value.IsDeleted(), "#%d", value.ID)
+					assert.Equal(t, tt.expectedParents[i], value.ParentID, "#%d", value.ID)
+				}
+				if tt.expectedErr != nil {
+					return
+				}
+
+				// удаление вместе с подзадачами отменяется одной операцией
+				count, err := manager.Undo(1)
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+				for i, value := range saved[:4] {
+					assert.False(t, value.IsDeleted(), "#%d", value.ID)
+					assert.Equal(t, []int{0, 1, 2, 1}[i], value.ParentID, "#%d", value.ID)
+				}
+			})
+		}
+	})
+
+	t.Run("показ и дерево", func(t *testing.T) {
+		tasks := subtaskTasks(t)
+		mockStorage := new(MockStorage)
+		mockRender := new(MockRender)
+		mockStorage.On("Load", mock.Anything).Return(tasks, nil)
+		mockRender.On("RenderWithSubtasks", mock.Anything, mock.Anything).Return()
+		mockRender.On("RenderDetailed", mock.Anything).Return()
+		mockRender.On("RenderTree", mock.Anything).Return()
+		manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+
+		require.NoError(t, manager.Show(1))
+		mockRender.AssertCalled(t, "RenderWithSubtasks", tasks[0], []*task.Task{tasks[1], tasks[3]})
+		require.NoError(t, manager.Show(3))
+		mockRender.AssertCalled(t, "RenderDetailed", tasks[2])
+
+		require.NoError(t, manager.List(ListOptions{Tree: true}))
+		nodes := mockRender.Calls[len(mockRender.Calls)-1].Arguments.Get(0).([]render.TaskNode)
+		require.Len(t, nodes, 3)
+		assert.Equal(t, 1, nodes[0].Task.ID)
+		require.Len(t, nodes[0].Children, 2)
+		assert.Equal(t, 3, nodes[0].Children[0].Children[0].Task.ID)
+	})
+
+	t.Run("очистка корзины отвязывает подзадачи", func(t *testing.T) {
+		tasks := subtaskTasks(t)
+		deleted := time.Now().Add(-time.Hour)
+		tasks[0].DeletedAt = &deleted
+		mockStorage := new(MockStorage)
+		mockStorage.On("Load", mock.Anything).Return(tasks, nil)
+		mockStorage.On("Save", mock.Anything, mock.Anything).Return(nil)
+		manager := NewManager(mockStorage, &FilterTasks{}, new(MockRender))
+
+		count, err := manager.EmptyTrash("")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 0, tasks[1].ParentID)
+		assert.Equal(t, 0, tasks[3].ParentID)
+		assert.Equal(t, 2, tasks[2].ParentID)
+	})
+}
+
+// dependencyTasks возвращает задачи ManyTasks с зависимостями: #1 ждёт #2 и #4 (выполнена), #2 ждёт #3.
+func dependencyTasks(t *testing.T) []*task.Task {
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	tasks[0].AddBlockers(2, 4)
+	tasks[1].AddBlockers(3)
+	return tasks
+}
+
+func TestDependencies(t *testing.T) {
+	newManager := func(tasks []*task.Task) (*Manager, *MockRender) {
+		mockStorage := new(MockStorage)
+		mockRender := new(MockRender)
+		mockStorage.On("Load", mock.Anything).Return(tasks, nil)
+		mockStorage.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRender.On("RenderDetailed", mock.Anything).Return()
+		mockRender.On("RenderList", mock.Anything).Return()
+		mockRender.On("RenderGraph", mock.Anything, mock.Anything).Return()
+		return NewManager(mockStorage, &FilterTasks{}, mockRender), mockRender
+	}
+
+	t.Run("добавление зависимостей", func(t *testing.T) {
+		tasks := dependencyTasks(t)
+		manager, _ := newManager(tasks)
+
+		require.NoError(t, manager.Block(5, []int{1, 6}))
+		assert.Equal(t, []int{1, 6}, tasks[4].BlockedBy)
+
+		tests := []struct {
+			name        string
+			id          int
+			by          []int
+			expectedErr error
+		}{
+			{"зависимость от себя", 3, []int{3}, task.ErrDependencyCycle},
+			{"прямой цикл", 3, []int{2}, task.ErrDependencyCycle},
+			{"цикл через задачу", 3, []int{1}, task.ErrDependencyCycle},
+			{"блокирующей задачи нет", 3, []int{99}, task.ErrTaskNotFound},
+			{"задачи нет", 99, []int{1}, task.ErrTaskNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.ErrorIs(t, manager.Block(tt.id, tt.by), tt.expectedErr)
+			})
+		}
+		assert.Empty(t, tasks[2].BlockedBy)
+
+		require.NoError(t, manager.Unblock(1, []int{4}))
+		assert.Equal(t, []int{2}, tasks[0].BlockedBy)
+		require.NoError(t, manager.Unblock(1, nil))
+		assert.Nil(t, tasks[0].BlockedBy)
+	})
+
+	t.Run("начало заблокированной задачи", func(t *testing.T) {
+		tasks := dependencyTasks(t)
+		manager, _ := newManager(tasks)
+
+		_, err := manager.Start(1, false)
+		assert.ErrorIs(t, err, task.ErrBlocked)
+		assert.ErrorContains(t, err, "#2")
+		assert.NotContains(t, err.Error(), "#4")
+		assert.Equal(t, task.StatusPending, tasks[0].Status)
+
+		_, err = manager.Start(1, true)
+		require.NoError(t, err)
+		assert.Equal(t, task.StatusProgress, tasks[0].Status)
+	})
+
+	t.Run("фильтры и граф", func(t *testing.T) {
+		tasks := dependencyTasks(t)
+		manager, mockRender := newManager(tasks)
+
+		require.NoError(t, manager.List(ListOptions{Blocked: true}))
+		mockRender.AssertCalled(t, "RenderList", []*task.Task{tasks[0], tasks[1]})
+		require.NoError(t, manager.List(ListOptions{Ready: true}))
+		mockRender.AssertCalled(t, "RenderList", []*task.Task{tasks[2]})
+
+		require.NoError(t, manager.Graph(render.GraphDOT))
+		mockRender.AssertCalled(t, "RenderGraph", render.Graph{
+			Tasks: []*task.Task{tasks[0], tasks[1], tasks[2], tasks[3]},
+			Dependencies: []render.Dependency{
+				{Task: 1, BlockedBy: 2}, {Task: 1, BlockedBy: 4}, {Task: 2, BlockedBy: 3},
+			},
+		}, render.GraphDOT)
+		assert.ErrorIs(t, manager.Graph("svg"), render.ErrUnknownFormat)
+	})
+
+	t.Run("очистка корзины снимает зависимости", func(t *testing.T) {
+		tasks := dependencyTasks(t)
+		deleted := time.Now().Add(-time.Hour)
+		tasks[1].DeletedAt = &deleted
+		manager, _ := newManager(tasks)
+
+		_, err := manager.EmptyTrash("")
+		require.NoError(t, err)
+		assert.Equal(t, []int{4}, tasks[0].BlockedBy)
+	})
+
+	t.Run("граф без зависимостей", func(t *testing.T) {
+		tasks, err := testutil.ManyTasks()
+		require.NoError(t, err)
+		manager, _ := newManager(tasks)
+
+		assert.Error(t, manager.Graph(render.GraphText))
+	})
+}
+
+func TestRecurringTasks(t *testing.T) {
+	mockStorage := new(MockHistoryStorage)
+	mockRender := new(MockRender)
+
+	var saved []*task.Task
+	mockStorage.On("Load", mock.Anything).Return(testutil.EmptyTasks(), nil).Once()
+	mockStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*task.Task)
+	}).Return(nil)
+	mockRender.On("RenderDetailed", mock.Anything).Return()
+	mockRender.On("RenderRecurring", mock.Anything).Return()
+	mockRender.On("RenderHistory", mock.Anything).Return()
+
+	manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+	_, err := manager.Create(map[string]string{"title": "weekly report", "description": "", "every": "2weeks", "due": "today"})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2", saved[0].Recur)
+	assert.Equal(t, 1, saved[0].SeriesID)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	next, err := manager.Complete(1, false)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, 2, *next)
+	require.Len(t, saved, 2)
+	assert.Equal(t, task.StatusPending, saved[1].Status)
+	assert.Equal(t, *saved[0].Due, saved[1].Due.AddDate(0, 0, -14))
+	assert.Equal(t, 1, saved[1].SeriesID)
+
+	// отмена завершения удаляет следующую задачу серии и переоткрывает задачу, повтор возвращает обе
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	_, err = manager.Undo(1)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, task.StatusPending, saved[0].Status)
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	_, err = manager.Redo(1)
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, task.StatusCompleted, saved[0].Status)
+
+	// повторное завершение не создаёт новую задачу серии
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	next, err = manager.Complete(1, false)
+	require.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Len(t, saved, 2)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	require.NoError(t, manager.List(ListOptions{Recurring: true}))
+	mockRender.AssertCalled(t, "RenderRecurring", mock.Anything)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	assert.ErrorIs(t, manager.Edit(2, map[string]string{"every": "sometimes"}), task.ErrInvalidRecurrence)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	require.NoError(t, manager.Edit(2, map[string]string{"every": "none"}))
+	assert.Empty(t, saved[1].Recur)
+	assert.Equal(t, 1, saved[1].SeriesID)
+}
+
+func TestTimeTracking(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	mockStorage := new(MockHistoryStorage)
+	mockRender := new(MockRender)
+
+	saved := tasksMany
+	mockStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*task.Task)
+	}).Return(nil)
+	mockRender.On("RenderDetailed", mock.Anything).Return()
+	mockRender.On("RenderTimeLog", mock.Anything).Return()
+	mockRender.On("RenderTimeReport", mock.Anything).Return()
+	mockRender.On("RenderHistory", mock.Anything).Return()
+	manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	stopped, err := manager.Start(1, false)
+	require.NoError(t, err)
+	assert.Nil(t, stopped)
+	assert.True(t, saved[0].IsTracking())
+
+	// запуск другой задачи останавливает учёт времени по первой
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	stopped, err = manager.Start(2, false)
+	require.NoError(t, err)
+	require.NotNil(t, stopped)
+	assert.Equal(t, 1, *stopped)
+	assert.False(t, saved[0].IsTracking())
+	assert.Equal(t, task.StatusProgress, saved[0].Status)
+	assert.True(t, saved[1].IsTracking())
+	assert.Equal(t, journal.OpStop, mockStorage.history.Done[len(mockStorage.history.Done)-2].Op)
+
+	// отмена запуска возобновляет учёт времени по первой задаче
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	_, err = manager.Undo(1)
+	require.NoError(t, err)
+	assert.True(t, saved[0].IsTracking())
+	assert.False(t, saved[1].IsTracking())
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	_, err = manager.Redo(1)
+	require.NoError(t, err)
+	assert.False(t, saved[0].IsTracking())
+	assert.True(t, saved[1].IsTracking())
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	id, err := manager.Stop()
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+	assert.False(t, saved[1].IsTracking())
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	_, err = manager.Stop()
+	assert.ErrorIs(t, err, task.ErrNotTracking)
+
+	// завершение задачи останавливает учёт времени
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	_, err = manager.Start(2, false)
+	require.NoError(t, err)
+	require.Len(t, saved[1].TimeLog, 2)
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	_, err = manager.Complete(2, false)
+	require.NoError(t, err)
+	assert.False(t, saved[1].IsTracking())
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	require.NoError(t, manager.TimeLog(TimeByDay, DefaultLogDays))
+	mockRender.AssertCalled(t, "RenderTimeLog", mock.Anything)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	require.NoError(t, manager.TimeReport(PeriodWeek))
+	mockRender.AssertCalled(t, "RenderTimeReport", mock.Anything)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	assert.ErrorIs(t, manager.TimeReport("year"), task.ErrInvalidPeriod)
+}
+
+func TestEstimates(t *testing.T) {
+	mockStorage := new(MockStorage)
+	mockRender := new(MockRender)
+
+	var saved []*task.Task
+	mockStorage.On("Load", mock.Anything).Return(testutil.EmptyTasks(), nil).Once()
+	mockStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]*task.Task)
+	}).Return(nil)
+	mockRender.On("RenderDetailed", mock.Anything).Return()
+	mockRender.On("RenderEffortStats", mock.Anything).Return()
+
+	manager := NewManager(mockStorage, &FilterTasks{}, mockRender)
+	_, err := manager.Create(map[string]string{"title": "write docs", "description": "", "estimate": "90m"})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, &task.Estimate{Duration: 90 * time.Minute}, saved[0].Estimate)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	require.NoError(t, manager.Edit(1, map[string]string{"estimate": "5pt"}))
+	assert.Equal(t, &task.Estimate{Points: 5}, saved[0].Estimate)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	assert.ErrorIs(t, manager.Edit(1, map[string]string{"estimate": "soon"}), task.ErrInvalidEstimate)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	require.NoError(t, manager.Edit(1, map[string]string{"estimate": "none"}))
+	assert.Nil(t, saved[0].Estimate)
+
+	mockStorage.On("Load", mock.Anything).Return(saved, nil).Once()
+	require.NoError(t, manager.Effort())
+	mockRender.AssertCalled(t, "RenderEffortStats", mock.Anything)
+}
@@ -0,0 +1,167 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+)
+
+// длительности помодоро по умолчанию
+const (
+	DefaultPomodoroWork  = 25 * time.Minute
+	DefaultPomodoroBreak = 5 * time.Minute
+)
+
+// PomodoroOptions - длительность работы и перерыва, количество помодоро подряд
+// и частота обновления индикатора (по умолчанию раз в секунду).
+type PomodoroOptions struct {
+	Work   time.Duration
+	Break  time.Duration
+	Rounds int
+	Tick   time.Duration
+}
+
+// PomodoroResult - итог запуска помодоро: сколько помодоро отработано полностью,
+// был ли отсчёт прерван и сколько всего времени заняла работа.
+type PomodoroResult struct {
+	Completed   int
+	Interrupted bool
+	Worked      time.Duration
+}
+
+// Pomodoro запускает помодоро по задаче: Rounds раз отсчитывает время работы и перерыва,
+// выводя прогресс через Render.RenderPomodoro. На время работы задача переводится в работу
+// и по ней запускается учёт времени (см. Start), после работы учёт останавливается и помодоро
+// записывается в журнал помодоро задачи. Файл задач блокируется только на время записи.
+// Если ctx отменён во время работы (например, по Ctrl+C), отработанная часть записывается
+// как прерванный помодоро, во время перерыва - отсчёт просто прекращается.
+// Выводит детальную информацию о задаче после окончания помодоро.
+// Возвращает ошибку, если параметры некорректны, задача не найдена, в корзине, выполнена
+// или ждёт завершения других задач, не будучи в работе.
+func (m *Manager) Pomodoro(ctx context.Context, id int, options PomodoroOptions) (PomodoroResult, error) {
+	result := PomodoroResult{}
+	if options.Work <= 0 || options.Break < 0 || options.Rounds <= 0 {
+		return result, fmt.Errorf("некорректные параметры помодоро: работа %s, перерыв %s, количество %d",
+			options.Work, options.Break, options.Rounds)
+	}
+	if options.Tick <= 0 {
+		options.Tick = time.Second
+	}
+	for round := 1; round <= options.Rounds; round++ {
+		value, err := m.beginPomodoro(id)
+		if err != nil {
+			return result, fmt.Errorf("не удалось начать помодоро: %w", err)
+		}
+		started := time.Now()
+		progress := render.PomodoroProgress{Task: value, Phase: render.PhaseWork, Round: round, Rounds: options.Rounds}
+		completed := m.countdown(ctx, progress, options.Work, options.Tick)
+		value, err = m.finishPomodoro(id, started, completed)
+		if err != nil {
+			return result, fmt.Errorf("не удалось записать помодоро: %w", err)
+		}
+		result.Worked += time.Since(started)
+		if !completed {
+			result.Interrupted = true
+			break
+		}
+		result.Completed += 1
+		progress.Task, progress.Phase = value, render.PhaseBreak
+		if options.Break > 0 && !m.countdown(ctx, progress, options.Break, options.Tick) {
+			result.Interrupted = true
+			break
+		}
+	}
+	return result, m.Show(id)
+}
+
+// countdown отсчитывает total, перерисовывая прогресс каждые tick.
+// Возвращает false, если ctx отменён до окончания отсчёта.
+func (m *Manager) countdown(ctx context.Context, progress render.PomodoroProgress, total, tick time.Duration) bool {
+	start := time.Now()
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	progress.Total = total
+	for {
+		progress.Elapsed = min(time.Since(start), total)
+		if progress.Elapsed >= total {
+			m.render.RenderPomodoro(progress)
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			progress.Interrupted = true
+			m.render.RenderPomodoro(progress)
+			return false
+		case <-ticker.C:
+			m.render.RenderPomodoro(progress)
+		}
+	}
+}
+
+// beginPomodoro переводит задачу в работу и запускает по ней учёт времени перед помодоро.
+// Задачу в работе можно начать, даже если она ждёт других задач: её уже начали с --force.
+// Возвращает копию задачи или ошибку task.ErrInvalidStatus, если задача уже выполнена.
+func (m *Manager) beginPomodoro(id int) (*task.Task, error) {
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении: %w", err)
+	}
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return nil, err
+	}
+	switch tasks[*indexTask].Status {
+	case task.StatusCompleted:
+		return nil, fmt.Errorf("%w: #%d уже выполнена", task.ErrInvalidStatus, id)
+	case task.StatusProgress:
+		if tasks[*indexTask].IsTracking() {
+			return tasks[*indexTask].Clone(), nil
+		}
+	}
+	indexTask, _, err = startTask(m, tasks, id, tasks[*indexTask].Status == task.StatusProgress)
+	if err != nil {
+		return nil, err
+	}
+	return tasks[*indexTask].Clone(), nil
+}
+
+// finishPomodoro останавливает учёт времени по задаче и записывает помодоро, начатый в started.
+// Если учёт времени по задаче уже остановлен другой командой, записывается только помодоро.
+// Возвращает копию задачи.
+func (m *Manager) finishPomodoro(id int, started time.Time, completed bool) (*task.Task, error) {
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении: %w", err)
+	}
+	indexTask, err := activeIndex(m, tasks, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	before := tasks[*indexTask].Clone()
+	tasks[*indexTask].StopTracking(now)
+	tasks[*indexTask].Pomodoros = append(tasks[*indexTask].Pomodoros,
+		task.Pomodoro{Start: started, End: now, Completed: completed})
+	err = m.store.Save(tasks, m.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при записи: %w", err)
+	}
+	err = m.record(journal.OpPomodoro, before, tasks[*indexTask])
+	if err != nil {
+		return nil, err
+	}
+	return tasks[*indexTask].Clone(), nil
+}
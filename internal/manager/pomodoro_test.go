@@ -0,0 +1,77 @@
+//go:build !production
+
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPomodoro(t *testing.T) {
+	options := PomodoroOptions{Work: 30 * time.Millisecond, Break: 10 * time.Millisecond, Rounds: 2, Tick: 5 * time.Millisecond}
+
+	newManager := func(t *testing.T) (*Manager, []*task.Task, *MockHistoryStorage) {
+		tasksMany, err := testutil.ManyTasks()
+		require.NoError(t, err)
+		mockStorage := new(MockHistoryStorage)
+		mockRender := new(MockRender)
+		mockStorage.On("Load", mock.Anything).Return(tasksMany, nil)
+		mockStorage.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRender.On("RenderPomodoro", mock.Anything).Return()
+		mockRender.On("RenderDetailed", mock.Anything).Return()
+		return NewManager(mockStorage, &FilterTasks{}, mockRender), tasksMany, mockStorage
+	}
+
+	t.Run("помодоро подряд", func(t *testing.T) {
+		manager, tasks, mockStorage := newManager(t)
+		result, err := manager.Pomodoro(context.Background(), 1, options)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Completed)
+		assert.False(t, result.Interrupted)
+		assert.GreaterOrEqual(t, result.Worked, 2*options.Work)
+
+		completed, interrupted := tasks[0].PomodoroCount()
+		assert.Equal(t, 2, completed)
+		assert.Zero(t, interrupted)
+		assert.Len(t, tasks[0].TimeLog, 2)
+		assert.False(t, tasks[0].IsTracking())
+		assert.Equal(t, task.StatusProgress, tasks[0].Status)
+		assert.Equal(t, journal.OpPomodoro, mockStorage.history.Recent()[0].Op)
+	})
+
+	t.Run("прерывание записывает отработанную часть", func(t *testing.T) {
+		manager, tasks, _ := newManager(t)
+		// #2 уже в работе с учётом времени - помодоро продолжает тот же интервал
+		_, err := manager.Start(2, false)
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		result, err := manager.Pomodoro(ctx, 2, PomodoroOptions{Work: time.Hour, Rounds: 1, Tick: 5 * time.Millisecond})
+		require.NoError(t, err)
+		assert.True(t, result.Interrupted)
+		assert.Zero(t, result.Completed)
+
+		require.Len(t, tasks[1].Pomodoros, 1)
+		assert.False(t, tasks[1].Pomodoros[0].Completed)
+		assert.Len(t, tasks[1].TimeLog, 1)
+		assert.False(t, tasks[1].IsTracking())
+	})
+
+	t.Run("ошибки", func(t *testing.T) {
+		manager, _, _ := newManager(t)
+		_, err := manager.Pomodoro(context.Background(), 4, options)
+		assert.ErrorIs(t, err, task.ErrInvalidStatus)
+		_, err = manager.Pomodoro(context.Background(), 99, options)
+		assert.ErrorIs(t, err, task.ErrTaskNotFound)
+		_, err = manager.Pomodoro(context.Background(), 1, PomodoroOptions{Rounds: 1})
+		assert.Error(t, err)
+	})
+}
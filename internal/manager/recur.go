@@ -0,0 +1,89 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+)
+
+// значение правила повтора, которое снимает повтор с задачи при редактировании
+const recurNone = "none"
+
+// setRecurrence задаёт задаче правило повтора в формате RRULE, а "none" снимает повтор.
+// Задача без серии начинает новую серию со своим ID.
+func setRecurrence(value *task.Task, rule string) error {
+	if rule == recurNone {
+		value.Recur = ""
+		return nil
+	}
+	recurrence, err := task.ParseRecurrence(rule)
+	if err != nil {
+		return err
+	}
+	value.Recur = recurrence.String()
+	if value.SeriesID == 0 {
+		value.SeriesID = value.ID
+	}
+	return nil
+}
+
+// nextDue возвращает срок следующего повтора задачи: следующую дату по правилу после её срока,
+// а для задачи без срока - после now. Пропущенные повторы не создаются: срок сдвигается, пока не станет позже now.
+func nextDue(rule task.Recurrence, due *time.Time, now time.Time) time.Time {
+	today := task.StartOfDay(now)
+	next := rule.Next(today)
+	if due != nil {
+		next = rule.Next(*due)
+	}
+	for !next.After(today) {
+		next = rule.Next(next)
+	}
+	return next
+}
+
+// nextInstance возвращает следующую задачу серии для выполненной повторяющейся задачи done:
+// с теми же названием, описанием, приоритетом, тегами и правилом повтора, новым ID и сдвинутым сроком.
+// Подзадачи и зависимости в следующую задачу не переносятся.
+// Возвращает ошибку task.ErrInvalidRecurrence, если правило повтора задачи повреждено.
+func nextInstance(tasks []*task.Task, done *task.Task, now time.Time) (*task.Task, error) {
+	rule, err := task.ParseRecurrence(done.Recur)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать следующую задачу серии #%d: %w", done.SeriesID, err)
+	}
+	next, err := task.NewTask(nextID(tasks), done.Title, done.Description, task.StatusPending.String())
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать следующую задачу серии #%d: %w", done.SeriesID, err)
+	}
+	due := nextDue(rule, done.Due, now)
+	next.Due = &due
+	next.Priority = done.Priority
+	next.AddTags(done.Tags...)
+	next.Recur = done.Recur
+	next.SeriesID = done.SeriesID
+	return next, nil
+}
+
+// GetRecurring возвращает серии повторяющихся задач по невыполненным задачам с правилом повтора из tasks
+// в порядке следования. Количество выполненных задач серии считается по all.
+// Задачи с повреждённым правилом повтора пропускаются.
+func (f *FilterTasks) GetRecurring(tasks []*task.Task, all []*task.Task, now time.Time) []render.Series {
+	series := make([]render.Series, 0)
+	for _, value := range tasks {
+		if value.Recur == "" || value.Status == task.StatusCompleted {
+			continue
+		}
+		rule, err := task.ParseRecurrence(value.Recur)
+		if err != nil {
+			continue
+		}
+		item := render.Series{Task: value, Rule: rule, Next: nextDue(rule, value.Due, now)}
+		for _, other := range all {
+			if other.SeriesID == value.SeriesID && other.Status == task.StatusCompleted {
+				item.Completed += 1
+			}
+		}
+		series = append(series, item)
+	}
+	return series
+}
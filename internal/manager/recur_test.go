@@ -0,0 +1,87 @@
+//go:build !production
+
+package manager
+
+import (
+	"testing"
+	"time"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextDue(t *testing.T) {
+	// среда, 15 октября 2025
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+	date := func(month time.Month, day int) *time.Time {
+		return testutil.TimePtr(time.Date(2025, month, day, 0, 0, 0, 0, time.UTC))
+	}
+	weekly, err := task.ParseRecurrence("week")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		due      *time.Time
+		expected time.Time
+	}{
+		{"без срока - от сегодняшнего дня", nil, *date(time.October, 22)},
+		{"выполнена в срок", date(time.October, 17), *date(time.October, 24)},
+		{"выполнена раньше срока", date(time.October, 20), *date(time.October, 27)},
+		{"пропущенные повторы не создаются", date(time.September, 1), *date(time.October, 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nextDue(weekly, tt.due, now))
+		})
+	}
+}
+
+func TestNextInstance(t *testing.T) {
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	done := tasks[3]
+	done.AddTags("finance")
+	done.ParentID = 1
+	done.AddBlockers(2)
+	done.Due = testutil.TimePtr(time.Date(2025, time.October, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, setRecurrence(done, "FREQ=MONTHLY;BYMONTHDAY=-1"))
+
+	next, err := nextInstance(tasks, done, time.Date(2025, time.October, 31, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 7, next.ID)
+	assert.Equal(t, done.Title, next.Title)
+	assert.Equal(t, task.StatusPending, next.Status)
+	assert.Equal(t, time.Date(2025, time.November, 30, 0, 0, 0, 0, time.UTC), *next.Due)
+	assert.Equal(t, []string{"finance"}, next.Tags)
+	assert.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=-1", next.Recur)
+	assert.Equal(t, 4, next.SeriesID)
+	assert.Zero(t, next.ParentID)
+	assert.Empty(t, next.BlockedBy)
+
+	done.Recur = "broken"
+	_, err = nextInstance(tasks, done, time.Now())
+	assert.ErrorIs(t, err, task.ErrInvalidRecurrence)
+}
+
+func TestGetRecurring(t *testing.T) {
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	// серия #4: выполнены #4 и #5, текущая - #1
+	for _, index := range []int{3, 4, 0} {
+		tasks[index].Recur = "FREQ=WEEKLY"
+		tasks[index].SeriesID = 4
+	}
+	tasks[0].Due = testutil.TimePtr(time.Date(2025, time.October, 17, 0, 0, 0, 0, time.UTC))
+	filter := &FilterTasks{}
+
+	series := filter.GetRecurring(tasks, tasks, now)
+	require.Len(t, series, 1)
+	assert.Equal(t, tasks[0], series[0].Task)
+	assert.Equal(t, task.FreqWeekly, series[0].Rule.Freq)
+	assert.Equal(t, time.Date(2025, time.October, 24, 0, 0, 0, 0, time.UTC), series[0].Next)
+	assert.Equal(t, 2, series[0].Completed)
+}
@@ -0,0 +1,214 @@
+package manager
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+)
+
+// группировки затраченного времени (см. GetTimeLog)
+const (
+	TimeByTask = "task"
+	TimeByDay  = "day"
+	TimeByTag  = "tag"
+)
+
+// количество дней в журнале затраченного времени по умолчанию
+const DefaultLogDays = 7
+
+// значения ключа "timer" в карте данных editTask
+const (
+	timerStart = "start"
+	timerStop  = "stop"
+)
+
+// trackingIndex возвращает индекс задачи вне корзины, по которой идёт учёт времени, или nil.
+func trackingIndex(tasks []*task.Task) *int {
+	for index, value := range tasks {
+		if !value.IsDeleted() && value.IsTracking() {
+			return &index
+		}
+	}
+	return nil
+}
+
+// timeByTask возвращает время, затраченное на каждую задачу с from до to, по убыванию времени.
+// Задачи, на которые время не тратилось, не попадают в результат.
+func timeByTask(tasks []*task.Task, from, to, now time.Time) []render.TimeEntry {
+	entries := make([]render.TimeEntry, 0)
+	for _, value := range tasks {
+		if spent := value.TimeSpentBetween(from, to, now); spent > 0 {
+			entries = append(entries, render.TimeEntry{Task: value, Spent: spent})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Spent > entries[j].Spent
+	})
+	return entries
+}
+
+// timeByTag возвращает время, затраченное на задачи с каждым тегом с from до to, по убыванию времени.
+// Время задачи с несколькими тегами учитывается в каждом из них, задачи без тегов собираются в строку с пустым тегом.
+func timeByTag(tasks []*task.Task, from, to, now time.Time) []render.TimeEntry {
+	spentByTag := make(map[string]time.Duration)
+	for _, value := range tasks {
+		spent := value.TimeSpentBetween(from, to, now)
+		if spent == 0 {
+			continue
+		}
+		if len(value.Tags) == 0 {
+			spentByTag[""] += spent
+		}
+		for _, tag := range value.Tags {
+			spentByTag[tag] += spent
+		}
+	}
+	entries := make([]render.TimeEntry, 0, len(spentByTag))
+	for tag, spent := range spentByTag {
+		entries = append(entries, render.TimeEntry{Tag: tag, Spent: spent})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Spent != entries[j].Spent {
+			return entries[i].Spent > entries[j].Spent
+		}
+		return entries[i].Tag < entries[j].Tag
+	})
+	return entries
+}
+
+// timeByDay возвращает время, затраченное на задачи за каждый день с from до to (не включая to).
+// Интервал работы, захватывающий полночь, делится между днями.
+func timeByDay(tasks []*task.Task, from, to, now time.Time) []render.TimeEntry {
+	entries := make([]render.TimeEntry, 0)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		entry := render.TimeEntry{Date: day}
+		for _, value := range tasks {
+			entry.Spent += value.TimeSpentBetween(day, day.AddDate(0, 0, 1), now)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// totalTime возвращает сумму затраченного времени по строкам.
+func totalTime(entries []render.TimeEntry) time.Duration {
+	var total time.Duration
+	for _, entry := range entries {
+		total += entry.Spent
+	}
+	return total
+}
+
+// GetTimeLog возвращает время, затраченное на задачи за последние days дней (включая сегодняшний),
+// сгруппированное по задачам, дням или тегам (TimeByTask, TimeByDay, TimeByTag), и задачу,
+// по которой сейчас идёт учёт времени. Дни без затраченного времени не выводятся.
+// Возвращает ошибку, если days не положительное или группировка неизвестна.
+func (f *FilterTasks) GetTimeLog(tasks []*task.Task, by string, days int, now time.Time) (render.TimeLog, error) {
+	if days <= 0 {
+		return render.TimeLog{}, fmt.Errorf("количество дней должно быть положительным: %d", days)
+	}
+	to := task.StartOfDay(now).AddDate(0, 0, 1)
+	log := render.TimeLog{By: by, From: to.AddDate(0, 0, -days), To: to}
+	switch by {
+	case TimeByTask:
+		log.Entries = timeByTask(tasks, log.From, log.To, now)
+	case TimeByTag:
+		log.Entries = timeByTag(tasks, log.From, log.To, now)
+	case TimeByDay:
+		log.Entries = make([]render.TimeEntry, 0)
+		for _, entry := range timeByDay(tasks, log.From, log.To, now) {
+			if entry.Spent > 0 {
+				log.Entries = append(log.Entries, entry)
+			}
+		}
+	default:
+		return render.TimeLog{}, fmt.Errorf("неизвестная группировка времени: %s", by)
+	}
+	// время задачи с несколькими тегами входит в несколько строк, поэтому общее время считается по задачам
+	log.Total = totalTime(timeByTask(tasks, log.From, log.To, now))
+	if index := trackingIndex(tasks); index != nil {
+		log.Active = tasks[*index]
+	}
+	return log, nil
+}
+
+// GetTimeReport возвращает отчёт о затраченном времени за текущую неделю (с понедельника) или месяц:
+// время по каждому дню периода и по задачам.
+// Возвращает ошибку task.ErrInvalidPeriod, если период не week и не month.
+func (f *FilterTasks) GetTimeReport(tasks []*task.Task, period string, now time.Time) (render.TimeReport, error) {
+	if period != PeriodWeek && period != PeriodMonth {
+		return render.TimeReport{}, fmt.Errorf("ошибка валидации (%w): %s", task.ErrInvalidPeriod, period)
+	}
+	from := periodStart(now, period)
+	to := nextPeriod(from, period)
+	report := render.TimeReport{
+		Period: period,
+		From:   from,
+		To:     to,
+		Days:   timeByDay(tasks, from, to, now),
+		Tasks:  timeByTask(tasks, from, to, now),
+	}
+	report.Total = totalTime(report.Tasks)
+	return report, nil
+}
+
+// Stop останавливает учёт времени по задаче, по которой он идёт. Статус задачи не меняется.
+// Выводит детальную информацию о задаче.
+// Возвращает ID задачи или ошибку task.ErrNotTracking, если учёт времени не ведётся ни по одной задаче.
+func (m *Manager) Stop() (int, error) {
+	unlock, err := m.store.Lock(m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при блокировке: %w", err)
+	}
+	defer unlock()
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при получении: %w", err)
+	}
+	indexTask := trackingIndex(tasks)
+	if indexTask == nil {
+		return 0, fmt.Errorf("%w: начните задачу командой todo start", task.ErrNotTracking)
+	}
+	id := tasks[*indexTask].ID
+	indexTask, err = editTask(m, tasks, id, journal.OpStop, map[string]string{"timer": timerStop})
+	if err != nil {
+		return 0, fmt.Errorf("не удалось остановить учёт времени: %w", err)
+	}
+	m.render.RenderDetailed(tasks[*indexTask])
+	return id, nil
+}
+
+// TimeLog выводит время, затраченное на задачи за последние days дней, сгруппированное
+// по задачам, дням или тегам (см. Filter.GetTimeLog). Задачи из корзины не учитываются.
+// Возвращает ошибку, если группировка или количество дней некорректны или произошла ошибка при загрузке.
+func (m *Manager) TimeLog(by string, days int) error {
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	log, err := m.filter.GetTimeLog(m.filter.GetTasksByDeleted(tasks, false), by, days, time.Now())
+	if err != nil {
+		return fmt.Errorf("не удалось собрать затраченное время: %w", err)
+	}
+	m.render.RenderTimeLog(log)
+	return nil
+}
+
+// TimeReport выводит отчёт о затраченном времени за текущую неделю или месяц (см. Filter.GetTimeReport).
+// Задачи из корзины не учитываются.
+// Возвращает ошибку, если передан некорректный период или произошла ошибка при загрузке задач.
+func (m *Manager) TimeReport(period string) error {
+	tasks, err := m.store.Load(m.fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении: %w", err)
+	}
+	report, err := m.filter.GetTimeReport(m.filter.GetTasksByDeleted(tasks, false), period, time.Now())
+	if err != nil {
+		return fmt.Errorf("передан некорректный период отчёта: %w", err)
+	}
+	m.render.RenderTimeReport(report)
+	return nil
+}
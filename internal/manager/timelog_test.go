@@ -0,0 +1,107 @@
+//go:build !production
+
+package manager
+
+import (
+	"testing"
+	"time"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trackedTasks возвращает задачи с интервалами работы: #1 (+backend, +api) - 2ч в понедельник 13.10.2025
+// и 1ч через полночь на среду, #2 (+backend) - 30м в среду, учёт времени по #2 идёт с 9:00 четверга (now).
+func trackedTasks(t *testing.T) ([]*task.Task, time.Time) {
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	at := func(day, hour, minute int) *time.Time {
+		return testutil.TimePtr(time.Date(2025, time.October, day, hour, minute, 0, 0, time.UTC))
+	}
+	tasks[0].AddTags("backend", "api")
+	tasks[0].TimeLog = []task.Interval{
+		{Start: *at(13, 10, 0), End: at(13, 12, 0)},
+		{Start: *at(14, 23, 30), End: at(15, 0, 30)},
+	}
+	tasks[1].AddTags("backend")
+	tasks[1].TimeLog = []task.Interval{
+		{Start: *at(15, 14, 0), End: at(15, 14, 30)},
+		{Start: *at(16, 9, 0)},
+	}
+	return tasks, *at(16, 10, 0)
+}
+
+func TestGetTimeLog(t *testing.T) {
+	tasks, now := trackedTasks(t)
+	filter := &FilterTasks{}
+	date := func(day int) time.Time { return time.Date(2025, time.October, day, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		by       string
+		days     int
+		expected []render.TimeEntry
+		total    time.Duration
+	}{
+		{"по задачам", TimeByTask, 7, []render.TimeEntry{
+			{Task: tasks[0], Spent: 3 * time.Hour},
+			{Task: tasks[1], Spent: 90 * time.Minute},
+		}, 4*time.Hour + 30*time.Minute},
+		{"по дням", TimeByDay, 7, []render.TimeEntry{
+			{Date: date(13), Spent: 2 * time.Hour},
+			{Date: date(14), Spent: 30 * time.Minute},
+			{Date: date(15), Spent: time.Hour},
+			{Date: date(16), Spent: time.Hour},
+		}, 4*time.Hour + 30*time.Minute},
+		{"по тегам", TimeByTag, 7, []render.TimeEntry{
+			{Tag: "backend", Spent: 4*time.Hour + 30*time.Minute},
+			{Tag: "api", Spent: 3 * time.Hour},
+		}, 4*time.Hour + 30*time.Minute},
+		{"за два дня", TimeByTask, 2, []render.TimeEntry{
+			{Task: tasks[1], Spent: 90 * time.Minute},
+			{Task: tasks[0], Spent: 30 * time.Minute},
+		}, 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := filter.GetTimeLog(tasks, tt.by, tt.days, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, log.Entries)
+			assert.Equal(t, tt.total, log.Total)
+			assert.Equal(t, tasks[1], log.Active)
+			assert.Equal(t, date(17), log.To)
+		})
+	}
+
+	_, err := filter.GetTimeLog(tasks, "month", 7, now)
+	assert.Error(t, err)
+	_, err = filter.GetTimeLog(tasks, TimeByTask, 0, now)
+	assert.Error(t, err)
+}
+
+func TestGetTimeReport(t *testing.T) {
+	tasks, now := trackedTasks(t)
+	filter := &FilterTasks{}
+
+	report, err := filter.GetTimeReport(tasks, PeriodWeek, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.October, 13, 0, 0, 0, 0, time.UTC), report.From)
+	assert.Equal(t, time.Date(2025, time.October, 20, 0, 0, 0, 0, time.UTC), report.To)
+	require.Len(t, report.Days, 7)
+	assert.Equal(t, 2*time.Hour, report.Days[0].Spent)
+	assert.Zero(t, report.Days[6].Spent)
+	require.Len(t, report.Tasks, 2)
+	assert.Equal(t, 4*time.Hour+30*time.Minute, report.Total)
+
+	report, err = filter.GetTimeReport(tasks, PeriodMonth, now)
+	require.NoError(t, err)
+	assert.Len(t, report.Days, 31)
+	assert.Equal(t, 4*time.Hour+30*time.Minute, report.Total)
+
+	_, err = filter.GetTimeReport(tasks, "day", now)
+	assert.ErrorIs(t, err, task.ErrInvalidPeriod)
+}
@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+)
+
+// buildTree строит дерево задач с сохранением их порядка.
+// Задачи, родитель которых не попал в список (отфильтрован или в корзине), выводятся на верхнем уровне.
+// Задачи из цикла в иерархии (например, в повреждённом файле) выводятся один раз.
+func buildTree(tasks []*task.Task) []render.TaskNode {
+	listed := make(map[int]bool, len(tasks))
+	for _, value := range tasks {
+		listed[value.ID] = true
+	}
+	children := make(map[int][]*task.Task)
+	roots := make([]*task.Task, 0)
+	for _, value := range tasks {
+		if value.ParentID != 0 && listed[value.ParentID] {
+			children[value.ParentID] = append(children[value.ParentID], value)
+			continue
+		}
+		roots = append(roots, value)
+	}
+
+	visited := make(map[int]bool, len(tasks))
+	var node func(value *task.Task) render.TaskNode
+	node = func(value *task.Task) render.TaskNode {
+		visited[value.ID] = true
+		result := render.TaskNode{Task: value, Children: make([]render.TaskNode, 0)}
+		for _, child := range children[value.ID] {
+			if !visited[child.ID] {
+				result.Children = append(result.Children, node(child))
+			}
+		}
+		return result
+	}
+	nodes := make([]render.TaskNode, 0, len(roots))
+	for _, value := range roots {
+		nodes = append(nodes, node(value))
+	}
+	for _, value := range tasks {
+		if !visited[value.ID] {
+			nodes = append(nodes, node(value))
+		}
+	}
+	return nodes
+}
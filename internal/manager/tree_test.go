@@ -0,0 +1,49 @@
+//go:build !production
+
+package manager
+
+import (
+	"testing"
+	"todo_cli/internal/render"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// treeIDs возвращает ID задач дерева в виде вложенных срезов: [ID, [подзадачи]...].
+func treeIDs(nodes []render.TaskNode) []any {
+	result := make([]any, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, []any{node.Task.ID, treeIDs(node.Children)})
+	}
+	return result
+}
+
+func TestBuildTree(t *testing.T) {
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	tasks[1].ParentID = 1
+	tasks[2].ParentID = 2
+	tasks[3].ParentID = 1
+	tasks[5].ParentID = 99
+
+	nodes := buildTree(tasks)
+	// #6 с отсутствующим родителем выводится на верхнем уровне
+	assert.Equal(t, []any{
+		[]any{1, []any{[]any{2, []any{[]any{3, []any{}}}}, []any{4, []any{}}}},
+		[]any{5, []any{}},
+		[]any{6, []any{}},
+	}, treeIDs(nodes))
+
+	// без #1 его подзадачи поднимаются на верхний уровень
+	assert.Equal(t, []any{
+		[]any{2, []any{[]any{3, []any{}}}},
+		[]any{4, []any{}},
+	}, treeIDs(buildTree(tasks[1:4])))
+
+	// цикл #1 -> #2 -> #1 выводится один раз
+	tasks[0].ParentID = 2
+	nodes = buildTree(tasks[:2])
+	assert.Equal(t, []any{[]any{1, []any{[]any{2, []any{}}}}}, treeIDs(nodes))
+}
@@ -0,0 +1,400 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/task"
+)
+
+// JSONRender реализует интерфейс Render для машиночитаемого вывода.
+// Каждый вызов выводит один JSON-документ в Out (по умолчанию stdout),
+// ошибки выводятся объектом {"error": "..."} в Err (по умолчанию stderr).
+type JSONRender struct {
+	Out io.Writer
+	Err io.Writer
+}
+
+// jsonTask - представление задачи в JSON: поля задачи и вычисляемые признаки.
+// Время выполнения (от начала работы до завершения) выводится в секундах только для завершённых задач,
+// затраченное время - только для задач с интервалами работы, оставшаяся по оценке работа (отрицательная
+// при превышении оценки) - только для задач с оценкой временем.
+type jsonTask struct {
+	*task.Task
+	Overdue   bool   `json:"overdue"`
+	CycleTime *int64 `json:"cycle_time_seconds,omitempty"`
+	TimeSpent *int64 `json:"time_spent_seconds,omitempty"`
+	Remaining *int64 `json:"remaining_seconds,omitempty"`
+}
+
+func newJSONTask(t *task.Task, now time.Time) jsonTask {
+	result := jsonTask{Task: t, Overdue: t.IsOverdue(now)}
+	if cycleTime, ok := t.CycleTime(); ok {
+		seconds := int64(cycleTime.Seconds())
+		result.CycleTime = &seconds
+	}
+	if len(t.TimeLog) > 0 {
+		seconds := int64(t.TimeSpent(now).Seconds())
+		result.TimeSpent = &seconds
+	}
+	if remaining, ok := t.Remaining(now); ok {
+		seconds := int64(remaining.Seconds())
+		result.Remaining = &seconds
+	}
+	return result
+}
+
+func (r *JSONRender) out() io.Writer {
+	if r.Out == nil {
+		return os.Stdout
+	}
+	return r.Out
+}
+
+func (r *JSONRender) err() io.Writer {
+	if r.Err == nil {
+		return os.Stderr
+	}
+	return r.Err
+}
+
+// write сериализует данные с отступами и выводит их отдельной строкой.
+// Ошибка сериализации выводится как JSON-ошибка.
+func (r *JSONRender) write(w io.Writer, data any) {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		r.RenderError(fmt.Errorf("не удалось преобразовать данные в json: %w", err))
+		return
+	}
+	fmt.Fprintln(w, string(jsonData))
+}
+
+// RenderList выводит массив задач. Пустой список выводится как [].
+func (r *JSONRender) RenderList(tasks []*task.Task) {
+	now := time.Now()
+	result := make([]jsonTask, 0, len(tasks))
+	for _, value := range tasks {
+		result = append(result, newJSONTask(value, now))
+	}
+	r.write(r.out(), result)
+}
+
+// RenderStats выводит статистику по статусам объектом {"total", "completed", "in_progress", "pending", "overdue"}.
+func (r *JSONRender) RenderStats(stats StatusStats) {
+	r.write(r.out(), stats)
+}
+
+// RenderVersion выводит объект {"version", "date"}.
+func (r *JSONRender) RenderVersion(info VersionInfo) {
+	r.write(r.out(), info)
+}
+
+// RenderDetailed выводит одну задачу объектом.
+func (r *JSONRender) RenderDetailed(tasks *task.Task) {
+	r.write(r.out(), newJSONTask(tasks, time.Now()))
+}
+
+// RenderWithSubtasks выводит задачу объектом с массивом подзадач "subtasks"
+// и процентом выполнения "progress_percent".
+func (r *JSONRender) RenderWithSubtasks(parent *task.Task, subtasks []*task.Task) {
+	now := time.Now()
+	_, percent := task.Progress(subtasks)
+	result := struct {
+		jsonTask
+		Subtasks []jsonTask `json:"subtasks"`
+		Progress int        `json:"progress_percent"`
+	}{jsonTask: newJSONTask(parent, now), Subtasks: make([]jsonTask, 0, len(subtasks)), Progress: percent}
+	for _, value := range subtasks {
+		result.Subtasks = append(result.Subtasks, newJSONTask(value, now))
+	}
+	r.write(r.out(), result)
+}
+
+// jsonNode - задача с вложенными подзадачами для вывода дерева.
+type jsonNode struct {
+	jsonTask
+	Subtasks []jsonNode `json:"subtasks"`
+}
+
+func newJSONNodes(nodes []TaskNode, now time.Time) []jsonNode {
+	result := make([]jsonNode, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, jsonNode{jsonTask: newJSONTask(node.Task, now), Subtasks: newJSONNodes(node.Children, now)})
+	}
+	return result
+}
+
+// RenderTree выводит массив задач верхнего уровня, подзадачи вложены в поле "subtasks".
+func (r *JSONRender) RenderTree(nodes []TaskNode) {
+	r.write(r.out(), newJSONNodes(nodes, time.Now()))
+}
+
+// RenderGraph выводит граф зависимостей объектом {"tasks", "dependencies"} независимо от формата.
+func (r *JSONRender) RenderGraph(graph Graph, format string) {
+	now := time.Now()
+	result := struct {
+		Tasks        []jsonTask   `json:"tasks"`
+		Dependencies []Dependency `json:"dependencies"`
+	}{Tasks: make([]jsonTask, 0, len(graph.Tasks)), Dependencies: graph.Dependencies}
+	for _, value := range graph.Tasks {
+		result.Tasks = append(result.Tasks, newJSONTask(value, now))
+	}
+	if result.Dependencies == nil {
+		result.Dependencies = make([]Dependency, 0)
+	}
+	r.write(r.out(), result)
+}
+
+// RenderRecurring выводит массив серий повторяющихся задач:
+// текущая задача "task", правило "rule" в формате RRULE, срок следующей задачи "next_due" и "completed".
+func (r *JSONRender) RenderRecurring(series []Series) {
+	type jsonSeries struct {
+		Task      jsonTask  `json:"task"`
+		Rule      string    `json:"rule"`
+		Next      time.Time `json:"next_due"`
+		Completed int       `json:"completed"`
+	}
+	now := time.Now()
+	result := make([]jsonSeries, 0, len(series))
+	for _, value := range series {
+		result = append(result, jsonSeries{newJSONTask(value.Task, now), value.Rule.String(), value.Next, value.Completed})
+	}
+	r.write(r.out(), result)
+}
+
+// RenderTagStats выводит объект "тег -> статистика по статусам" с ключами в алфавитном порядке.
+func (r *JSONRender) RenderTagStats(stats []TagStats) {
+	result := make(map[string]StatusStats, len(stats))
+	for _, value := range stats {
+		result[value.Tag] = value.Stats
+	}
+	r.write(r.out(), result)
+}
+
+// jsonEffort - суммарные оценки, время - в секундах
+type jsonEffort struct {
+	Tasks     int     `json:"tasks"`
+	Estimated int     `json:"estimated"`
+	Duration  int64   `json:"estimate_seconds"`
+	Points    float64 `json:"points"`
+	Spent     int64   `json:"spent_seconds"`
+	Remaining int64   `json:"remaining_seconds"`
+}
+
+func newJSONEffort(effort Effort) jsonEffort {
+	return jsonEffort{
+		Tasks:     effort.Tasks,
+		Estimated: effort.Estimated,
+		Duration:  int64(effort.Duration.Seconds()),
+		Points:    effort.Points,
+		Spent:     int64(effort.Spent.Seconds()),
+		Remaining: int64(effort.Remaining.Seconds()),
+	}
+}
+
+// RenderEffortStats выводит суммарные оценки объектом {"by_status", "by_tag", "total"},
+// где by_status и by_tag - объекты "статус или тег -> оценки".
+func (r *JSONRender) RenderEffortStats(stats EffortStats) {
+	byStatus := make(map[string]jsonEffort, len(stats.ByStatus))
+	for _, value := range stats.ByStatus {
+		byStatus[value.Status.String()] = newJSONEffort(value.Effort)
+	}
+	byTag := make(map[string]jsonEffort, len(stats.ByTag))
+	for _, value := range stats.ByTag {
+		byTag[value.Tag] = newJSONEffort(value.Effort)
+	}
+	r.write(r.out(), map[string]interface{}{
+		"by_status": byStatus,
+		"by_tag":    byTag,
+		"total":     newJSONEffort(stats.Total),
+	})
+}
+
+// RenderLists выводит массив списков задач.
+func (r *JSONRender) RenderLists(lists []ListInfo) {
+	if lists == nil {
+		lists = []ListInfo{}
+	}
+	r.write(r.out(), lists)
+}
+
+// jsonDurationStats - длительности в секундах для машиночитаемого вывода
+type jsonDurationStats struct {
+	Count   int   `json:"count"`
+	Average int64 `json:"average_seconds"`
+	P50     int64 `json:"p50_seconds"`
+	P90     int64 `json:"p90_seconds"`
+}
+
+func newJSONDurationStats(stats DurationStats) jsonDurationStats {
+	return jsonDurationStats{
+		Count:   stats.Count,
+		Average: int64(stats.Average.Seconds()),
+		P50:     int64(stats.P50.Seconds()),
+		P90:     int64(stats.P90.Seconds()),
+	}
+}
+
+// RenderFlowStats выводит показатели потока задач объектом, длительности - в секундах.
+func (r *JSONRender) RenderFlowStats(stats FlowStats) {
+	type jsonPeriodCount struct {
+		Start     time.Time `json:"start"`
+		Completed int       `json:"completed"`
+	}
+	throughput := make([]jsonPeriodCount, 0, len(stats.Throughput))
+	for _, count := range stats.Throughput {
+		throughput = append(throughput, jsonPeriodCount{Start: count.Start, Completed: count.Completed})
+	}
+	now := time.Now()
+	oldest := make([]jsonTask, 0, len(stats.Oldest))
+	for _, value := range stats.Oldest {
+		oldest = append(oldest, newJSONTask(value, now))
+	}
+	r.write(r.out(), map[string]interface{}{
+		"period":      stats.Period,
+		"throughput":  throughput,
+		"lead_time":   newJSONDurationStats(stats.LeadTime),
+		"cycle_time":  newJSONDurationStats(stats.CycleTime),
+		"oldest_open": oldest,
+	})
+}
+
+// RenderActivity выводит данные графиков активности объектом с массивами дней.
+func (r *JSONRender) RenderActivity(activity Activity) {
+	type jsonDay struct {
+		Date      string `json:"date"`
+		Created   int    `json:"created"`
+		Completed int    `json:"completed"`
+		Open      int    `json:"open"`
+	}
+	days := func(values []DayActivity) []jsonDay {
+		result := make([]jsonDay, 0, len(values))
+		for _, day := range values {
+			result = append(result, jsonDay{
+				Date:      day.Date.Format("2006-01-02"),
+				Created:   day.Created,
+				Completed: day.Completed,
+				Open:      day.Open,
+			})
+		}
+		return result
+	}
+	r.write(r.out(), map[string]interface{}{
+		"days":    days(activity.Days),
+		"heatmap": days(activity.Heatmap),
+	})
+}
+
+// jsonTimeEntry - затраченное время в секундах по задаче, дню или тегу
+type jsonTimeEntry struct {
+	TaskID int    `json:"task_id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Date   string `json:"date,omitempty"`
+	Tag    string `json:"tag,omitempty"`
+	Spent  int64  `json:"spent_seconds"`
+}
+
+func newJSONTimeEntries(entries []TimeEntry) []jsonTimeEntry {
+	result := make([]jsonTimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		value := jsonTimeEntry{Tag: entry.Tag, Spent: int64(entry.Spent.Seconds())}
+		if entry.Task != nil {
+			value.TaskID, value.Title = entry.Task.ID, entry.Task.Title
+		}
+		if !entry.Date.IsZero() {
+			value.Date = entry.Date.Format("2006-01-02")
+		}
+		result = append(result, value)
+	}
+	return result
+}
+
+// RenderTimeLog выводит затраченное время объектом {"by", "from", "to", "entries", "total_seconds", "active"},
+// "active" - ID задачи, по которой идёт учёт времени, или null.
+func (r *JSONRender) RenderTimeLog(log TimeLog) {
+	var active *int
+	if log.Active != nil {
+		active = &log.Active.ID
+	}
+	r.write(r.out(), map[string]interface{}{
+		"by":            log.By,
+		"from":          log.From.Format("2006-01-02"),
+		"to":            log.To.AddDate(0, 0, -1).Format("2006-01-02"),
+		"entries":       newJSONTimeEntries(log.Entries),
+		"total_seconds": int64(log.Total.Seconds()),
+		"active":        active,
+	})
+}
+
+// RenderTimeReport выводит отчёт о затраченном времени объектом с массивами "days" и "tasks".
+func (r *JSONRender) RenderTimeReport(report TimeReport) {
+	r.write(r.out(), map[string]interface{}{
+		"period":        report.Period,
+		"from":          report.From.Format("2006-01-02"),
+		"to":            report.To.AddDate(0, 0, -1).Format("2006-01-02"),
+		"days":          newJSONTimeEntries(report.Days),
+		"tasks":         newJSONTimeEntries(report.Tasks),
+		"total_seconds": int64(report.Total.Seconds()),
+	})
+}
+
+// RenderPomodoro ничего не выводит: отсчёт помодоро предназначен для человека,
+// а результат выводится задачей после окончания помодоро.
+func (r *JSONRender) RenderPomodoro(progress PomodoroProgress) {}
+
+// RenderHistory выводит массив операций журнала со снимками задачи до и после операции.
+func (r *JSONRender) RenderHistory(entries []journal.Entry) {
+	if entries == nil {
+		entries = []journal.Entry{}
+	}
+	r.write(r.out(), entries)
+}
+
+// jsonImportIssue - пропущенная, ошибочная или созданная с замечанием запись импорта
+type jsonImportIssue struct {
+	Record int    `json:"record"`
+	Title  string `json:"title,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// newJSONImportIssues преобразует записи импорта, пустой список выводится как [].
+func newJSONImportIssues(issues []ImportIssue) []jsonImportIssue {
+	result := make([]jsonImportIssue, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, jsonImportIssue(issue))
+	}
+	return result
+}
+
+// RenderImport выводит итог импорта объектом {"created", "skipped", "failed", "warnings"},
+// где created - созданные задачи, skipped, failed и warnings - записи файла с причинами.
+func (r *JSONRender) RenderImport(report ImportReport) {
+	now := time.Now()
+	created := make([]jsonTask, 0, len(report.Created))
+	for _, value := range report.Created {
+		created = append(created, newJSONTask(value, now))
+	}
+	r.write(r.out(), map[string]interface{}{
+		"created":  created,
+		"skipped":  newJSONImportIssues(report.Skipped),
+		"failed":   newJSONImportIssues(report.Failed),
+		"warnings": newJSONImportIssues(report.Warnings),
+	})
+}
+
+// RenderMessage ничего не выводит: информационные сообщения предназначены для человека,
+// а в stdout должен оставаться только JSON с данными.
+func (r *JSONRender) RenderMessage(message string) {}
+
+// RenderWarning выводит замечание объектом {"warning": "..."} в поток ошибок.
+func (r *JSONRender) RenderWarning(err error) {
+	r.write(r.err(), map[string]string{"warning": err.Error()})
+}
+
+// RenderError выводит ошибку объектом {"error": "..."} в поток ошибок.
+func (r *JSONRender) RenderError(err error) {
+	r.write(r.err(), map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,190 @@
+//go:build !production
+
+package render_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRender_RenderList(t *testing.T) {
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	tasksMany[0].Due = &yesterday
+	tasksMany[0].AddTags("auth")
+
+	tests := []struct {
+		name          string
+		tasks         []*task.Task
+		expectedCount int
+	}{
+		{"пустой список - пустой массив", testutil.EmptyTasks(), 0},
+		{"nil - пустой массив", nil, 0},
+		{"несколько задач", tasksMany, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			r := &render.JSONRender{Out: &buffer}
+			r.RenderList(tt.tasks)
+
+			var result []map[string]interface{}
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &result))
+			require.NotNil(t, result)
+			assert.Len(t, result, tt.expectedCount)
+			if tt.expectedCount > 0 {
+				assert.Equal(t, float64(1), result[0]["id"])
+				assert.Equal(t, "pending", result[0]["status"])
+				assert.Equal(t, "low", result[0]["priority"])
+				assert.Equal(t, []interface{}{"auth"}, result[0]["tags"])
+				assert.Equal(t, true, result[0]["overdue"])
+				assert.Equal(t, false, result[1]["overdue"])
+			}
+		})
+	}
+}
+
+func TestJSONRender_RenderStats(t *testing.T) {
+	var buffer bytes.Buffer
+	r := &render.JSONRender{Out: &buffer}
+	r.RenderStats(testutil.StatsTask(6, 3, 1, 2, 0))
+
+	assert.JSONEq(t, `{"total":6,"completed":3,"in_progress":1,"pending":2,"overdue":0}`, buffer.String())
+
+	buffer.Reset()
+	r.RenderTagStats([]render.TagStats{{Tag: "docs", Stats: testutil.StatsTask(1, 1, 0, 0, 0)}})
+	assert.JSONEq(t, `{"docs":{"total":1,"completed":1,"in_progress":0,"pending":0,"overdue":0}}`, buffer.String())
+
+	buffer.Reset()
+	r.RenderVersion(render.VersionInfo{Version: "1.2.0", Date: "2025-10-15"})
+	assert.JSONEq(t, `{"version":"1.2.0","date":"2025-10-15"}`, buffer.String())
+}
+
+func TestJSONRender_RenderDetailed(t *testing.T) {
+	started := time.Date(2025, time.October, 15, 10, 0, 0, 0, time.UTC)
+	value, err := task.NewTask(1, "single task", "description", task.StatusPending.String())
+	require.NoError(t, err)
+	value.SetStatus(task.StatusProgress, started)
+	value.SetStatus(task.StatusCompleted, started.Add(90*time.Minute))
+
+	var buffer bytes.Buffer
+	r := &render.JSONRender{Out: &buffer}
+	r.RenderDetailed(value)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &result))
+	assert.Equal(t, float64(5400), result["cycle_time_seconds"])
+	assert.Equal(t, "2025-10-15T10:00:00Z", result["started"])
+	assert.Len(t, result["status_history"], 3)
+}
+
+func TestJSONRender_RenderError(t *testing.T) {
+	var out, errOut bytes.Buffer
+	r := &render.JSONRender{Out: &out, Err: &errOut}
+	r.RenderError(errors.New("задача не найдена"))
+	r.RenderMessage("сообщение не попадает в stdout")
+
+	assert.JSONEq(t, `{"error":"задача не найдена"}`, errOut.String())
+	assert.Empty(t, out.String())
+}
+
+func TestJSONRender_RenderWarning(t *testing.T) {
+	var out, errOut bytes.Buffer
+	r := &render.JSONRender{Out: &out, Err: &errOut}
+	r.RenderWarning(errors.New("приоритет critical выгружен как H"))
+
+	assert.JSONEq(t, `{"warning":"приоритет critical выгружен как H"}`, errOut.String())
+	assert.Empty(t, out.String())
+}
+
+func TestNew(t *testing.T) {
+	r, err := render.New(render.FormatJSON)
+	require.NoError(t, err)
+	assert.IsType(t, &render.JSONRender{}, r)
+
+	r, err = render.New(render.FormatText)
+	require.NoError(t, err)
+	assert.IsType(t, &render.TerminalRender{}, r)
+
+	_, err = render.New("xml")
+	assert.ErrorIs(t, err, render.ErrUnknownFormat)
+}
+
+func TestJSONRender_Subtasks(t *testing.T) {
+	tasks := subtaskTasks(t)
+	var buffer bytes.Buffer
+	r := &render.JSONRender{Out: &buffer}
+
+	r.RenderWithSubtasks(tasks[0], []*task.Task{tasks[1], tasks[3]})
+	var detailed map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &detailed))
+	assert.Equal(t, float64(1), detailed["id"])
+	assert.Equal(t, float64(50), detailed["progress_percent"])
+	require.Len(t, detailed["subtasks"], 2)
+	assert.Equal(t, float64(1), detailed["subtasks"].([]interface{})[0].(map[string]interface{})["parent"])
+
+	buffer.Reset()
+	r.RenderTree([]render.TaskNode{{Task: tasks[0], Children: []render.TaskNode{{Task: tasks[1]}}}})
+	var tree []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &tree))
+	require.Len(t, tree, 1)
+	children := tree[0]["subtasks"].([]interface{})
+	require.Len(t, children, 1)
+	assert.Equal(t, float64(2), children[0].(map[string]interface{})["id"])
+	assert.Equal(t, []interface{}{}, children[0].(map[string]interface{})["subtasks"])
+}
+
+func TestJSONRender_RenderGraph(t *testing.T) {
+	var buffer bytes.Buffer
+	r := &render.JSONRender{Out: &buffer}
+	r.RenderGraph(dependencyGraph(subtaskTasks(t)), render.GraphDOT)
+
+	var result map[string][]map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &result))
+	assert.Len(t, result["tasks"], 4)
+	assert.Equal(t, map[string]interface{}{"task": float64(1), "blocked_by": float64(2)}, result["dependencies"][0])
+}
+
+func TestJSONRender_RenderTimeLog(t *testing.T) {
+	tasks := subtaskTasks(t)
+	byTask, _, byTag := timeEntries(tasks)
+	var buffer bytes.Buffer
+	r := &render.JSONRender{Out: &buffer}
+	r.RenderTimeLog(render.TimeLog{
+		By:      "task",
+		From:    time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, time.October, 17, 0, 0, 0, 0, time.UTC),
+		Entries: append(byTask, byTag...),
+		Total:   270 * time.Minute,
+		Active:  tasks[1],
+	})
+
+	var result struct {
+		From    string                   `json:"from"`
+		To      string                   `json:"to"`
+		Entries []map[string]interface{} `json:"entries"`
+		Total   int64                    `json:"total_seconds"`
+		Active  *int                     `json:"active"`
+	}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &result))
+	assert.Equal(t, "2025-10-10", result.From)
+	assert.Equal(t, "2025-10-16", result.To)
+	assert.Equal(t, int64(16200), result.Total)
+	require.NotNil(t, result.Active)
+	assert.Equal(t, 2, *result.Active)
+	require.Len(t, result.Entries, 4)
+	assert.Equal(t, map[string]interface{}{"task_id": float64(1), "title": "pending task 1", "spent_seconds": float64(10800)},
+		result.Entries[0])
+	assert.Equal(t, map[string]interface{}{"tag": "backend", "spent_seconds": float64(16200)}, result.Entries[2])
+}
@@ -1,69 +1,1065 @@
 package render
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"strings"
+	"time"
+	"todo_cli/internal/journal"
 	"todo_cli/internal/task"
 	"unicode/utf8"
 )
 
 type Render interface {
 	RenderList(tasks []*task.Task)
-	RenderMap(data map[string]interface{})
+	RenderStats(stats StatusStats)
+	RenderVersion(info VersionInfo)
 	RenderDetailed(tasks *task.Task)
+	RenderWithSubtasks(parent *task.Task, subtasks []*task.Task)
+	RenderTree(nodes []TaskNode)
+	RenderGraph(graph Graph, format string)
+	RenderRecurring(series []Series)
+	RenderTagStats(stats []TagStats)
+	RenderEffortStats(stats EffortStats)
+	RenderLists(lists []ListInfo)
+	RenderFlowStats(stats FlowStats)
+	RenderActivity(activity Activity)
+	RenderTimeLog(log TimeLog)
+	RenderTimeReport(report TimeReport)
+	RenderPomodoro(progress PomodoroProgress)
+	RenderHistory(entries []journal.Entry)
+	RenderImport(report ImportReport)
+	RenderMessage(message string)
+	RenderWarning(err error)
+	RenderError(err error)
 }
 
-type TerminalRender struct{}
+var ErrUnknownFormat = errors.New("неизвестный формат вывода")
+
+// форматы вывода для глобального флага --output
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// фазы помодоро
+const (
+	PhaseWork  = "work"
+	PhaseBreak = "break"
+)
+
+// форматы вывода графа зависимостей в терминал
+const (
+	GraphText = "text"
+	GraphDOT  = "dot"
+)
+
+// New возвращает реализацию Render для указанного формата вывода.
+// Возвращает ошибку ErrUnknownFormat, если формат не поддерживается.
+func New(format string) (Render, error) {
+	switch format {
+	case FormatText:
+		return &TerminalRender{}, nil
+	case FormatJSON:
+		return &JSONRender{}, nil
+	}
+	return nil, fmt.Errorf("ошибка (%w): %s", ErrUnknownFormat, format)
+}
+
+// ListInfo описывает именованный список задач для вывода командой lists.
+type ListInfo struct {
+	Name    string `json:"name"`
+	Tasks   int    `json:"tasks"`
+	Default bool   `json:"default"`
+	Current bool   `json:"current"`
+}
+
+// TaskNode - задача с подзадачами для вывода иерархии задач.
+type TaskNode struct {
+	Task     *task.Task
+	Children []TaskNode
+}
+
+// Dependency - зависимость между задачами: задачу Task нельзя начать до завершения BlockedBy.
+type Dependency struct {
+	Task      int `json:"task"`
+	BlockedBy int `json:"blocked_by"`
+}
+
+// Graph - граф зависимостей: задачи, участвующие в зависимостях, и зависимости между ними.
+type Graph struct {
+	Tasks        []*task.Task
+	Dependencies []Dependency
+}
+
+// Series - серия повторяющихся задач: текущая невыполненная задача серии, её правило повтора,
+// срок следующей задачи после текущей и количество уже выполненных задач серии.
+type Series struct {
+	Task      *task.Task
+	Rule      task.Recurrence
+	Next      time.Time
+	Completed int
+}
+
+// StatusStats - количество задач всего, в каждом статусе и просроченных.
+type StatusStats struct {
+	Total      int `json:"total"`
+	Completed  int `json:"completed"`
+	InProgress int `json:"in_progress"`
+	Pending    int `json:"pending"`
+	Overdue    int `json:"overdue"`
+}
+
+// TagStats - статистика по статусам задач с тегом Tag.
+type TagStats struct {
+	Tag   string
+	Stats StatusStats
+}
+
+// Effort - суммарные оценки группы задач: количество задач и задач с оценкой, оценка временем
+// и в story points, затраченное время и оставшаяся по оценке работа невыполненных задач.
+type Effort struct {
+	Tasks     int
+	Estimated int
+	Duration  time.Duration
+	Points    float64
+	Spent     time.Duration
+	Remaining time.Duration
+}
+
+// StatusEffort - суммарные оценки задач в статусе Status.
+type StatusEffort struct {
+	Status task.Status
+	Effort Effort
+}
+
+// TagEffort - суммарные оценки задач с тегом Tag.
+type TagEffort struct {
+	Tag    string
+	Effort Effort
+}
+
+// EffortStats - суммарные оценки по статусам, тегам и по всем задачам для планирования.
+type EffortStats struct {
+	ByStatus []StatusEffort
+	ByTag    []TagEffort
+	Total    Effort
+}
+
+// ImportIssue - запись файла импорта, которая не была добавлена или добавлена с замечанием:
+// номер записи (с 1, без заголовка), название задачи, если его удалось прочитать, и причина.
+type ImportIssue struct {
+	Record int
+	Title  string
+	Reason string
+}
+
+// ImportReport - итог импорта задач из файла: созданные задачи с новыми ID,
+// пропущенные дубликаты, записи с ошибками и созданные задачи с замечаниями (например, без правила повтора).
+type ImportReport struct {
+	Created  []*task.Task
+	Skipped  []ImportIssue
+	Failed   []ImportIssue
+	Warnings []ImportIssue
+}
+
+// VersionInfo - версия приложения и дата сборки для команды version.
+type VersionInfo struct {
+	Version string `json:"version"`
+	Date    string `json:"date"`
+}
+
+// FlowStats - показатели потока задач за последние периоды (неделя или месяц):
+// сколько задач выполнено в каждом периоде, время от создания до завершения (lead time),
+// время от начала работы до завершения (cycle time) и самые старые открытые задачи.
+type FlowStats struct {
+	Period     string
+	Throughput []PeriodCount
+	LeadTime   DurationStats
+	CycleTime  DurationStats
+	Oldest     []*task.Task
+}
+
+// PeriodCount - количество задач, выполненных за период, начинающийся в Start.
+type PeriodCount struct {
+	Start     time.Time
+	Completed int
+}
+
+// DurationStats - среднее и перцентили длительности по Count задачам.
+type DurationStats struct {
+	Count   int
+	Average time.Duration
+	P50     time.Duration
+	P90     time.Duration
+}
+
+// Activity - данные для графиков активности: дни за выбранный период и дни для тепловой карты,
+// начиная с понедельника, чтобы недели выстраивались в колонки.
+type Activity struct {
+	Days    []DayActivity
+	Heatmap []DayActivity
+}
+
+// DayActivity - количество созданных и выполненных за день задач и открытых задач на конец дня.
+type DayActivity struct {
+	Date      time.Time
+	Created   int
+	Completed int
+	Open      int
+}
+
+// TimeEntry - время, затраченное на задачу, за день или на задачи с тегом.
+// Заполнено одно из полей Task, Date или Tag - в зависимости от группировки.
+type TimeEntry struct {
+	Task  *task.Task
+	Date  time.Time
+	Tag   string
+	Spent time.Duration
+}
+
+// TimeLog - затраченное время с From до To (не включая To), сгруппированное по задачам, дням или тегам,
+// и задача, по которой сейчас идёт учёт времени (nil, если учёт не ведётся).
+type TimeLog struct {
+	By      string
+	From    time.Time
+	To      time.Time
+	Entries []TimeEntry
+	Total   time.Duration
+	Active  *task.Task
+}
+
+// TimeReport - отчёт о затраченном времени за неделю или месяц: время по каждому дню периода
+// и по задачам, на которые тратилось время.
+type TimeReport struct {
+	Period string
+	From   time.Time
+	To     time.Time
+	Days   []TimeEntry
+	Tasks  []TimeEntry
+	Total  time.Duration
+}
+
+// PomodoroProgress - состояние отсчёта помодоро: фаза (работа или перерыв), номер помодоро из Rounds,
+// прошедшее и полное время фазы. Interrupted - отсчёт прерван до окончания фазы.
+type PomodoroProgress struct {
+	Task        *task.Task
+	Phase       string
+	Round       int
+	Rounds      int
+	Elapsed     time.Duration
+	Total       time.Duration
+	Interrupted bool
+}
+
+// подписи статистики по статусам и версии для вывода в терминал
+const (
+	LabelTotal     = "Всего задач"
+	LabelCompleted = "Выполнено"
+	LabelProgress  = "В работе"
+	LabelPending   = "Ожидает"
+	LabelOverdue   = "Просрочено"
+	LabelVersion   = "Версия"
+	LabelBuildDate = "Дата"
+)
+
+// TerminalRender реализует интерфейс Render для вывода в терминал.
+// Out и Err по умолчанию - stdout и stderr, в тестах их можно заменить буфером.
+type TerminalRender struct {
+	Out io.Writer
+	Err io.Writer
+}
+
+func (r *TerminalRender) out() io.Writer {
+	if r.Out == nil {
+		return os.Stdout
+	}
+	return r.Out
+}
+
+func (r *TerminalRender) err() io.Writer {
+	if r.Err == nil {
+		return os.Stderr
+	}
+	return r.Err
+}
 
 // RenderList выводит список задач в виде таблицы в терминал.
-// Таблица содержит колонки: ID, Название, Статус, Создана.
+// Таблица содержит колонки: ID, Название, Статус, Приоритет, Срок, Оценка, Создана.
 // Ширина колонки "Название" автоматически подстраивается под самое длинное название.
-// Даты отображаются в формате DD.MM.YYYY.
+// Даты отображаются в формате DD.MM.YYYY, просроченные задачи отмечаются "!" в колонке "Срок".
+// Если у задач есть оценки, под таблицей выводится суммарная оценка.
 func (r *TerminalRender) RenderList(tasks []*task.Task) {
 	var columnMax int = 0
+	now := time.Now()
 	for _, value := range tasks {
 		if columnMax < utf8.RuneCountInString(value.Title) {
 			columnMax = utf8.RuneCountInString(value.Title)
 		}
 	}
 	columnMax += 5
-	fmt.Print("\n")
-	fmt.Printf("%-4s | %-*s | %-12s | %-15s\n", "ID", columnMax, "Название", "Статус", "Создана")
-	fmt.Println(strings.Repeat("-", columnMax+40))
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "%-4s | %-*s | %-12s | %-10s | %-12s | %-8s | %-15s\n", "ID", columnMax, "Название", "Статус", "Приоритет", "Срок", "Оценка", "Создана")
+	fmt.Fprintln(r.out(), strings.Repeat("-", columnMax+79))
 
 	for _, task := range tasks {
-		fmt.Printf("%-4d | %-*s | %-12s | %-15s\n",
-			task.ID, columnMax, task.Title, task.Status,
-			task.CreatedAt.Format("02.01.2006"))
+		due := dueLabel(task.Due)
+		if task.IsOverdue(now) {
+			due += " !"
+		}
+		fmt.Fprintf(r.out(), "%-4d | %-*s | %-12s | %-10s | %-12s | %-8s | %-15s\n",
+			task.ID, columnMax, task.Title, task.Status, priorityLabel(task.Priority),
+			due, estimateLabel(task.Estimate), task.CreatedAt.Format("02.01.2006"))
+	}
+	var total Effort
+	for _, value := range tasks {
+		if value.Estimate != nil {
+			total.Estimated += 1
+			total.Duration += value.Estimate.Duration
+			total.Points += value.Estimate.Points
+		}
+	}
+	if total.Estimated > 0 {
+		fmt.Fprintf(r.out(), "\nСуммарная оценка: %s (задач с оценкой: %d из %d)\n", effortLabel(total), total.Estimated, len(tasks))
 	}
-	fmt.Print("\n")
+	fmt.Fprint(r.out(), "\n")
 }
 
-// RenderMap выводит данные из карты в формате "ключ: значение".
-// Поддерживает типы значений: string и int.
-// Используется для вывода статистики и других агрегированных данных.
-func (r *TerminalRender) RenderMap(data map[string]interface{}) {
-	fmt.Print("\n")
-	for name, value := range data {
-		if stringValue, ok := value.(string); ok {
-			fmt.Printf("%v: %v\n", name, stringValue)
-		}
-		if intValue, ok := value.(int); ok {
-			fmt.Printf("%v: %v\n", name, intValue)
-		}
+// RenderStats выводит статистику по статусам в фиксированном порядке:
+// всего задач, выполнено, в работе, ожидает, просрочено.
+func (r *TerminalRender) RenderStats(stats StatusStats) {
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "%s: %d\n", LabelTotal, stats.Total)
+	fmt.Fprintf(r.out(), "%s: %d\n", LabelCompleted, stats.Completed)
+	fmt.Fprintf(r.out(), "%s: %d\n", LabelProgress, stats.InProgress)
+	fmt.Fprintf(r.out(), "%s: %d\n", LabelPending, stats.Pending)
+	fmt.Fprintf(r.out(), "%s: %d\n", LabelOverdue, stats.Overdue)
+	fmt.Fprint(r.out(), "\n")
+}
+
+// RenderVersion выводит версию приложения и дату сборки.
+func (r *TerminalRender) RenderVersion(info VersionInfo) {
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "%s: %s\n", LabelVersion, info.Version)
+	fmt.Fprintf(r.out(), "%s: %s\n", LabelBuildDate, info.Date)
+	fmt.Fprint(r.out(), "\n")
+}
+
+// idsLabel возвращает ID задач через запятую в формате #1, #2.
+func idsLabel(ids []int) string {
+	labels := make([]string, 0, len(ids))
+	for _, id := range ids {
+		labels = append(labels, fmt.Sprintf("#%d", id))
+	}
+	return strings.Join(labels, ", ")
+}
+
+// priorityLabel возвращает приоритет для вывода, подставляя "-" для задач без приоритета.
+func priorityLabel(priority task.Priority) string {
+	if priority == task.PriorityNone {
+		return "-"
+	}
+	return priority.String()
+}
+
+// dueLabel возвращает срок задачи в формате DD.MM.YYYY или "-", если срок не задан.
+func dueLabel(due *time.Time) string {
+	if due == nil {
+		return "-"
+	}
+	return due.Format("02.01.2006")
+}
+
+// estimateLabel возвращает оценку задачи в формате ввода (2h, 3pt) или "-", если оценки нет.
+func estimateLabel(estimate *task.Estimate) string {
+	if estimate == nil {
+		return "-"
 	}
-	fmt.Print("\n")
+	return estimate.String()
+}
+
+// pointsLabel возвращает story points без лишних нулей, например "8 pt" или "2.5 pt".
+func pointsLabel(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64) + " pt"
+}
+
+// effortLabel возвращает суммарную оценку временем в часах и в story points, например "12.5 ч, 8 pt".
+func effortLabel(effort Effort) string {
+	parts := make([]string, 0, 2)
+	if effort.Duration > 0 || effort.Points == 0 {
+		parts = append(parts, hoursLabel(effort.Duration))
+	}
+	if effort.Points > 0 {
+		parts = append(parts, pointsLabel(effort.Points))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// tagsLabel возвращает теги через запятую с префиксом "+" или "-", если тегов нет.
+func tagsLabel(tags []string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	return "+" + strings.Join(tags, ", +")
+}
+
+// timeLabel возвращает дату в формате DD.MM.YYYY HH:MM или "-", если дата не задана.
+func timeLabel(value *time.Time) string {
+	if value == nil {
+		return "-"
+	}
+	return value.Format("02.01.2006 15:04")
+}
+
+// durationLabel возвращает длительность в виде "2д 3ч 15м" с точностью до минуты.
+func durationLabel(duration time.Duration) string {
+	minutes := int(duration.Round(time.Minute).Minutes())
+	days, hours := minutes/(24*60), minutes/60%24
+	minutes %= 60
+	parts := make([]string, 0, 3)
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dд", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dч", hours))
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dм", minutes))
+	}
+	return strings.Join(parts, " ")
 }
 
 // RenderDetailed выводит детальную информацию об одной задаче.
-// Отображает: ID, название, описание, статус и дату создания.
-// Дата создания показывается в формате DD.MM.YYYY HH:MM.
+// Отображает: ID, название, описание, статус, приоритет, теги, срок, даты создания, начала и завершения,
+// время выполнения, затраченное время, оценку с остатком работы, помодоро и историю статусов. Даты показываются в формате DD.MM.YYYY HH:MM.
 func (r *TerminalRender) RenderDetailed(tasks *task.Task) {
-	fmt.Print("\n")
-	fmt.Printf("ID: %d\n", tasks.ID)
-	fmt.Printf("Название: %s\n", tasks.Title)
-	fmt.Printf("Описание: %s\n", tasks.Description)
-	fmt.Printf("Статус: %s\n", tasks.Status.String())
-	fmt.Printf("Создана: %s\n", tasks.CreatedAt.Format("02.01.2006 15:04"))
-	fmt.Print("\n")
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "ID: %d\n", tasks.ID)
+	fmt.Fprintf(r.out(), "Название: %s\n", tasks.Title)
+	fmt.Fprintf(r.out(), "Описание: %s\n", tasks.Description)
+	fmt.Fprintf(r.out(), "Статус: %s\n", tasks.Status.String())
+	fmt.Fprintf(r.out(), "Приоритет: %s\n", priorityLabel(tasks.Priority))
+	fmt.Fprintf(r.out(), "Теги: %s\n", tagsLabel(tasks.Tags))
+	if tasks.ParentID != 0 {
+		fmt.Fprintf(r.out(), "Родительская задача: #%d\n", tasks.ParentID)
+	}
+	if len(tasks.BlockedBy) > 0 {
+		fmt.Fprintf(r.out(), "Ждёт задачи: %s\n", idsLabel(tasks.BlockedBy))
+	}
+	if tasks.Recur != "" {
+		fmt.Fprintf(r.out(), "Повтор: %s (серия #%d)\n", recurLabel(tasks.Recur), tasks.SeriesID)
+	}
+	if tasks.IsOverdue(time.Now()) {
+		fmt.Fprintf(r.out(), "Срок: %s (просрочено)\n", dueLabel(tasks.Due))
+	} else {
+		fmt.Fprintf(r.out(), "Срок: %s\n", dueLabel(tasks.Due))
+	}
+	fmt.Fprintf(r.out(), "Создана: %s\n", tasks.CreatedAt.Format("02.01.2006 15:04"))
+	fmt.Fprintf(r.out(), "Начата: %s\n", timeLabel(tasks.StartedAt))
+	fmt.Fprintf(r.out(), "Завершена: %s\n", timeLabel(tasks.CompletedAt))
+	if cycleTime, ok := tasks.CycleTime(); ok {
+		fmt.Fprintf(r.out(), "Время выполнения: %s\n", durationLabel(cycleTime))
+	}
+	if len(tasks.TimeLog) > 0 {
+		spent := durationLabel(tasks.TimeSpent(time.Now()))
+		if tasks.IsTracking() {
+			spent += " (идёт учёт времени)"
+		}
+		fmt.Fprintf(r.out(), "Затрачено: %s\n", spent)
+	}
+	if tasks.Estimate != nil {
+		fmt.Fprintf(r.out(), "Оценка: %s\n", estimateProgressLabel(tasks, time.Now()))
+	}
+	if len(tasks.Pomodoros) > 0 {
+		completed, interrupted := tasks.PomodoroCount()
+		fmt.Fprintf(r.out(), "Помодоро: %d, прервано: %d\n", completed, interrupted)
+	}
+	if len(tasks.StatusHistory) > 0 {
+		fmt.Fprintln(r.out(), "История статусов:")
+		for _, change := range tasks.StatusHistory {
+			fmt.Fprintf(r.out(), "  %s  %s\n", change.At.Format("02.01.2006 15:04"), change.Status)
+		}
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// RenderWithSubtasks выводит детальную информацию о задаче и список её подзадач
+// со статусами и процентом выполнения.
+func (r *TerminalRender) RenderWithSubtasks(parent *task.Task, subtasks []*task.Task) {
+	r.RenderDetailed(parent)
+	completed, percent := task.Progress(subtasks)
+	fmt.Fprintf(r.out(), "Подзадачи: %d/%d (%d%%)\n", completed, len(subtasks), percent)
+	for _, value := range subtasks {
+		fmt.Fprintf(r.out(), "  #%-4d %-12s %s\n", value.ID, value.Status, value.Title)
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// RenderTree выводит задачи деревом: подзадачи рисуются под родительской задачей
+// с отступом и линиями иерархии, у задач с подзадачами выводится процент выполнения.
+func (r *TerminalRender) RenderTree(nodes []TaskNode) {
+	fmt.Fprint(r.out(), "\n")
+	r.renderNodes(nodes, "", true)
+	fmt.Fprint(r.out(), "\n")
+}
+
+// renderNodes выводит узлы одного уровня дерева. prefix - линии родительских уровней,
+// root = true для задач верхнего уровня, которые выводятся без линий.
+func (r *TerminalRender) renderNodes(nodes []TaskNode, prefix string, root bool) {
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		if root {
+			branch, indent = "", ""
+		}
+		fmt.Fprintf(r.out(), "%s%s%s\n", prefix, branch, nodeLabel(node))
+		r.renderNodes(node.Children, prefix+indent, false)
+	}
+}
+
+// nodeLabel возвращает строку задачи в дереве: ID, название, статус и выполнение подзадач.
+func nodeLabel(node TaskNode) string {
+	label := fmt.Sprintf("#%d %s [%s]", node.Task.ID, node.Task.Title, node.Task.Status)
+	if len(node.Children) == 0 {
+		return label
+	}
+	children := make([]*task.Task, 0, len(node.Children))
+	for _, child := range node.Children {
+		children = append(children, child.Task)
+	}
+	completed, percent := task.Progress(children)
+	return fmt.Sprintf("%s %d/%d (%d%%)", label, completed, len(children), percent)
+}
+
+// RenderGraph выводит граф зависимостей в формате GraphText или GraphDOT.
+// В текстовом формате для каждой заблокированной задачи выводятся задачи, которых она ждёт,
+// выполненные отмечаются галочкой. DOT можно передать в Graphviz: todo graph --format dot | dot -Tpng.
+func (r *TerminalRender) RenderGraph(graph Graph, format string) {
+	if format == GraphDOT {
+		fmt.Fprint(r.out(), dotGraph(graph))
+		return
+	}
+	byID := make(map[int]*task.Task, len(graph.Tasks))
+	for _, value := range graph.Tasks {
+		byID[value.ID] = value
+	}
+	fmt.Fprint(r.out(), "\n")
+	for _, value := range graph.Tasks {
+		blockers := make([]*task.Task, 0)
+		for _, dependency := range graph.Dependencies {
+			if dependency.Task == value.ID {
+				blockers = append(blockers, byID[dependency.BlockedBy])
+			}
+		}
+		if len(blockers) == 0 {
+			continue
+		}
+		fmt.Fprintf(r.out(), "#%d %s [%s] ждёт:\n", value.ID, value.Title, value.Status)
+		for _, blocker := range blockers {
+			mark := ""
+			if blocker.Status == task.StatusCompleted {
+				mark = " ✓"
+			}
+			fmt.Fprintf(r.out(), "  ← #%d %s [%s]%s\n", blocker.ID, blocker.Title, blocker.Status, mark)
+		}
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// dotGraph возвращает граф зависимостей на языке DOT: стрелка ведёт от блокирующей задачи
+// к заблокированной, выполненные задачи выводятся серым.
+func dotGraph(graph Graph) string {
+	var builder strings.Builder
+	builder.WriteString("digraph todo {\n")
+	builder.WriteString("  rankdir=LR;\n")
+	builder.WriteString("  node [shape=box];\n")
+	for _, value := range graph.Tasks {
+		label := strconv.Quote(fmt.Sprintf("#%d %s\n[%s]", value.ID, value.Title, value.Status))
+		if value.Status == task.StatusCompleted {
+			fmt.Fprintf(&builder, "  t%d [label=%s, color=gray, fontcolor=gray];\n", value.ID, label)
+			continue
+		}
+		fmt.Fprintf(&builder, "  t%d [label=%s];\n", value.ID, label)
+	}
+	for _, dependency := range graph.Dependencies {
+		fmt.Fprintf(&builder, "  t%d -> t%d;\n", dependency.BlockedBy, dependency.Task)
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// RenderRecurring выводит таблицу серий повторяющихся задач: текущая задача, правило повтора,
+// её срок, срок следующей задачи и количество выполненных задач серии.
+func (r *TerminalRender) RenderRecurring(series []Series) {
+	titleMax := utf8.RuneCountInString("Задача")
+	ruleMax := utf8.RuneCountInString("Повтор")
+	for _, value := range series {
+		titleMax = max(titleMax, utf8.RuneCountInString(value.Task.Title))
+		ruleMax = max(ruleMax, utf8.RuneCountInString(recurrenceLabel(value.Rule)))
+	}
+
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "%-4s | %-*s | %-*s | %-10s | %-10s | %s\n", "ID", titleMax, "Задача", ruleMax, "Повтор",
+		"Срок", "Следующая", "Выполнено")
+	fmt.Fprintln(r.out(), strings.Repeat("-", titleMax+ruleMax+52))
+	for _, value := range series {
+		fmt.Fprintf(r.out(), "%-4d | %-*s | %-*s | %-10s | %-10s | %d\n", value.Task.ID, titleMax, value.Task.Title,
+			ruleMax, recurrenceLabel(value.Rule), dueLabel(value.Task.Due), value.Next.Format("02.01.2006"), value.Completed)
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// recurLabel возвращает описание правила повтора задачи или само правило, если его не удалось разобрать.
+func recurLabel(rule string) string {
+	recurrence, err := task.ParseRecurrence(rule)
+	if err != nil {
+		return rule
+	}
+	return recurrenceLabel(recurrence)
+}
+
+// recurrenceLabel возвращает описание правила повтора: "каждую неделю", "каждые 2 нед. (пн, чт)", "по будням".
+func recurrenceLabel(rule task.Recurrence) string {
+	if rule.IsWeekdays() {
+		return "по будням"
+	}
+	var label string
+	switch rule.Freq {
+	case task.FreqDaily:
+		label = intervalLabel(rule.Interval, "каждый день", "дн.")
+	case task.FreqWeekly:
+		label = intervalLabel(rule.Interval, "каждую неделю", "нед.")
+	case task.FreqMonthly:
+		label = intervalLabel(rule.Interval, "каждый месяц", "мес.")
+	default:
+		label = intervalLabel(rule.Interval, "каждый год", "г.")
+	}
+	if len(rule.ByDay) > 0 {
+		days := make([]string, 0, len(rule.ByDay))
+		for _, day := range rule.ByDay {
+			days = append(days, weekdayLabels[(int(day)+6)%7])
+		}
+		label += " (" + strings.Join(days, ", ") + ")"
+	}
+	if rule.ByMonthDay == task.LastDayOfMonth {
+		label += " (последний день)"
+	} else if rule.ByMonthDay != 0 {
+		label += fmt.Sprintf(" (%d-го числа)", rule.ByMonthDay)
+	}
+	return label
+}
+
+// intervalLabel возвращает single для интервала 1, иначе "каждые N unit".
+func intervalLabel(interval int, single, unit string) string {
+	if interval <= 1 {
+		return single
+	}
+	return fmt.Sprintf("каждые %d %s", interval, unit)
+}
+
+// RenderTagStats выводит таблицу тегов с количеством задач в каждом статусе в переданном порядке.
+func (r *TerminalRender) RenderTagStats(stats []TagStats) {
+	columnMax := utf8.RuneCountInString("Тег")
+	for _, value := range stats {
+		if columnMax < utf8.RuneCountInString(value.Tag)+1 {
+			columnMax = utf8.RuneCountInString(value.Tag) + 1
+		}
+	}
+
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "%-*s | %-8s | %-8s | %-8s | %-9s\n", columnMax, "Тег", "Всего", LabelPending, LabelProgress, LabelCompleted)
+	fmt.Fprintln(r.out(), strings.Repeat("-", columnMax+46))
+	for _, value := range stats {
+		fmt.Fprintf(r.out(), "%-*s | %-8d | %-8d | %-8d | %-9d\n", columnMax, "+"+value.Tag,
+			value.Stats.Total, value.Stats.Pending, value.Stats.InProgress, value.Stats.Completed)
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// estimateProgressLabel возвращает оценку задачи в сравнении с затраченным временем:
+// "2h, затрачено 1ч 30м, осталось 30м", для превышенной оценки - "превышение на ...".
+// Для оценки в story points и выполненной задачи остаток не выводится, как и в todo stats --effort.
+func estimateProgressLabel(value *task.Task, now time.Time) string {
+	label := fmt.Sprintf("%s, затрачено %s", value.Estimate, durationLabel(value.TimeSpent(now)))
+	remaining, ok := value.Remaining(now)
+	switch {
+	case !ok || value.Status == task.StatusCompleted:
+		return label
+	case remaining < 0:
+		return fmt.Sprintf("%s, превышение на %s", label, durationLabel(-remaining))
+	}
+	return fmt.Sprintf("%s, осталось %s", label, durationLabel(remaining))
+}
+
+// RenderEffortStats выводит таблицы суммарных оценок по статусам и тегам с итоговой строкой:
+// количество задач и задач с оценкой, оценка в часах и story points, затраченное и оставшееся время в часах.
+func (r *TerminalRender) RenderEffortStats(stats EffortStats) {
+	columnMax := utf8.RuneCountInString("in_progress")
+	for _, value := range stats.ByTag {
+		columnMax = max(columnMax, utf8.RuneCountInString(value.Tag)+1)
+	}
+	header := func(title string) {
+		fmt.Fprintf(r.out(), "%-*s | %-6s | %-9s | %-9s | %-8s | %-9s | %-9s\n", columnMax, title,
+			"Задач", "С оценкой", "Оценка", "Очки", "Затрачено", "Осталось")
+		fmt.Fprintln(r.out(), strings.Repeat("-", columnMax+75))
+	}
+	row := func(label string, effort Effort) {
+		fmt.Fprintf(r.out(), "%-*s | %-6d | %-9d | %-9s | %-8s | %-9s | %-9s\n", columnMax, label,
+			effort.Tasks, effort.Estimated, hoursLabel(effort.Duration), strconv.FormatFloat(effort.Points, 'f', -1, 64),
+			hoursLabel(effort.Spent), hoursLabel(effort.Remaining))
+	}
+
+	fmt.Fprint(r.out(), "\n")
+	header("Статус")
+	for _, value := range stats.ByStatus {
+		row(value.Status.String(), value.Effort)
+	}
+	row(LabelTotal, stats.Total)
+	if len(stats.ByTag) > 0 {
+		fmt.Fprint(r.out(), "\n")
+		header("Тег")
+		for _, value := range stats.ByTag {
+			row("+"+value.Tag, value.Effort)
+		}
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// RenderLists выводит таблицу списков задач с количеством задач в каждом.
+// Список по умолчанию и текущий список отмечаются в последней колонке.
+func (r *TerminalRender) RenderLists(lists []ListInfo) {
+	columnMax := utf8.RuneCountInString("Список")
+	for _, list := range lists {
+		if columnMax < utf8.RuneCountInString(list.Name) {
+			columnMax = utf8.RuneCountInString(list.Name)
+		}
+	}
+
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "%-*s | %-6s | %s\n", columnMax, "Список", "Задач", "")
+	fmt.Fprintln(r.out(), strings.Repeat("-", columnMax+30))
+	for _, list := range lists {
+		marks := make([]string, 0, 2)
+		if list.Default {
+			marks = append(marks, "по умолчанию")
+		}
+		if list.Current {
+			marks = append(marks, "текущий")
+		}
+		fmt.Fprintf(r.out(), "%-*s | %-6d | %s\n", columnMax, list.Name, list.Tasks, strings.Join(marks, ", "))
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// подписи периодов статистики для вывода в терминал
+var periodLabels = map[string]string{
+	"week":  "неделя",
+	"month": "месяц",
+}
+
+// RenderFlowStats выводит показатели потока задач: выполненные задачи по периодам,
+// lead time и cycle time (среднее, медиана и 90-й перцентиль) и самые старые открытые задачи с их возрастом.
+func (r *TerminalRender) RenderFlowStats(stats FlowStats) {
+	now := time.Now()
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "Выполнено задач (период - %s):\n", periodLabels[stats.Period])
+	for _, count := range stats.Throughput {
+		fmt.Fprintf(r.out(), "  с %s  %d\n", count.Start.Format("02.01.2006"), count.Completed)
+	}
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "Lead time (создание → завершение): %s\n", durationStatsLabel(stats.LeadTime))
+	fmt.Fprintf(r.out(), "Cycle time (начало → завершение): %s\n", durationStatsLabel(stats.CycleTime))
+	if len(stats.Oldest) > 0 {
+		fmt.Fprint(r.out(), "\n")
+		fmt.Fprintln(r.out(), "Самые старые открытые задачи:")
+		for _, value := range stats.Oldest {
+			fmt.Fprintf(r.out(), "  #%-4d %s (%s, %s)\n", value.ID, value.Title, value.Status, durationLabel(now.Sub(value.CreatedAt)))
+		}
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// durationStatsLabel возвращает строку "среднее ..., медиана ..., 90% ... (задач: N)" или "нет данных".
+func durationStatsLabel(stats DurationStats) string {
+	if stats.Count == 0 {
+		return "нет данных"
+	}
+	return fmt.Sprintf("среднее %s, медиана %s, 90%% %s (задач: %d)",
+		durationLabel(stats.Average), durationLabel(stats.P50), durationLabel(stats.P90), stats.Count)
+}
+
+const (
+	// максимальная ширина столбца графика в символах
+	chartWidth = 30
+	chartBar   = "█"
+)
+
+// уровни тепловой карты от отсутствия выполненных задач до максимума
+var heatmapLevels = []string{"·", "░", "▒", "▓", "█"}
+
+// сокращённые дни недели с понедельника для тепловой карты
+var weekdayLabels = []string{"пн", "вт", "ср", "чт", "пт", "сб", "вс"}
+
+// bar возвращает столбец графика длиной, пропорциональной value относительно maxValue.
+// Ненулевое значение всегда занимает хотя бы один символ.
+func bar(value, maxValue int) string {
+	if value <= 0 || maxValue <= 0 {
+		return ""
+	}
+	return strings.Repeat(chartBar, max(1, value*chartWidth/maxValue))
+}
+
+// heatmapLevel возвращает символ тепловой карты для value относительно maxValue.
+func heatmapLevel(value, maxValue int) string {
+	if value <= 0 || maxValue <= 0 {
+		return heatmapLevels[0]
+	}
+	// округление вверх: максимальное значение получает последний уровень
+	steps := len(heatmapLevels) - 1
+	return heatmapLevels[(value*steps+maxValue-1)/maxValue]
+}
+
+// RenderActivity выводит графики активности в терминал:
+// созданные и выполненные задачи по дням, количество открытых задач на конец дня (burndown)
+// и тепловую карту выполненных задач по неделям (строки - дни недели, колонки - недели).
+func (r *TerminalRender) RenderActivity(activity Activity) {
+	maxDay, maxOpen := 0, 0
+	for _, day := range activity.Days {
+		maxDay = max(maxDay, day.Created, day.Completed)
+		maxOpen = max(maxOpen, day.Open)
+	}
+
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintln(r.out(), "Создано (+) и выполнено (✓) задач по дням:")
+	for _, day := range activity.Days {
+		fmt.Fprintf(r.out(), "  %s %s  + %-*s %-3d\n", day.Date.Format("02.01"), weekdayLabels[(int(day.Date.Weekday())+6)%7],
+			chartWidth, bar(day.Created, maxDay), day.Created)
+		fmt.Fprintf(r.out(), "  %-8s  ✓ %-*s %-3d\n", "", chartWidth, bar(day.Completed, maxDay), day.Completed)
+	}
+
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintln(r.out(), "Открытые задачи на конец дня:")
+	for _, day := range activity.Days {
+		fmt.Fprintf(r.out(), "  %s  %-*s %d\n", day.Date.Format("02.01"), chartWidth, bar(day.Open, maxOpen), day.Open)
+	}
+
+	maxCompleted := 0
+	for _, day := range activity.Heatmap {
+		maxCompleted = max(maxCompleted, day.Completed)
+	}
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintln(r.out(), "Выполненные задачи по неделям:")
+	for weekday, label := range weekdayLabels {
+		cells := make([]string, 0, len(activity.Heatmap)/7+1)
+		for i := weekday; i < len(activity.Heatmap); i += 7 {
+			cells = append(cells, heatmapLevel(activity.Heatmap[i].Completed, maxCompleted))
+		}
+		fmt.Fprintf(r.out(), "  %s %s\n", label, strings.Join(cells, " "))
+	}
+	fmt.Fprintf(r.out(), "  меньше %s больше\n", strings.Join(heatmapLevels, " "))
+	fmt.Fprint(r.out(), "\n")
+}
+
+// подписи группировок затраченного времени для вывода в терминал
+var timeByLabels = map[string]string{
+	"task": "по задачам",
+	"day":  "по дням",
+	"tag":  "по тегам",
+}
+
+// timeEntryLabel возвращает подпись строки затраченного времени: задачу, день или тег.
+func timeEntryLabel(entry TimeEntry) string {
+	switch {
+	case entry.Task != nil:
+		return fmt.Sprintf("#%-4d %s", entry.Task.ID, entry.Task.Title)
+	case entry.Tag != "":
+		return "+" + entry.Tag
+	case !entry.Date.IsZero():
+		return fmt.Sprintf("%s %s", weekdayLabels[(int(entry.Date.Weekday())+6)%7], entry.Date.Format("02.01"))
+	}
+	return "без тега"
+}
+
+// hoursLabel возвращает длительность в часах с одним знаком после запятой, например "12.5 ч".
+func hoursLabel(duration time.Duration) string {
+	return fmt.Sprintf("%.1f ч", duration.Hours())
+}
+
+// periodRangeLabel возвращает промежуток дат from - to в формате DD.MM.YYYY, to не включается.
+func periodRangeLabel(from, to time.Time) string {
+	return fmt.Sprintf("%s – %s", from.Format("02.01.2006"), to.AddDate(0, 0, -1).Format("02.01.2006"))
+}
+
+// renderTimeEntries выводит строки затраченного времени с выровненными подписями.
+func (r *TerminalRender) renderTimeEntries(entries []TimeEntry) {
+	columnMax := 0
+	for _, entry := range entries {
+		columnMax = max(columnMax, utf8.RuneCountInString(timeEntryLabel(entry)))
+	}
+	for _, entry := range entries {
+		fmt.Fprintf(r.out(), "  %-*s  %s\n", columnMax, timeEntryLabel(entry), durationLabel(entry.Spent))
+	}
+}
+
+// RenderTimeLog выводит затраченное время по задачам, дням или тегам за период, общее время
+// и задачу, по которой сейчас идёт учёт времени.
+func (r *TerminalRender) RenderTimeLog(log TimeLog) {
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "Затраченное время %s (%s):\n", timeByLabels[log.By], periodRangeLabel(log.From, log.To))
+	if len(log.Entries) == 0 {
+		fmt.Fprintln(r.out(), "  нет данных")
+	}
+	r.renderTimeEntries(log.Entries)
+	fmt.Fprintf(r.out(), "Всего: %s\n", durationLabel(log.Total))
+	if log.Active != nil && log.Active.IsTracking() {
+		started := log.Active.TimeLog[len(log.Active.TimeLog)-1].Start
+		fmt.Fprintf(r.out(), "Идёт учёт времени: #%d %s (с %s)\n", log.Active.ID, log.Active.Title, timeLabel(&started))
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// RenderTimeReport выводит отчёт о затраченном времени: график по дням периода,
+// общее время в часах и время по задачам.
+func (r *TerminalRender) RenderTimeReport(report TimeReport) {
+	maxDay := 0
+	for _, day := range report.Days {
+		maxDay = max(maxDay, int(day.Spent.Minutes()))
+	}
+
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "Затраченное время, %s %s:\n", periodLabels[report.Period], periodRangeLabel(report.From, report.To))
+	for _, day := range report.Days {
+		fmt.Fprintf(r.out(), "  %s  %-*s %s\n", timeEntryLabel(day), chartWidth, bar(int(day.Spent.Minutes()), maxDay),
+			durationLabel(day.Spent))
+	}
+	fmt.Fprintf(r.out(), "Всего: %s (%s)\n", durationLabel(report.Total), hoursLabel(report.Total))
+	if len(report.Tasks) > 0 {
+		fmt.Fprint(r.out(), "\n")
+		fmt.Fprintln(r.out(), "По задачам:")
+		r.renderTimeEntries(report.Tasks)
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// подписи фаз помодоро для вывода в терминал
+var phaseLabels = map[string]string{
+	PhaseWork:  "работа",
+	PhaseBreak: "перерыв",
+}
+
+// clockLabel возвращает длительность в формате ММ:СС.
+func clockLabel(duration time.Duration) string {
+	seconds := int(duration.Round(time.Second).Seconds())
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// RenderPomodoro перерисовывает строку отсчёта помодоро с индикатором выполнения фазы.
+// Строка переводится, когда фаза закончилась или отсчёт прерван.
+func (r *TerminalRender) RenderPomodoro(progress PomodoroProgress) {
+	filled := 0
+	if progress.Total > 0 {
+		filled = int(int64(chartWidth) * int64(progress.Elapsed) / int64(progress.Total))
+	}
+	filled = min(filled, chartWidth)
+	fmt.Fprintf(r.out(), "\r#%d %-7s %d/%d [%s%s] %s / %s", progress.Task.ID, phaseLabels[progress.Phase],
+		progress.Round, progress.Rounds, strings.Repeat(chartBar, filled), strings.Repeat("░", chartWidth-filled),
+		clockLabel(progress.Elapsed), clockLabel(progress.Total))
+	if progress.Interrupted || progress.Elapsed >= progress.Total {
+		fmt.Fprint(r.out(), "\n")
+	}
+}
+
+// подписи операций журнала для вывода в терминал
+var opLabels = map[journal.Op]string{
+	journal.OpCreate:   "создание",
+	journal.OpEdit:     "изменение",
+	journal.OpStart:    "начало работы",
+	journal.OpStop:     "остановка",
+	journal.OpPomodoro: "помодоро",
+	journal.OpComplete: "завершение",
+	journal.OpDelete:   "удаление",
+	journal.OpRestore:  "восстановление",
+	journal.OpBlock:    "блокировка",
+	journal.OpUnblock:  "разблокировка",
+	journal.OpImport:   "импорт",
+}
+
+// RenderHistory выводит таблицу операций журнала в переданном порядке.
+// Номер в первой колонке - сколько операций нужно отменить, чтобы отменить и эту (todo undo N).
+// Записи одной операции (изменения задач одной командой) выводятся под общим номером.
+func (r *TerminalRender) RenderHistory(entries []journal.Entry) {
+	columnMax := utf8.RuneCountInString("Задача")
+	for _, entry := range entries {
+		if columnMax < utf8.RuneCountInString(entry.Title()) {
+			columnMax = utf8.RuneCountInString(entry.Title())
+		}
+	}
+
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "%-3s | %-14s | %-4s | %-*s | %-16s\n", "№", "Операция", "ID", columnMax, "Задача", "Время")
+	fmt.Fprintln(r.out(), strings.Repeat("-", columnMax+52))
+	operation := 0
+	for i, entry := range entries {
+		// записи одной операции идут подряд, номер выводится у первой из них
+		number := ""
+		if i == 0 || !journal.SameOperation(entries[i-1], entry) {
+			operation += 1
+			number = strconv.Itoa(operation)
+		}
+		fmt.Fprintf(r.out(), "%-3s | %-14s | %-4d | %-*s | %-16s\n", number, opLabels[entry.Op], entry.TaskID,
+			columnMax, entry.Title(), entry.At.Format("02.01.2006 15:04"))
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// RenderImport выводит итог импорта: количество созданных задач с диапазоном новых ID,
+// пропущенные дубликаты и записи с ошибками с причинами.
+func (r *TerminalRender) RenderImport(report ImportReport) {
+	fmt.Fprint(r.out(), "\n")
+	fmt.Fprintf(r.out(), "Создано задач: %d", len(report.Created))
+	switch count := len(report.Created); {
+	case count == 1:
+		fmt.Fprintf(r.out(), " (#%d)", report.Created[0].ID)
+	case count > 1:
+		fmt.Fprintf(r.out(), " (#%d - #%d)", report.Created[0].ID, report.Created[count-1].ID)
+	}
+	fmt.Fprintf(r.out(), "\nПропущено: %d\nС ошибками: %d\n", len(report.Skipped), len(report.Failed))
+	for _, group := range []struct {
+		label  string
+		issues []ImportIssue
+	}{{"Пропущенные записи", report.Skipped}, {"Записи с ошибками", report.Failed}, {"Созданы с замечаниями", report.Warnings}} {
+		if len(group.issues) == 0 {
+			continue
+		}
+		fmt.Fprintf(r.out(), "\n%s:\n", group.label)
+		for _, issue := range group.issues {
+			title := ""
+			if issue.Title != "" {
+				title = fmt.Sprintf(" %q", issue.Title)
+			}
+			fmt.Fprintf(r.out(), "  запись %d%s: %s\n", issue.Record, title, issue.Reason)
+		}
+	}
+	fmt.Fprint(r.out(), "\n")
+}
+
+// RenderMessage выводит информационное сообщение о результате команды.
+func (r *TerminalRender) RenderMessage(message string) {
+	fmt.Fprintln(r.out(), message)
+}
+
+// RenderWarning выводит замечание, не прервавшее команду, в поток ошибок (stderr),
+// чтобы оно не смешивалось с данными в stdout.
+func (r *TerminalRender) RenderWarning(err error) {
+	fmt.Fprintf(r.err(), "замечание: %v\n", err)
+}
+
+// RenderError выводит ошибку в поток ошибок (stderr).
+func (r *TerminalRender) RenderError(err error) {
+	fmt.Fprintf(r.err(), "%v\n", err)
 }
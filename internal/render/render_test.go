@@ -0,0 +1,224 @@
+//go:build !production
+
+package render_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update перезаписывает эталонные файлы: go test ./internal/render -update
+var update = flag.Bool("update", false, "перезаписать эталонные файлы в testdata")
+
+// assertGolden сравнивает вывод с эталонным файлом testdata/<name>.golden.
+func assertGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.WriteFile(path, actual, 0644))
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+// subtaskTasks возвращает задачи с фиксированными датами: #1 -> #2 -> #3, #1 -> #4 (выполнена).
+func subtaskTasks(t *testing.T) []*task.Task {
+	tasks, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	created := time.Date(2025, time.October, 15, 10, 0, 0, 0, time.UTC)
+	for _, value := range tasks {
+		value.CreatedAt = created
+		value.StatusHistory = nil
+	}
+	tasks[1].ParentID = 1
+	tasks[2].ParentID = 2
+	tasks[3].ParentID = 1
+	return tasks
+}
+
+// dependencyGraph возвращает граф, в котором #1 ждёт #2 и #4 (выполнена), а #2 ждёт #3.
+func dependencyGraph(tasks []*task.Task) render.Graph {
+	return render.Graph{
+		Tasks: tasks[:4],
+		Dependencies: []render.Dependency{
+			{Task: 1, BlockedBy: 2}, {Task: 1, BlockedBy: 4}, {Task: 2, BlockedBy: 3},
+		},
+	}
+}
+
+// recurringSeries возвращает еженедельную серию по будням и ежемесячную серию на последний день месяца.
+func recurringSeries(t *testing.T, tasks []*task.Task) []render.Series {
+	weekdays, err := task.ParseRecurrence("weekday")
+	require.NoError(t, err)
+	monthly, err := task.ParseRecurrence("FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=-1")
+	require.NoError(t, err)
+	due := time.Date(2025, time.October, 17, 0, 0, 0, 0, time.UTC)
+	tasks[0].Due = &due
+	return []render.Series{
+		{Task: tasks[0], Rule: weekdays, Next: time.Date(2025, time.October, 20, 0, 0, 0, 0, time.UTC), Completed: 3},
+		{Task: tasks[1], Rule: monthly, Next: time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+// timeEntries возвращает затраченное время по задачам, дням и тегам за неделю с 13.10.2025;
+// по задаче #2 идёт учёт времени с 16.10.2025 09:00.
+func timeEntries(tasks []*task.Task) (byTask, byDay, byTag []render.TimeEntry) {
+	date := func(day int) time.Time { return time.Date(2025, time.October, day, 0, 0, 0, 0, time.UTC) }
+	tasks[1].TimeLog = []task.Interval{{Start: date(16).Add(9 * time.Hour)}}
+	byTask = []render.TimeEntry{
+		{Task: tasks[0], Spent: 3 * time.Hour},
+		{Task: tasks[1], Spent: 90 * time.Minute},
+	}
+	byDay = make([]render.TimeEntry, 0, 7)
+	for day, spent := range []time.Duration{2 * time.Hour, 30 * time.Minute, time.Hour, time.Hour, 0, 0, 0} {
+		byDay = append(byDay, render.TimeEntry{Date: date(13 + day), Spent: spent})
+	}
+	byTag = []render.TimeEntry{
+		{Tag: "backend", Spent: 4*time.Hour + 30*time.Minute},
+		{Spent: 15 * time.Minute},
+	}
+	return byTask, byDay, byTag
+}
+
+func TestTerminalRender_Golden(t *testing.T) {
+	tasks := subtaskTasks(t)
+	leaf := func(value *task.Task) render.TaskNode { return render.TaskNode{Task: value} }
+
+	tests := []struct {
+		name   string
+		render func(r *render.TerminalRender)
+	}{
+		{"stats", func(r *render.TerminalRender) {
+			r.RenderStats(testutil.StatsTask(6, 3, 1, 2, 1))
+		}},
+		{"tag_stats", func(r *render.TerminalRender) {
+			r.RenderTagStats([]render.TagStats{
+				{Tag: "backend", Stats: testutil.StatsTask(2, 0, 1, 1, 0)},
+				{Tag: "docs", Stats: testutil.StatsTask(1, 1, 0, 0, 0)},
+			})
+		}},
+		{"version", func(r *render.TerminalRender) {
+			r.RenderVersion(render.VersionInfo{Version: "1.2.0", Date: "2025-10-15"})
+		}},
+		{"subtasks", func(r *render.TerminalRender) {
+			r.RenderWithSubtasks(tasks[0], []*task.Task{tasks[1], tasks[3]})
+		}},
+		{"graph", func(r *render.TerminalRender) {
+			r.RenderGraph(dependencyGraph(tasks), render.GraphText)
+		}},
+		{"graph_dot", func(r *render.TerminalRender) {
+			r.RenderGraph(dependencyGraph(tasks), render.GraphDOT)
+		}},
+		{"recurring", func(r *render.TerminalRender) {
+			r.RenderRecurring(recurringSeries(t, subtaskTasks(t)))
+		}},
+		{"time_log", func(r *render.TerminalRender) {
+			tasks := subtaskTasks(t)
+			byTask, _, byTag := timeEntries(tasks)
+			from, to := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, time.October, 17, 0, 0, 0, 0, time.UTC)
+			r.RenderTimeLog(render.TimeLog{By: "task", From: from, To: to, Entries: byTask, Total: 270 * time.Minute, Active: tasks[1]})
+			r.RenderTimeLog(render.TimeLog{By: "tag", From: from, To: to, Entries: byTag, Total: 270 * time.Minute})
+			r.RenderTimeLog(render.TimeLog{By: "day", From: from, To: to, Entries: []render.TimeEntry{}})
+		}},
+		{"time_report", func(r *render.TerminalRender) {
+			byTask, byDay, _ := timeEntries(subtaskTasks(t))
+			r.RenderTimeReport(render.TimeReport{
+				Period: "week",
+				From:   time.Date(2025, time.October, 13, 0, 0, 0, 0, time.UTC),
+				To:     time.Date(2025, time.October, 20, 0, 0, 0, 0, time.UTC),
+				Days:   byDay,
+				Tasks:  byTask,
+				Total:  270 * time.Minute,
+			})
+		}},
+		{"pomodoro", func(r *render.TerminalRender) {
+			progress := render.PomodoroProgress{Task: tasks[0], Phase: render.PhaseWork, Round: 1, Rounds: 2, Total: 25 * time.Minute}
+			for _, elapsed := range []time.Duration{0, 10 * time.Minute, 25 * time.Minute} {
+				progress.Elapsed = elapsed
+				r.RenderPomodoro(progress)
+			}
+			progress.Phase, progress.Total, progress.Elapsed, progress.Interrupted = render.PhaseBreak, 5*time.Minute, 90*time.Second, true
+			r.RenderPomodoro(progress)
+		}},
+		{"estimates", func(r *render.TerminalRender) {
+			tasks := subtaskTasks(t)
+			tasks[0].Estimate = &task.Estimate{Duration: 90 * time.Minute}
+			tasks[1].Estimate = &task.Estimate{Points: 3}
+			tasks[2].Estimate = &task.Estimate{Duration: 2 * time.Hour}
+			r.RenderList(tasks[:4])
+		}},
+		{"effort_stats", func(r *render.TerminalRender) {
+			backend := render.Effort{Tasks: 2, Estimated: 2, Duration: 2 * time.Hour, Points: 3, Remaining: 2 * time.Hour}
+			r.RenderEffortStats(render.EffortStats{
+				ByStatus: []render.StatusEffort{
+					{Status: task.StatusPending, Effort: backend},
+					{Status: task.StatusProgress, Effort: render.Effort{Tasks: 1, Estimated: 1, Duration: 4 * time.Hour, Spent: 5 * time.Hour}},
+					{Status: task.StatusCompleted, Effort: render.Effort{Tasks: 3, Estimated: 1, Duration: time.Hour, Spent: 30 * time.Minute}},
+				},
+				ByTag: []render.TagEffort{
+					{Tag: "api", Effort: render.Effort{Tasks: 1, Estimated: 1, Points: 3}},
+					{Tag: "backend", Effort: backend},
+				},
+				Total: render.Effort{Tasks: 6, Estimated: 4, Duration: 7 * time.Hour, Points: 3, Spent: 330 * time.Minute, Remaining: 2 * time.Hour},
+			})
+		}},
+		{"import", func(r *render.TerminalRender) {
+			r.RenderImport(render.ImportReport{
+				Created: tasks[:3],
+				Skipped: []render.ImportIssue{{Record: 4, Title: "pending task 1", Reason: "дубликат задачи #1 (то же название и дата создания)"}},
+				Failed:  []render.ImportIssue{{Record: 6, Reason: "поле due: некорректная дата: bad"}},
+				Warnings: []render.ImportIssue{{Record: 2, Title: "pending task 2",
+					Reason: "поле recur: правило повтора 2h не поддерживается, задача создана без повтора"}},
+			})
+			r.RenderImport(render.ImportReport{Created: tasks[:1]})
+		}},
+		{"history", func(r *render.TerminalRender) {
+			at := time.Date(2025, time.October, 16, 18, 0, 0, 0, time.UTC)
+			tasks := subtaskTasks(t)
+			r.RenderHistory([]journal.Entry{
+				{Op: journal.OpDelete, TaskID: 2, Group: 3, At: at, Before: tasks[1], After: tasks[1]},
+				{Op: journal.OpDelete, TaskID: 1, Group: 3, At: at, Before: tasks[0], After: tasks[0]},
+				{Op: journal.OpEdit, TaskID: 1, Group: 2, At: at, Before: tasks[0], After: tasks[0]},
+				{Op: journal.OpCreate, TaskID: 1, Group: 1, At: at, After: tasks[0]},
+			})
+		}},
+		{"estimate_detailed", func(r *render.TerminalRender) {
+			tasks := subtaskTasks(t)
+			completed := time.Date(2025, time.October, 16, 18, 0, 0, 0, time.UTC)
+			tasks[3].CompletedAt = &completed
+			for _, value := range []*task.Task{tasks[0], tasks[3]} {
+				value.Estimate = &task.Estimate{Duration: 2 * time.Hour}
+				r.RenderDetailed(value)
+			}
+		}},
+		{"tree", func(r *render.TerminalRender) {
+			r.RenderTree([]render.TaskNode{
+				{Task: tasks[0], Children: []render.TaskNode{
+					{Task: tasks[1], Children: []render.TaskNode{leaf(tasks[2])}},
+					leaf(tasks[3]),
+				}},
+				leaf(tasks[4]),
+			})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			tt.render(&render.TerminalRender{Out: &buffer})
+			assertGolden(t, tt.name, buffer.Bytes())
+		})
+	}
+}
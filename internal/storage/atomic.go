@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// суффикс файла с предыдущей версией данных
+const backupSuffix = ".bak"
+
+// backupPath возвращает путь к резервной копии файла (предыдущее поколение).
+func backupPath(fileName string) string {
+	return fileName + backupSuffix
+}
+
+// writeFileAtomic записывает данные во временный файл в той же директории,
+// синхронизирует его на диск и переименовывает поверх целевого файла.
+// Переименование в пределах одной файловой системы атомарно, поэтому при падении
+// процесса или Ctrl-C на диске остаётся либо старая, либо новая версия файла целиком.
+func writeFileAtomic(fileName string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(fileName)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(fileName)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("не удалось создать временный файл: %w", err)
+	}
+	tmpName := tmp.Name()
+	// при любой ошибке ниже временный файл не должен оставаться в директории
+	success := false
+	defer func() {
+		if !success {
+			tmp.Close()
+			os.Remove(tmpName)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return fmt.Errorf("не удалось записать временный файл: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("не удалось синхронизировать временный файл: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("не удалось закрыть временный файл: %w", err)
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return fmt.Errorf("не удалось выставить права на файл: %w", err)
+	}
+	if err := os.Rename(tmpName, fileName); err != nil {
+		return fmt.Errorf("не удалось заменить файл %s: %w", fileName, err)
+	}
+	success = true
+	syncDir(dir)
+	return nil
+}
+
+// syncDir сбрасывает на диск запись директории, чтобы переименование пережило сбой питания.
+// Ошибка игнорируется: не все платформы (например, Windows) поддерживают fsync директорий.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	defer d.Close()
+	_ = d.Sync()
+}
+
+// rotateBackup сохраняет текущее содержимое файла в резервную копию .bak перед перезаписью.
+// Копия обновляется только если содержимое файла проходит проверку valid (для файла задач - валидный JSON),
+// иначе испорченный файл затёр бы последнюю рабочую резервную копию.
+func rotateBackup(fileName string, valid func([]byte) bool) error {
+	current, err := os.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("не удалось прочитать файл для резервной копии: %w", err)
+	}
+	if !valid(current) {
+		return nil
+	}
+	return writeFileAtomic(backupPath(fileName), current, fileMode644)
+}
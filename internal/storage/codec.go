@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"todo_cli/internal/task"
+)
+
+var ErrUnknownCodec = errors.New("неизвестный формат файла")
+
+// форматы файлов обмена задачами для экспорта и импорта
+const (
+	CodecJSON        = "json"
+	CodecCSV         = "csv"
+	CodecTodoTxt     = "todotxt"
+	CodecMarkdown    = "markdown"
+	CodecIcs         = "ics"
+	CodecTaskwarrior = "taskwarrior"
+)
+
+// форматы файлов обмена по расширению файла
+var codecExts = map[string]string{
+	".json":     CodecJSON,
+	".csv":      CodecCSV,
+	".txt":      CodecTodoTxt,
+	".md":       CodecMarkdown,
+	".markdown": CodecMarkdown,
+	".ics":      CodecIcs,
+}
+
+// Codec преобразует задачи в формат файла обмена и обратно.
+// Decode возвращает задачи и ошибки по записям файла: для записи, которую не удалось разобрать,
+// задача равна nil, а ошибка указывает причину; для разобранной записи ошибка равна nil.
+// Ошибка вместе с задачей - замечание: запись загружается без части данных (см. TaskwarriorCodec).
+// Ошибка третьим значением означает, что файл не удалось разобрать целиком.
+type Codec interface {
+	Encode(tasks []*task.Task) ([]byte, error)
+	Decode(data []byte) ([]*task.Task, []error, error)
+}
+
+// NewCodec возвращает преобразователь для формата файла: CodecJSON, CodecCSV, CodecTodoTxt, CodecMarkdown,
+// CodecIcs или CodecTaskwarrior. JSON Taskwarrior по расширению .json не отличить от формата хранилища.
+// Возвращает ошибку ErrUnknownCodec, если формат не поддерживается.
+func NewCodec(format string) (Codec, error) {
+	switch strings.ToLower(format) {
+	case CodecJSON:
+		return &JsonCodec{}, nil
+	case CodecCSV:
+		return &CsvCodec{}, nil
+	case CodecTodoTxt:
+		return &TodoTxtCodec{}, nil
+	case CodecMarkdown:
+		return &MarkdownCodec{}, nil
+	case CodecIcs:
+		return &IcsCodec{}, nil
+	case CodecTaskwarrior:
+		return &TaskwarriorCodec{}, nil
+	}
+	return nil, fmt.Errorf("ошибка (%w): %s", ErrUnknownCodec, format)
+}
+
+// CodecByExt возвращает формат файла по его расширению: tasks.csv - CodecCSV, todo.txt - CodecTodoTxt.
+// Возвращает ошибку ErrUnknownCodec, если формат по расширению не определить.
+func CodecByExt(fileName string) (string, error) {
+	format, ok := codecExts[strings.ToLower(filepath.Ext(fileName))]
+	if !ok {
+		return "", fmt.Errorf("ошибка (%w): не удалось определить формат по имени файла %s, укажите --format",
+			ErrUnknownCodec, fileName)
+	}
+	return format, nil
+}
+
+// JsonCodec - файл обмена в формате хранилища: массив задач в JSON (см. DataToJson).
+type JsonCodec struct{}
+
+// Encode преобразует задачи в JSON.
+func (c *JsonCodec) Encode(tasks []*task.Task) ([]byte, error) {
+	return DataToJson(&tasks)
+}
+
+// Decode разбирает массив задач в JSON. Пустые элементы массива (null) считаются ошибочными записями.
+func (c *JsonCodec) Decode(data []byte) ([]*task.Task, []error, error) {
+	var tasks []*task.Task
+	err := JsonToData(data, &tasks)
+	if err != nil {
+		return nil, nil, err
+	}
+	errs := make([]error, len(tasks))
+	for i, value := range tasks {
+		if value == nil {
+			errs[i] = fmt.Errorf("%w: пустая запись", ErrDeserializeJson)
+		}
+	}
+	return tasks, errs, nil
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// имя файла настроек в директории ~/.todo
+const configFileName = "config.json"
+
+// хранилища задач для настройки backend
+const (
+	BackendJSON    = "json"
+	BackendTodoTxt = "todotxt"
+)
+
+// Config хранит настройки приложения между запусками.
+type Config struct {
+	// DefaultList - список задач, который используется, если не передан флаг --list
+	DefaultList string `json:"default_list,omitempty"`
+	// Backend - хранилище задач: json (списки в ~/.todo, по умолчанию) или todotxt (один файл todo.txt)
+	Backend string `json:"backend,omitempty"`
+	// TodoTxtFile - путь к файлу todo.txt для хранилища todotxt, по умолчанию ~/.todo/todo.txt
+	TodoTxtFile string `json:"todotxt_file,omitempty"`
+}
+
+// getConfigPath возвращает путь к файлу настроек ~/.todo/config.json.
+func getConfigPath() (string, error) {
+	todoDir, err := getTodoDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(todoDir, configFileName), nil
+}
+
+// LoadConfig загружает настройки из ~/.todo/config.json.
+// Если файла нет, возвращает настройки по умолчанию.
+func LoadConfig() (*Config, error) {
+	config := &Config{}
+	configPath, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать настройки: %w", err)
+	}
+	err = JsonToData(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось преобразовать настройки: %w", err)
+	}
+	return config, nil
+}
+
+// SaveConfig атомарно сохраняет настройки в ~/.todo/config.json.
+func SaveConfig(config *Config) error {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return err
+	}
+	data, err := DataToJson(config)
+	if err != nil {
+		return fmt.Errorf("ошибка при преобразовании настроек: %w", err)
+	}
+	return writeFileAtomic(configPath, data, fileMode644)
+}
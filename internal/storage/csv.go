@@ -0,0 +1,317 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"todo_cli/internal/task"
+)
+
+var (
+	ErrSerializeCsv   = errors.New("сериализации данных в csv")
+	ErrDeserializeCsv = errors.New("преобразование csv в задачи")
+)
+
+// колонки CSV в порядке экспорта, названия совпадают с ключами задачи в JSON
+var csvColumns = []string{
+	"id", "title", "description", "status", "priority", "created", "started", "completed", "due", "estimate",
+	"tags", "deleted", "parent", "blocked_by", "recur", "series", "time_log", "pomodoros", "status_history",
+}
+
+// форматы дат, которые принимаются при импорте; экспорт пишет даты в RFC 3339
+var csvTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02", "02.01.2006"}
+
+// метка порядка байтов UTF-8, с которой CSV сохраняют табличные редакторы
+const utf8BOM = "\ufeff"
+
+// CsvCodec - файл обмена в формате CSV с заголовком: одна задача на строку, колонки - поля задачи.
+// Даты пишутся в RFC 3339, теги и ID блокирующих задач - через запятую, журнал времени, помодоро
+// и история статусов - в JSON, поэтому экспорт и импорт сохраняют все поля задачи.
+// Mapping сопоставляет колонкам файла поля задачи при импорте ("Название" -> "title", см. ParseCsvMapping);
+// колонки без сопоставления разбираются по названию поля, неизвестные колонки пропускаются.
+type CsvCodec struct {
+	Mapping map[string]string
+}
+
+// ParseCsvMapping разбирает сопоставление колонок в формате "Колонка=поле,Другая колонка=поле".
+// Возвращает ошибку, если пара задана без "=", поле задачи неизвестно или на поле сопоставлено несколько колонок.
+func ParseCsvMapping(value string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		column, field, ok := strings.Cut(pair, "=")
+		column, field = strings.TrimSpace(column), strings.ToLower(strings.TrimSpace(field))
+		if !ok || column == "" {
+			return nil, fmt.Errorf("некорректное сопоставление колонок %q: ожидается Колонка=поле", pair)
+		}
+		if !slices.Contains(csvColumns, field) {
+			return nil, fmt.Errorf("неизвестное поле задачи %q, допустимые поля: %s", field, strings.Join(csvColumns, ", "))
+		}
+		for other, mapped := range mapping {
+			if mapped == field {
+				return nil, fmt.Errorf("на поле %s сопоставлено несколько колонок: %s и %s", field, other, column)
+			}
+		}
+		mapping[column] = field
+	}
+	return mapping, nil
+}
+
+// Encode преобразует задачи в CSV с заголовком из названий полей задачи.
+func (c *CsvCodec) Encode(tasks []*task.Task) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	records := make([][]string, 0, len(tasks)+1)
+	records = append(records, csvColumns)
+	for _, value := range tasks {
+		record, err := csvRecord(value)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка: %w. Задача #%d: %v", ErrSerializeCsv, value.ID, err)
+		}
+		records = append(records, record)
+	}
+	err := writer.WriteAll(records)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка: %w. Данные: %v", ErrSerializeCsv, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// csvRecord возвращает значения колонок CSV для задачи в порядке csvColumns.
+func csvRecord(value *task.Task) ([]string, error) {
+	timeLog, err := csvJson(value.TimeLog)
+	if err != nil {
+		return nil, err
+	}
+	pomodoros, err := csvJson(value.Pomodoros)
+	if err != nil {
+		return nil, err
+	}
+	history, err := csvJson(value.StatusHistory)
+	if err != nil {
+		return nil, err
+	}
+	blockedBy := make([]string, 0, len(value.BlockedBy))
+	for _, id := range value.BlockedBy {
+		blockedBy = append(blockedBy, strconv.Itoa(id))
+	}
+	estimate := ""
+	if value.Estimate != nil {
+		estimate = value.Estimate.String()
+	}
+	return []string{
+		strconv.Itoa(value.ID), value.Title, value.Description, value.Status.String(), value.Priority.String(),
+		csvTime(&value.CreatedAt), csvTime(value.StartedAt), csvTime(value.CompletedAt), csvTime(value.Due), estimate,
+		strings.Join(value.Tags, ","), csvTime(value.DeletedAt), csvID(value.ParentID), strings.Join(blockedBy, ","),
+		value.Recur, csvID(value.SeriesID), timeLog, pomodoros, history,
+	}, nil
+}
+
+// csvTime возвращает дату в RFC 3339 или пустую строку для отсутствующей даты.
+func csvTime(value *time.Time) string {
+	if value == nil || value.IsZero() {
+		return ""
+	}
+	return value.Format(time.RFC3339Nano)
+}
+
+// csvID возвращает ID или пустую строку для нулевого ID.
+func csvID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// csvJson возвращает список в компактном JSON или пустую строку для пустого списка.
+func csvJson[T any](values []T) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Decode разбирает CSV с заголовком. Разделитель - запятая или точка с запятой (определяется по заголовку).
+// Пустые строки пропускаются. Колонка с названием задачи (title) обязательна.
+func (c *CsvCodec) Decode(data []byte) ([]*task.Task, []error, error) {
+	data = bytes.TrimPrefix(data, []byte(utf8BOM))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка: %w. Данные: %v", ErrDeserializeCsv, err)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("ошибка: %w. Данные: нет заголовка", ErrDeserializeCsv)
+	}
+	fields, err := c.fields(records[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка: %w. Данные: %v", ErrDeserializeCsv, err)
+	}
+	tasks := make([]*task.Task, 0, len(records)-1)
+	errs := make([]error, 0, len(records)-1)
+	for _, record := range records[1:] {
+		if !slices.ContainsFunc(record, func(value string) bool { return strings.TrimSpace(value) != "" }) {
+			continue
+		}
+		value, err := csvTask(fields, record)
+		tasks = append(tasks, value)
+		errs = append(errs, err)
+	}
+	return tasks, errs, nil
+}
+
+// fields возвращает поле задачи для каждой колонки заголовка: по сопоставлению Mapping
+// или по названию колонки без учёта регистра. Для неизвестных колонок поле пустое.
+// Возвращает ошибку, если колонки сопоставления нет в файле, нет колонки title или поле задано несколькими колонками.
+func (c *CsvCodec) fields(header []string) ([]string, error) {
+	fields := make([]string, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if field, ok := c.Mapping[column]; ok {
+			fields[i] = field
+		} else if name := strings.ToLower(column); slices.Contains(csvColumns, name) && !c.mapped(name) {
+			fields[i] = name
+		}
+	}
+	for column := range c.Mapping {
+		if !slices.ContainsFunc(header, func(value string) bool { return strings.TrimSpace(value) == column }) {
+			return nil, fmt.Errorf("в файле нет колонки %q из сопоставления", column)
+		}
+	}
+	for i, field := range fields {
+		if field != "" && slices.Index(fields, field) != i {
+			return nil, fmt.Errorf("поле %s задано несколькими колонками", field)
+		}
+	}
+	if !slices.Contains(fields, "title") {
+		return nil, fmt.Errorf("нет колонки с названием задачи, добавьте колонку title или сопоставьте её через --map")
+	}
+	return fields, nil
+}
+
+// mapped сообщает, сопоставлена ли полю задачи колонка через Mapping.
+func (c *CsvCodec) mapped(field string) bool {
+	for _, value := range c.Mapping {
+		if value == field {
+			return true
+		}
+	}
+	return false
+}
+
+// csvTask собирает задачу из значений колонок записи. Пустые значения оставляют поле незаполненным.
+// Возвращает ошибку с названием поля, если значение не удалось разобрать.
+func csvTask(fields, record []string) (*task.Task, error) {
+	value := &task.Task{}
+	for i, field := range fields {
+		if field == "" || i >= len(record) || strings.TrimSpace(record[i]) == "" {
+			continue
+		}
+		err := setCsvField(value, field, record[i])
+		if err != nil {
+			return nil, fmt.Errorf("поле %s: %w", field, err)
+		}
+	}
+	return value, nil
+}
+
+// setCsvField записывает в задачу значение колонки CSV.
+func setCsvField(value *task.Task, field, raw string) error {
+	text := strings.TrimSpace(raw)
+	var err error
+	switch field {
+	case "id":
+		value.ID, err = parseCsvID(text)
+	case "title":
+		value.Title = text
+	case "description":
+		value.Description = raw
+	case "status":
+		value.Status = task.Status(strings.ToLower(text))
+		if !value.Status.Valid() {
+			err = fmt.Errorf("ошибка валидации (%w): %s", task.ErrInvalidStatus, text)
+		}
+	case "priority":
+		value.Priority, err = task.ParsePriority(text)
+	case "created":
+		var created *time.Time
+		created, err = parseCsvTime(text)
+		if created != nil {
+			value.CreatedAt = *created
+		}
+	case "started":
+		value.StartedAt, err = parseCsvTime(text)
+	case "completed":
+		value.CompletedAt, err = parseCsvTime(text)
+	case "due":
+		value.Due, err = parseCsvTime(text)
+	case "estimate":
+		var estimate task.Estimate
+		estimate, err = task.ParseEstimate(text)
+		value.Estimate = &estimate
+	case "tags":
+		value.Tags, err = task.ParseTags(text)
+	case "deleted":
+		value.DeletedAt, err = parseCsvTime(text)
+	case "parent":
+		value.ParentID, err = parseCsvID(text)
+	case "blocked_by":
+		for _, part := range strings.Split(text, ",") {
+			id, err := parseCsvID(strings.TrimSpace(part))
+			if err != nil {
+				return err
+			}
+			value.BlockedBy = append(value.BlockedBy, id)
+		}
+	case "recur":
+		var recurrence task.Recurrence
+		recurrence, err = task.ParseRecurrence(text)
+		value.Recur = recurrence.String()
+	case "series":
+		value.SeriesID, err = parseCsvID(text)
+	case "time_log":
+		err = JsonToData([]byte(text), &value.TimeLog)
+	case "pomodoros":
+		err = JsonToData([]byte(text), &value.Pomodoros)
+	case "status_history":
+		err = JsonToData([]byte(text), &value.StatusHistory)
+	}
+	return err
+}
+
+// parseCsvID разбирает положительный ID задачи.
+func parseCsvID(value string) (int, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: %s", task.ErrInvalidID, value)
+	}
+	return id, nil
+}
+
+// parseCsvTime разбирает дату в RFC 3339 или в одном из форматов табличных редакторов (см. csvTimeLayouts).
+// Даты без часового пояса считаются местным временем.
+func parseCsvTime(value string) (*time.Time, error) {
+	for _, layout := range csvTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("некорректная дата: %s, ожидается 2006-01-02 или RFC 3339", value)
+}
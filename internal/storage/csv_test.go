@@ -0,0 +1,155 @@
+//go:build !production
+
+package storage
+
+import (
+	"testing"
+	"time"
+	"todo_cli/internal/task"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fullTask возвращает задачу с заполненными полями для проверки экспорта и импорта без потерь.
+func fullTask() *task.Task {
+	at := func(hour int) *time.Time {
+		value := time.Date(2025, time.October, 15, hour, 30, 0, 0, time.UTC)
+		return &value
+	}
+	return &task.Task{
+		ID:          7,
+		Title:       "Перевести API на v2",
+		Description: "строка с запятой, \"кавычками\"\nи переносом",
+		Status:      task.StatusCompleted,
+		Priority:    task.PriorityHigh,
+		CreatedAt:   *at(9),
+		StartedAt:   at(10),
+		CompletedAt: at(12),
+		Due:         at(18),
+		Estimate:    &task.Estimate{Duration: 90 * time.Minute},
+		Tags:        []string{"backend", "api"},
+		DeletedAt:   at(13),
+		ParentID:    3,
+		BlockedBy:   []int{4, 5},
+		Recur:       "FREQ=WEEKLY",
+		SeriesID:    7,
+		TimeLog:     []task.Interval{{Start: *at(10), End: at(11)}},
+		Pomodoros:   []task.Pomodoro{{Start: *at(10), End: *at(11), Completed: true}},
+		StatusHistory: []task.StatusChange{
+			{Status: task.StatusPending, At: *at(9)},
+			{Status: task.StatusProgress, At: *at(10)},
+			{Status: task.StatusCompleted, At: *at(12)},
+		},
+	}
+}
+
+func TestCsvCodec_RoundTrip(t *testing.T) {
+	tasks := []*task.Task{fullTask(), {ID: 8, Title: "пустая задача", Status: task.StatusPending}}
+	codec := &CsvCodec{}
+
+	data, err := codec.Encode(tasks)
+	require.NoError(t, err)
+	decoded, errs, err := codec.Decode(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, tasks, decoded)
+}
+
+func TestCsvCodec_Decode(t *testing.T) {
+	data := utf8BOM + "Задача;Срок;Метки;Статус;Комментарий\n" +
+		"Купить молоко;2025-11-01;\"дом,+покупки\";completed;\n" +
+		";;;;\n" +
+		"Позвонить;15.10.2025;;done;\n" +
+		"Отчёт;;;;можно позже\n"
+	codec := &CsvCodec{Mapping: map[string]string{"Задача": "title", "Срок": "due", "Метки": "tags", "Статус": "status"}}
+
+	tasks, errs, err := codec.Decode([]byte(data))
+	require.NoError(t, err)
+
+	require.Len(t, tasks, 3)
+	due := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, &task.Task{Title: "Купить молоко", Status: task.StatusCompleted, Due: &due, Tags: []string{"дом", "покупки"}}, tasks[0])
+	assert.NoError(t, errs[0])
+	assert.Nil(t, tasks[1])
+	assert.ErrorIs(t, errs[1], task.ErrInvalidStatus)
+	assert.Equal(t, &task.Task{Title: "Отчёт"}, tasks[2])
+	assert.NoError(t, errs[2])
+}
+
+func TestCsvCodec_DecodeInvalidHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		mapping map[string]string
+	}{
+		{"пустой файл", "", nil},
+		{"нет колонки title", "name,due\nзадача,2025-10-15\n", nil},
+		{"колонки из сопоставления нет в файле", "title\nзадача\n", map[string]string{"Срок": "due"}},
+		{"поле задано двумя колонками", "title,Title\nзадача,задача\n", nil},
+		{"незакрытая кавычка", "title\n\"задача\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := (&CsvCodec{Mapping: tt.mapping}).Decode([]byte(tt.data))
+			assert.ErrorIs(t, err, ErrDeserializeCsv)
+		})
+	}
+}
+
+func TestParseCsvMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected map[string]string
+		wantErr  bool
+	}{
+		{"несколько колонок", "Задача=title, Срок = DUE,", map[string]string{"Задача": "title", "Срок": "due"}, false},
+		{"пустая строка", "", map[string]string{}, false},
+		{"без знака равенства", "Задача", nil, true},
+		{"неизвестное поле", "Задача=name", nil, true},
+		{"две колонки на одно поле", "Задача=title,Название=title", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := ParseCsvMapping(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mapping)
+		})
+	}
+}
+
+func TestCodecByExt(t *testing.T) {
+	tests := []struct {
+		fileName string
+		expected string
+		wantErr  bool
+	}{
+		{"tasks.csv", CodecCSV, false},
+		{"backup.JSON", CodecJSON, false},
+		{"todo.txt", CodecTodoTxt, false},
+		{"TODO.md", CodecMarkdown, false},
+		{"tasks.ics", CodecIcs, false},
+		{"tasks.xml", "", true},
+		{"tasks", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			format, err := CodecByExt(tt.fileName)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownCodec)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"todo_cli/internal/journal"
+)
+
+// суффикс файла с историей операций списка задач
+const historySuffix = ".history"
+
+// historyPath возвращает путь к журналу операций для файла задач.
+func historyPath(fileName string) string {
+	return fileName + historySuffix
+}
+
+// LoadHistory загружает журнал операций для файла задач (см. journal.History).
+// Если differentFileName = nil, используется дефолтный файл ~/.todo/tasks.json.
+// Отсутствующий журнал означает пустую историю. Повреждённый журнал тоже считается пустым:
+// история вспомогательная и не должна мешать работе с задачами.
+func (fs *FileStorage) LoadHistory(differentFileName *string) (*journal.History, error) {
+	var choiceNameFile string
+	var err error
+
+	if differentFileName != nil {
+		choiceNameFile = *differentFileName
+	} else {
+		choiceNameFile, err = getDefaultFilePath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	history := &journal.History{}
+	data, err := os.ReadFile(historyPath(choiceNameFile))
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить историю: %w", err)
+	}
+	if err := JsonToData(data, history); err != nil {
+		return &journal.History{}, nil
+	}
+	return history, nil
+}
+
+// SaveHistory атомарно сохраняет журнал операций рядом с файлом задач (суффикс .history).
+// Если newFileName = nil, используется дефолтный файл ~/.todo/tasks.json.
+func (fs *FileStorage) SaveHistory(history *journal.History, newFileName *string) error {
+	var choiceNameFile string
+	var err error
+
+	if newFileName != nil {
+		choiceNameFile = *newFileName
+	} else {
+		choiceNameFile, err = getDefaultFilePath()
+		if err != nil {
+			return err
+		}
+	}
+	data, err := DataToJson(history)
+	if err != nil {
+		return fmt.Errorf("ошибка при преобразовании истории: %w", err)
+	}
+	return writeFileAtomic(historyPath(choiceNameFile), data, fileMode644)
+}
@@ -0,0 +1,51 @@
+//go:build !production
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStorage_History(t *testing.T) {
+	tmpDir := t.TempDir()
+	fileName := filepath.Join(tmpDir, "tasks.json")
+	fs := &FileStorage{}
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	// журнала ещё нет - история пустая
+	history, err := fs.LoadHistory(&fileName)
+	require.NoError(t, err)
+	assert.Empty(t, history.Done)
+
+	history.Record(journal.NewEntry(journal.OpDelete, tasksMany[0], nil, time.Now()))
+	require.NoError(t, fs.SaveHistory(history, &fileName))
+	assert.FileExists(t, fileName+".history")
+
+	loaded, err := fs.LoadHistory(&fileName)
+	require.NoError(t, err)
+	require.Len(t, loaded.Done, 1)
+	assert.Equal(t, journal.OpDelete, loaded.Done[0].Op)
+	assert.Equal(t, tasksMany[0].Title, loaded.Done[0].Before.Title)
+	assert.Nil(t, loaded.Done[0].After)
+}
+
+func TestFileStorage_LoadCorruptedHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	fileName := filepath.Join(tmpDir, "tasks.json")
+	fs := &FileStorage{}
+	require.NoError(t, os.WriteFile(fileName+".history", []byte("{broken"), fileMode644))
+
+	history, err := fs.LoadHistory(&fileName)
+	require.NoError(t, err)
+	assert.Empty(t, history.Done)
+	assert.Empty(t, history.Undone)
+}
@@ -0,0 +1,437 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"todo_cli/internal/task"
+	"unicode/utf8"
+)
+
+var ErrDeserializeIcs = errors.New("преобразование iCalendar в задачи")
+
+// форматы дат iCalendar: дата и время в UTC, местные дата и время, дата без времени (VALUE=DATE)
+const (
+	icsTimeUTC   = "20060102T150405Z"
+	icsTimeLocal = "20060102T150405"
+	icsDate      = "20060102"
+)
+
+// максимальная длина строки iCalendar в байтах, длинные строки переносятся с пробелом в начале продолжения
+const icsLineLength = 75
+
+// статусы VTODO для статусов задачи
+var icsStatuses = map[task.Status]string{
+	task.StatusPending:   "NEEDS-ACTION",
+	task.StatusProgress:  "IN-PROCESS",
+	task.StatusCompleted: "COMPLETED",
+}
+
+// значения PRIORITY для приоритетов задачи: 1 - самый высокий, 9 - самый низкий, 0 - не задан
+var icsPriorities = map[task.Priority]int{
+	task.PriorityCritical: 1,
+	task.PriorityHigh:     3,
+	task.PriorityMedium:   5,
+	task.PriorityLow:      9,
+}
+
+// icsProperty - строка содержимого iCalendar вида NAME;PARAM=value:значение.
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// IcsCodec - календарь iCalendar (RFC 5545), каждая задача записывается компонентом VTODO:
+// UID, SUMMARY, DESCRIPTION, STATUS (NEEDS-ACTION, IN-PROCESS, COMPLETED), CREATED, COMPLETED, а также
+// PRIORITY, CATEGORIES (теги), DTSTART (дата начала работы), DUE (срок), RELATED-TO (родительская задача) и RRULE.
+// Файл можно открыть или подписаться на него в календаре. Оценка, связи блокировки, журнал времени,
+// помодоро и история статусов в iCalendar не сохраняются.
+type IcsCodec struct{}
+
+// Encode преобразует задачи в календарь iCalendar. UID задачи составляется из её ID и даты создания,
+// DTSTAMP - время последнего изменения статуса.
+func (c *IcsCodec) Encode(tasks []*task.Task) ([]byte, error) {
+	byID := make(map[int]*task.Task, len(tasks))
+	for _, value := range tasks {
+		byID[value.ID] = value
+	}
+	var buffer bytes.Buffer
+	line := func(name, value string) {
+		writeIcsLine(&buffer, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//todo_cli//todo//RU")
+	for _, value := range tasks {
+		line("BEGIN", "VTODO")
+		line("UID", icsUID(value))
+		line("DTSTAMP", icsTime(lastChange(value)))
+		line("CREATED", icsTime(value.CreatedAt))
+		line("SUMMARY", icsEscape(value.Title))
+		if value.Description != "" {
+			line("DESCRIPTION", icsEscape(value.Description))
+		}
+		if status, ok := icsStatuses[value.Status]; ok {
+			line("STATUS", status)
+		}
+		if priority, ok := icsPriorities[value.Priority]; ok {
+			line("PRIORITY", strconv.Itoa(priority))
+		}
+		if len(value.Tags) > 0 {
+			tags := make([]string, 0, len(value.Tags))
+			for _, tag := range value.Tags {
+				tags = append(tags, icsEscape(tag))
+			}
+			line("CATEGORIES", strings.Join(tags, ","))
+		}
+		if value.StartedAt != nil {
+			line("DTSTART", icsTime(*value.StartedAt))
+		}
+		if value.Due != nil {
+			line("DUE", icsTime(*value.Due))
+		}
+		if value.CompletedAt != nil {
+			line("COMPLETED", icsTime(*value.CompletedAt))
+		}
+		if parent, ok := byID[value.ParentID]; ok && value.ParentID != value.ID {
+			line("RELATED-TO", icsUID(parent))
+		}
+		if value.Recur != "" {
+			line("RRULE", value.Recur)
+		}
+		line("END", "VTODO")
+	}
+	line("END", "VCALENDAR")
+	return buffer.Bytes(), nil
+}
+
+// icsUID возвращает UID задачи в календаре.
+func icsUID(value *task.Task) string {
+	return fmt.Sprintf("%d-%s@todo_cli", value.ID, value.CreatedAt.UTC().Format(icsTimeUTC))
+}
+
+// lastChange возвращает время последнего изменения задачи: последнюю смену статуса или дату создания.
+func lastChange(value *task.Task) time.Time {
+	stamp := value.CreatedAt
+	for _, change := range value.StatusHistory {
+		if change.At.After(stamp) {
+			stamp = change.At
+		}
+	}
+	return stamp
+}
+
+// icsTime возвращает дату и время в UTC в формате iCalendar.
+func icsTime(value time.Time) string {
+	return value.UTC().Format(icsTimeUTC)
+}
+
+// icsEscape экранирует текстовое значение iCalendar: обратную косую черту, точку с запятой, запятую и перенос строки.
+func icsEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// writeIcsLine записывает строку с окончанием CRLF, перенося её по icsLineLength байт без разрыва символов UTF-8.
+func writeIcsLine(buffer *bytes.Buffer, line string) {
+	limit := icsLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut -= 1
+		}
+		buffer.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// строка продолжения начинается с пробела, он входит в длину строки
+		limit = icsLineLength - 1
+	}
+	buffer.WriteString(line + "\r\n")
+}
+
+// Decode разбирает календарь iCalendar, задачами становятся компоненты VTODO, остальные компоненты пропускаются.
+// Задачи получают ID по порядку VTODO, начиная с 1, а RELATED-TO со ссылкой на UID другой задачи файла - родителя.
+// Задачи со статусом CANCELLED и VTODO с некорректными значениями считаются ошибочными записями.
+// Возвращает ошибку ErrDeserializeIcs, если в файле нет календаря или компоненты BEGIN и END не парные.
+func (c *IcsCodec) Decode(data []byte) ([]*task.Task, []error, error) {
+	lines := unfoldIcs(string(bytes.TrimPrefix(data, []byte(utf8BOM))))
+	records := make([][]icsProperty, 0)
+	errs := make([]error, 0)
+	components := make([]string, 0)
+	calendar := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		property, err := parseIcsProperty(line)
+		// запись открыта только для VTODO, вложенного прямо в VCALENDAR (см. BEGIN ниже)
+		inRecord := len(components) == 2 && components[0] == "VCALENDAR" && components[1] == "VTODO"
+		if err != nil {
+			if inRecord && errs[len(errs)-1] == nil {
+				errs[len(errs)-1] = err
+			}
+			continue
+		}
+		switch property.name {
+		case "BEGIN":
+			component := strings.ToUpper(property.value)
+			if component == "VCALENDAR" {
+				calendar = true
+			}
+			if component == "VTODO" && len(components) == 1 && components[0] == "VCALENDAR" {
+				records = append(records, make([]icsProperty, 0))
+				errs = append(errs, nil)
+			}
+			components = append(components, component)
+		case "END":
+			component := strings.ToUpper(property.value)
+			if len(components) == 0 || components[len(components)-1] != component {
+				return nil, nil, fmt.Errorf("ошибка: %w. Данные: END:%s без BEGIN:%s", ErrDeserializeIcs, component, component)
+			}
+			components = components[:len(components)-1]
+		default:
+			if inRecord {
+				records[len(records)-1] = append(records[len(records)-1], property)
+			}
+		}
+	}
+	if !calendar {
+		return nil, nil, fmt.Errorf("ошибка: %w. Данные: нет компонента VCALENDAR", ErrDeserializeIcs)
+	}
+	if len(components) > 0 {
+		return nil, nil, fmt.Errorf("ошибка: %w. Данные: нет END:%s", ErrDeserializeIcs, components[len(components)-1])
+	}
+	tasks := make([]*task.Task, len(records))
+	uids := make(map[string]int, len(records))
+	parents := make(map[int]string, len(records))
+	for i, properties := range records {
+		if errs[i] != nil {
+			continue
+		}
+		value, uid, parent, err := icsTask(properties)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		value.ID = i + 1
+		tasks[i] = value
+		if uid != "" {
+			uids[uid] = value.ID
+		}
+		if parent != "" {
+			parents[value.ID] = parent
+		}
+	}
+	for _, value := range tasks {
+		if value != nil && uids[parents[value.ID]] != value.ID {
+			value.ParentID = uids[parents[value.ID]]
+		}
+	}
+	return tasks, errs, nil
+}
+
+// unfoldIcs разбивает календарь на строки и склеивает перенесённые строки (продолжение начинается с пробела или табуляции).
+func unfoldIcs(data string) []string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseIcsProperty разбирает строку содержимого NAME;PARAM=value;PARAM="value":значение.
+// Двоеточие и точка с запятой внутри кавычек в параметрах не считаются разделителями.
+func parseIcsProperty(line string) (icsProperty, error) {
+	parts := make([]string, 0)
+	quoted := false
+	start := 0
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ';' && !quoted:
+			parts = append(parts, line[start:i])
+			start = i + 1
+		case r == ':' && !quoted:
+			parts = append(parts, line[start:i])
+			property := icsProperty{name: strings.ToUpper(parts[0]), params: make(map[string]string), value: line[i+1:]}
+			for _, param := range parts[1:] {
+				key, value, _ := strings.Cut(param, "=")
+				property.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+			}
+			if property.name == "" {
+				return icsProperty{}, fmt.Errorf("некорректная строка: %s", line)
+			}
+			return property, nil
+		}
+	}
+	return icsProperty{}, fmt.Errorf("некорректная строка: %s", line)
+}
+
+// icsTask собирает задачу из свойств VTODO и возвращает её UID и UID родительской задачи из RELATED-TO.
+// Без STATUS задача с COMPLETED считается выполненной, остальные - ожидающими.
+func icsTask(properties []icsProperty) (*task.Task, string, string, error) {
+	value := &task.Task{}
+	var uid, parent string
+	for _, property := range properties {
+		var err error
+		switch property.name {
+		case "UID":
+			uid = property.value
+		case "SUMMARY":
+			value.Title = strings.TrimSpace(icsUnescape(property.value))
+		case "DESCRIPTION":
+			value.Description = icsUnescape(property.value)
+		case "STATUS":
+			err = setIcsStatus(value, property.value)
+		case "PRIORITY":
+			err = setIcsPriority(value, property.value)
+		case "CATEGORIES":
+			err = addIcsTags(value, property.value)
+		case "CREATED":
+			var created *time.Time
+			created, err = parseIcsTime(property)
+			if created != nil {
+				value.CreatedAt = *created
+			}
+		case "DTSTART":
+			value.StartedAt, err = parseIcsTime(property)
+		case "DUE":
+			value.Due, err = parseIcsTime(property)
+		case "COMPLETED":
+			value.CompletedAt, err = parseIcsTime(property)
+		case "RELATED-TO":
+			if relation := strings.ToUpper(property.params["RELTYPE"]); relation == "" || relation == "PARENT" {
+				parent = property.value
+			}
+		case "RRULE":
+			var recurrence task.Recurrence
+			recurrence, err = task.ParseRecurrence(property.value)
+			value.Recur = recurrence.String()
+		}
+		if err != nil {
+			return nil, "", "", fmt.Errorf("поле %s: %w", property.name, err)
+		}
+	}
+	if value.Status == "" && value.CompletedAt != nil {
+		value.Status = task.StatusCompleted
+	}
+	return value, uid, parent, nil
+}
+
+// addIcsTags добавляет задаче теги из списка CATEGORIES, пустые значения пропускаются.
+func addIcsTags(value *task.Task, raw string) error {
+	for _, category := range icsSplit(raw) {
+		if strings.TrimSpace(category) == "" {
+			continue
+		}
+		tag, err := task.NormalizeTag(category)
+		if err != nil {
+			return err
+		}
+		value.AddTags(tag)
+	}
+	return nil
+}
+
+// setIcsStatus записывает в задачу статус VTODO. Отменённые задачи (CANCELLED) не поддерживаются.
+func setIcsStatus(value *task.Task, raw string) error {
+	for status, name := range icsStatuses {
+		if strings.EqualFold(raw, name) {
+			value.Status = status
+			return nil
+		}
+	}
+	if strings.EqualFold(raw, "CANCELLED") {
+		return errors.New("отменённые задачи не поддерживаются")
+	}
+	return fmt.Errorf("ошибка валидации (%w): %s", task.ErrInvalidStatus, raw)
+}
+
+// setIcsPriority записывает в задачу приоритет по значению PRIORITY: 1 - критический, 2-4 - высокий,
+// 5 - средний, 6-9 - низкий, 0 - без приоритета.
+func setIcsPriority(value *task.Task, raw string) error {
+	priority, err := strconv.Atoi(raw)
+	if err != nil || priority < 0 || priority > 9 {
+		return fmt.Errorf("ошибка валидации (%w): %s", task.ErrInvalidPriority, raw)
+	}
+	switch {
+	case priority == 0:
+		value.Priority = task.PriorityNone
+	case priority == 1:
+		value.Priority = task.PriorityCritical
+	case priority <= 4:
+		value.Priority = task.PriorityHigh
+	case priority == 5:
+		value.Priority = task.PriorityMedium
+	default:
+		value.Priority = task.PriorityLow
+	}
+	return nil
+}
+
+// parseIcsTime разбирает дату iCalendar: в UTC (Z), в часовом поясе TZID, местное время или дату без времени.
+// Часовой пояс, неизвестный системе, заменяется местным.
+func parseIcsTime(property icsProperty) (*time.Time, error) {
+	location := time.Local
+	if tzid := property.params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			location = zone
+		}
+	}
+	if parsed, err := time.ParseInLocation(icsTimeUTC, property.value, time.UTC); err == nil {
+		return &parsed, nil
+	}
+	for _, layout := range []string{icsTimeLocal, icsDate} {
+		if parsed, err := time.ParseInLocation(layout, property.value, location); err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("некорректная дата: %s, ожидается 20060102T150405Z", property.value)
+}
+
+// icsUnescape убирает экранирование текстового значения iCalendar (см. icsEscape).
+func icsUnescape(value string) string {
+	var builder strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped && (r == 'n' || r == 'N'):
+			builder.WriteByte('\n')
+		case escaped:
+			builder.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			continue
+		default:
+			builder.WriteRune(r)
+		}
+		escaped = false
+	}
+	return builder.String()
+}
+
+// icsSplit разбивает список значений iCalendar по неэкранированным запятым и убирает экранирование.
+func icsSplit(value string) []string {
+	parts := make([]string, 0)
+	start := 0
+	escaped := false
+	for i, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			parts = append(parts, icsUnescape(value[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, icsUnescape(value[start:]))
+}
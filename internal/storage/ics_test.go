@@ -0,0 +1,106 @@
+//go:build !production
+
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"todo_cli/internal/task"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIcsCodec_RoundTrip(t *testing.T) {
+	at := func(hour int) *time.Time {
+		value := time.Date(2025, time.October, 15, hour, 30, 0, 0, time.UTC)
+		return &value
+	}
+	tasks := []*task.Task{
+		{
+			ID: 1, Title: "Релиз; версия 2,0", Description: "строка с \\ и\nпереносом", Status: task.StatusProgress,
+			Priority: task.PriorityCritical, CreatedAt: *at(9), StartedAt: at(10), Due: at(18),
+			Tags: []string{"release", "@office"}, Recur: "FREQ=WEEKLY;BYDAY=MO",
+		},
+		{ID: 2, Title: "Обновить README", Status: task.StatusCompleted, Priority: task.PriorityLow,
+			CreatedAt: *at(9), CompletedAt: at(12), ParentID: 1},
+		{ID: 3, Title: strings.TrimSpace(strings.Repeat("очень длинное название ", 10)), Status: task.StatusPending, CreatedAt: *at(11)},
+	}
+	codec := &IcsCodec{}
+
+	data, err := codec.Encode(tasks)
+	require.NoError(t, err)
+	for _, line := range strings.Split(string(data), "\r\n") {
+		assert.LessOrEqual(t, len(line), icsLineLength)
+	}
+	decoded, errs, err := codec.Decode(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Equal(t, tasks, decoded)
+}
+
+func TestIcsCodec_Decode(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\nUID:event\r\nSUMMARY:Встреча\r\nEND:VEVENT\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:parent@example.com\r\n" +
+		"SUMMARY:Подготовить отч\r\n ёт\r\n" +
+		"DESCRIPTION:первая строка\\Nвторая\\, с запятой\r\n" +
+		"DUE;VALUE=DATE:20251101\r\n" +
+		"CREATED;TZID=\"Europe/Moscow\":20251015T120000\r\n" +
+		"PRIORITY:2\r\n" +
+		"CATEGORIES:Work,,home\r\n" +
+		"BEGIN:VALARM\r\nACTION:DISPLAY\r\nDESCRIPTION:Напоминание\r\nEND:VALARM\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\nUID:child\r\nSUMMARY:Собрать цифры\r\nRELATED-TO:parent@example.com\r\n" +
+		"COMPLETED:20251016T100000Z\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:Отменено\r\nSTATUS:CANCELLED\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:Без срока\r\nDUE:завтра\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:Повтор\r\nRRULE:FREQ=DAILY;COUNT=3\r\nEND:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	tasks, errs, err := (&IcsCodec{}).Decode([]byte(data))
+	require.NoError(t, err)
+
+	require.Len(t, tasks, 5)
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	due := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, &task.Task{
+		ID: 1, Title: "Подготовить отчёт", Description: "первая строка\nвторая, с запятой", Priority: task.PriorityHigh,
+		CreatedAt: time.Date(2025, time.October, 15, 12, 0, 0, 0, moscow), Due: &due, Tags: []string{"work", "home"},
+	}, tasks[0])
+	completed := time.Date(2025, time.October, 16, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, &task.Task{ID: 2, Title: "Собрать цифры", Status: task.StatusCompleted, CompletedAt: &completed, ParentID: 1}, tasks[1])
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	for _, i := range []int{2, 3, 4} {
+		assert.Nil(t, tasks[i])
+		assert.Error(t, errs[i])
+	}
+	assert.ErrorIs(t, errs[4], task.ErrInvalidRecurrence)
+}
+
+func TestIcsCodec_DecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"пустой файл", ""},
+		{"не календарь", "title,due\nзадача,2025-10-15\n"},
+		{"VTODO без календаря", "BEGIN:VTODO\r\nSUMMARY:Задача\r\nEND:VTODO\r\n"},
+		{"VTODO после закрытого календаря", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\nBEGIN:X\r\nBEGIN:VTODO\r\nSUMMARY:hi\r\n"},
+		{"некорректная строка вне календаря", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\nBEGIN:X\r\nBEGIN:VTODO\r\nhi\r\n"},
+		{"END без BEGIN", "BEGIN:VCALENDAR\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := (&IcsCodec{}).Decode([]byte(tt.data))
+			assert.ErrorIs(t, err, ErrDeserializeIcs)
+		})
+	}
+}
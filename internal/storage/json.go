@@ -24,7 +24,7 @@ func DataToJson[T any](data *T) ([]byte, error) {
 func JsonToData[T any](jsonData []byte, data *T) error {
 	err := json.Unmarshal(jsonData, data)
 	if err != nil {
-		return fmt.Errorf("ошибка: %w. Данные: %v", ErrSerializeJson, data)
+		return fmt.Errorf("ошибка: %w. Данные: %v", ErrDeserializeJson, err)
 	}
 	return nil
 }
@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrListNotFound    = errors.New("список задач не найден")
+	ErrListExists      = errors.New("список задач уже существует")
+	ErrInvalidListName = errors.New("некорректное имя списка")
+	ErrListNotEmpty    = errors.New("список задач не пуст")
+	ErrDefaultList     = errors.New("нельзя удалить список по умолчанию")
+)
+
+const (
+	// DefaultListName - список по умолчанию, хранится в исторически сложившемся файле tasks.json
+	DefaultListName = "tasks"
+	listExt         = ".json"
+)
+
+// имя списка становится именем файла, поэтому допускаются только буквы, цифры, "-" и "_"
+var listNamePattern = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
+// имена, которые заняты служебными файлами в ~/.todo
+var reservedListNames = []string{strings.TrimSuffix(configFileName, listExt)}
+
+// validateListName проверяет, что имя списка можно использовать как имя файла.
+func validateListName(name string) error {
+	if !listNamePattern.MatchString(name) || slices.Contains(reservedListNames, name) {
+		return fmt.Errorf("ошибка валидации (%w): %q", ErrInvalidListName, name)
+	}
+	return nil
+}
+
+// fileExists сообщает, существует ли файл.
+func fileExists(fileName string) bool {
+	_, err := os.Stat(fileName)
+	return err == nil
+}
+
+// listRemoved сообщает, что файла именованного списка в ~/.todo нет - список удалён или переименован.
+// Список по умолчанию и файлы вне ~/.todo создаются при первом обращении, поэтому удалёнными не считаются.
+func (fs *FileStorage) listRemoved(fileName string) bool {
+	name, ok := strings.CutSuffix(filepath.Base(fileName), listExt)
+	if !ok || name == DefaultListName || validateListName(name) != nil || fileExists(fileName) {
+		return false
+	}
+	todoDir, err := getTodoDir()
+	if err != nil {
+		return false
+	}
+	return filepath.Dir(fileName) == todoDir
+}
+
+// lockLists захватывает блокировки файлов списков в порядке путей, чтобы встречные переименования
+// не ждали друг друга. Возвращает функцию, которая снимает все захваченные блокировки.
+func (fs *FileStorage) lockLists(paths ...string) (func(), error) {
+	slices.Sort(paths)
+	unlocks := make([]func() error, 0, len(paths))
+	release := func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+	for _, path := range paths {
+		unlock, err := fs.lockFile(path)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		unlocks = append(unlocks, unlock)
+	}
+	return release, nil
+}
+
+// removeListFiles удаляет резервную копию и историю операций списка, отсутствующие файлы пропускаются.
+func removeListFiles(listPath string) error {
+	for _, fileName := range []string{backupPath(listPath), historyPath(listPath)} {
+		err := os.Remove(fileName)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("не удалось удалить файл %s: %w", fileName, err)
+		}
+	}
+	return nil
+}
+
+// ListPath возвращает путь к файлу списка задач ~/.todo/<имя>.json.
+// Возвращает ошибку ErrInvalidListName, если имя списка недопустимо.
+func (fs *FileStorage) ListPath(name string) (string, error) {
+	if err := validateListName(name); err != nil {
+		return "", err
+	}
+	todoDir, err := getTodoDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(todoDir, name+listExt), nil
+}
+
+// HasList сообщает, существует ли список задач.
+// Список по умолчанию существует всегда: его файл создаётся при первом обращении.
+func (fs *FileStorage) HasList(name string) (bool, error) {
+	listPath, err := fs.ListPath(name)
+	if err != nil {
+		return false, err
+	}
+	return name == DefaultListName || fileExists(listPath), nil
+}
+
+// OpenList возвращает путь к файлу существующего списка задач.
+// Возвращает ошибку ErrListNotFound, если списка нет.
+func (fs *FileStorage) OpenList(name string) (string, error) {
+	exists, err := fs.HasList(name)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("ошибка (%w): %s, создайте его командой todo lists create", ErrListNotFound, name)
+	}
+	return fs.ListPath(name)
+}
+
+// Lists возвращает имена всех списков задач в алфавитном порядке.
+// Список по умолчанию присутствует в результате, даже если его файл ещё не создан.
+func (fs *FileStorage) Lists() ([]string, error) {
+	todoDir, err := getTodoDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(todoDir, "*"+listExt))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить списки задач: %w", err)
+	}
+	names := []string{DefaultListName}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), listExt)
+		if validateListName(name) != nil || slices.Contains(names, name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// CreateList создаёт новый пустой список задач.
+// Возвращает ошибку ErrListExists, если список с таким именем уже есть.
+func (fs *FileStorage) CreateList(name string) error {
+	listPath, err := fs.ListPath(name)
+	if err != nil {
+		return err
+	}
+	if fileExists(listPath) {
+		return fmt.Errorf("ошибка (%w): %s", ErrListExists, name)
+	}
+	return checkExistsFile(listPath)
+}
+
+// RenameList переименовывает список задач вместе с его резервной копией и историей операций.
+// На время переименования оба файла списков блокируются, как при записи задач (см. Lock).
+// Если переименовывается список по умолчанию, настройка default_list обновляется.
+func (fs *FileStorage) RenameList(oldName, newName string) error {
+	oldPath, err := fs.ListPath(oldName)
+	if err != nil {
+		return err
+	}
+	newPath, err := fs.ListPath(newName)
+	if err != nil {
+		return err
+	}
+	err = fs.renameListFiles(oldName, newName, oldPath, newPath)
+	if err != nil {
+		return err
+	}
+
+	defaultList, err := fs.DefaultList()
+	if err != nil {
+		return err
+	}
+	if defaultList == oldName {
+		return fs.SetDefaultList(newName)
+	}
+	return nil
+}
+
+// renameListFiles переименовывает файлы списка под блокировкой старого и нового файла.
+// Файл блокировки старого списка удаляется: процесс, ожидавший его, получит ошибку ErrListNotFound (см. Lock).
+func (fs *FileStorage) renameListFiles(oldName, newName, oldPath, newPath string) error {
+	unlock, err := fs.lockLists(oldPath, newPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(lockPath(oldPath))
+	defer unlock()
+	if !fileExists(oldPath) {
+		return fmt.Errorf("ошибка (%w): %s", ErrListNotFound, oldName)
+	}
+	if fileExists(newPath) {
+		return fmt.Errorf("ошибка (%w): %s", ErrListExists, newName)
+	}
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return fmt.Errorf("не удалось переименовать список %s: %w", oldName, err)
+	}
+	if fileExists(backupPath(oldPath)) {
+		if err := os.Rename(backupPath(oldPath), backupPath(newPath)); err != nil {
+			return fmt.Errorf("не удалось переименовать резервную копию списка %s: %w", oldName, err)
+		}
+	}
+	if fileExists(historyPath(oldPath)) {
+		if err := os.Rename(historyPath(oldPath), historyPath(newPath)); err != nil {
+			return fmt.Errorf("не удалось переименовать историю списка %s: %w", oldName, err)
+		}
+	}
+	return nil
+}
+
+// DeleteList удаляет список задач вместе с резервной копией, историей операций и файлом блокировки.
+// На время удаления файл списка блокируется, как при записи задач (см. Lock).
+// Непустой список удаляется только при force = true, список по умолчанию удалить нельзя.
+func (fs *FileStorage) DeleteList(name string, force bool) error {
+	listPath, err := fs.ListPath(name)
+	if err != nil {
+		return err
+	}
+	unlock, err := fs.lockLists(listPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(lockPath(listPath))
+	defer unlock()
+	if !fileExists(listPath) {
+		return fmt.Errorf("ошибка (%w): %s", ErrListNotFound, name)
+	}
+	defaultList, err := fs.DefaultList()
+	if err != nil {
+		return err
+	}
+	if name == defaultList {
+		return fmt.Errorf("ошибка (%w): %s", ErrDefaultList, name)
+	}
+	if !force {
+		tasks, err := fs.Load(&listPath)
+		if err != nil {
+			return err
+		}
+		if len(tasks) > 0 {
+			return fmt.Errorf("ошибка (%w): %s содержит задач: %d", ErrListNotEmpty, name, len(tasks))
+		}
+	}
+	if err := os.Remove(listPath); err != nil {
+		return fmt.Errorf("не удалось удалить список %s: %w", name, err)
+	}
+	return removeListFiles(listPath)
+}
+
+// DefaultList возвращает имя списка по умолчанию из настроек (или DefaultListName).
+func (fs *FileStorage) DefaultList() (string, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	if config.DefaultList == "" {
+		return DefaultListName, nil
+	}
+	return config.DefaultList, nil
+}
+
+// SetDefaultList сохраняет в настройках список, который используется без флага --list.
+// Возвращает ошибку ErrListNotFound, если такого списка нет.
+func (fs *FileStorage) SetDefaultList(name string) error {
+	exists, err := fs.HasList(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("ошибка (%w): %s", ErrListNotFound, name)
+	}
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	config.DefaultList = name
+	return SaveConfig(config)
+}
@@ -0,0 +1,160 @@
+//go:build !production
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateListName(t *testing.T) {
+	tests := []struct {
+		name        string
+		listName    string
+		expectedErr bool
+	}{
+		{"латиница", "work", false},
+		{"кириллица с цифрами", "дом-2", false},
+		{"пустое имя", "", true},
+		{"путь", "../etc", true},
+		{"пробел", "my list", true},
+		{"служебное имя", "config", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateListName(tt.listName)
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, ErrInvalidListName)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFileStorage_Lists(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fs := &FileStorage{}
+
+	// на чистой установке есть только список по умолчанию
+	names, err := fs.Lists()
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultListName}, names)
+
+	require.NoError(t, fs.CreateList("work"))
+	assert.ErrorIs(t, fs.CreateList("work"), ErrListExists)
+
+	workPath, err := fs.OpenList("work")
+	require.NoError(t, err)
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+	require.NoError(t, fs.Save(tasksMany, &workPath))
+
+	names, err = fs.Lists()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tasks", "work"}, names)
+
+	_, err = fs.OpenList("nope")
+	assert.ErrorIs(t, err, ErrListNotFound)
+}
+
+func TestFileStorage_DefaultList(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fs := &FileStorage{}
+
+	defaultList, err := fs.DefaultList()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultListName, defaultList)
+
+	assert.ErrorIs(t, fs.SetDefaultList("work"), ErrListNotFound)
+	require.NoError(t, fs.CreateList("work"))
+	require.NoError(t, fs.SetDefaultList("work"))
+
+	defaultList, err = fs.DefaultList()
+	require.NoError(t, err)
+	assert.Equal(t, "work", defaultList)
+
+	// список по умолчанию нельзя удалить, а при переименовании настройка обновляется
+	assert.ErrorIs(t, fs.DeleteList("work", true), ErrDefaultList)
+	require.NoError(t, fs.RenameList("work", "job"))
+	defaultList, err = fs.DefaultList()
+	require.NoError(t, err)
+	assert.Equal(t, "job", defaultList)
+}
+
+func TestFileStorage_RenameDeleteList(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	fs := &FileStorage{}
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	require.NoError(t, fs.CreateList("work"))
+	workPath, err := fs.OpenList("work")
+	require.NoError(t, err)
+	require.NoError(t, fs.Save(tasksMany, &workPath))
+	require.NoError(t, fs.Save(tasksMany, &workPath))
+	require.NoError(t, fs.SaveHistory(&journal.History{}, &workPath))
+
+	require.NoError(t, fs.CreateList("home"))
+	assert.ErrorIs(t, fs.RenameList("work", "home"), ErrListExists)
+	assert.ErrorIs(t, fs.RenameList("nope", "other"), ErrListNotFound)
+	require.NoError(t, fs.RenameList("work", "job"))
+
+	jobPath, err := fs.OpenList("job")
+	require.NoError(t, err)
+	tasks, err := fs.Load(&jobPath)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 6)
+	assert.FileExists(t, jobPath+".bak")
+	assert.FileExists(t, jobPath+".history")
+
+	// непустой список удаляется только с force
+	assert.ErrorIs(t, fs.DeleteList("job", false), ErrListNotEmpty)
+	require.NoError(t, fs.DeleteList("job", true))
+	require.NoError(t, fs.DeleteList("home", false))
+
+	entries, err := os.ReadDir(filepath.Join(home, ".todo"))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.NotContains(t, entry.Name(), "job")
+	}
+}
+
+func TestFileStorage_RenameDeleteListLocked(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fs := &FileStorage{LockTimeout: 100 * time.Millisecond}
+	require.NoError(t, fs.CreateList("work"))
+	workPath, err := fs.OpenList("work")
+	require.NoError(t, err)
+	jobPath, err := fs.ListPath("job")
+	require.NoError(t, err)
+
+	// пока другой процесс пишет в список, переименовать или удалить его нельзя
+	unlock, err := fs.Lock(&workPath)
+	require.NoError(t, err)
+	assert.ErrorIs(t, fs.RenameList("work", "job"), ErrLocked)
+	assert.ErrorIs(t, fs.DeleteList("work", true), ErrLocked)
+	require.NoError(t, unlock())
+	assert.FileExists(t, workPath)
+
+	// занятое целевое имя тоже блокирует переименование
+	unlock, err = fs.lockFile(jobPath)
+	require.NoError(t, err)
+	assert.ErrorIs(t, fs.RenameList("work", "job"), ErrLocked)
+	require.NoError(t, unlock())
+
+	// процесс, дождавшийся блокировки удалённого списка, не создаёт его заново
+	require.NoError(t, fs.DeleteList("work", true))
+	_, err = fs.Lock(&workPath)
+	assert.ErrorIs(t, err, ErrListNotFound)
+	assert.NoFileExists(t, workPath)
+}
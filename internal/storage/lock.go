@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrLocked = errors.New("файл задач заблокирован другим процессом")
+
+const (
+	// DefaultLockTimeout - сколько по умолчанию ждать освобождения блокировки
+	DefaultLockTimeout = 5 * time.Second
+	// lockRetryInterval - пауза между попытками захватить блокировку
+	lockRetryInterval = 50 * time.Millisecond
+	lockSuffix        = ".lock"
+)
+
+// lockPath возвращает путь к файлу-блокировке рядом с файлом задач.
+func lockPath(fileName string) string {
+	return fileName + lockSuffix
+}
+
+// Lock захватывает эксклюзивную рекомендательную (advisory) блокировку файла задач.
+// Блокировка ставится на отдельный файл <имя>.lock и защищает весь цикл
+// "загрузка - изменение - сохранение" от параллельных процессов todo.
+// Если differentFileName = nil, блокируется дефолтный файл ~/.todo/tasks.json.
+// Ожидает освобождения не дольше LockTimeout (DefaultLockTimeout, если не задан).
+// Возвращает функцию для снятия блокировки или ошибку ErrLocked по истечении таймаута.
+// Если за время ожидания именованный список удалили или переименовали (todo lists delete, rename),
+// блокировка снимается и возвращается ошибка ErrListNotFound - иначе загрузка создала бы список заново.
+func (fs *FileStorage) Lock(differentFileName *string) (func() error, error) {
+	var choiceNameFile string
+	var err error
+
+	if differentFileName != nil {
+		choiceNameFile = *differentFileName
+	} else {
+		choiceNameFile, err = getDefaultFilePath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unlock, err := fs.lockFile(choiceNameFile)
+	if err != nil {
+		return nil, err
+	}
+	if fs.listRemoved(choiceNameFile) {
+		unlock()
+		return nil, fmt.Errorf("ошибка (%w): %s удалён или переименован другим процессом", ErrListNotFound, choiceNameFile)
+	}
+	return unlock, nil
+}
+
+// lockFile захватывает блокировку файла, ожидая её освобождения не дольше LockTimeout.
+func (fs *FileStorage) lockFile(choiceNameFile string) (func() error, error) {
+	timeout := fs.LockTimeout
+	if timeout <= 0 {
+		timeout = DefaultLockTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		unlock, acquired, err := acquireFileLock(lockPath(choiceNameFile))
+		if err != nil {
+			return nil, fmt.Errorf("не удалось заблокировать файл %s: %w", choiceNameFile, err)
+		}
+		if acquired {
+			return unlock, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("ошибка (%w): ожидание дольше %v, файл %s", ErrLocked, timeout, choiceNameFile)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !unix
+
+package storage
+
+import (
+	"os"
+)
+
+// acquireFileLock на платформах без flock использует эксклюзивное создание файла-блокировки.
+// Файл существует, пока блокировка удерживается, и удаляется при её снятии.
+func acquireFileLock(name string) (func() error, bool, error) {
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, fileMode644)
+	if os.IsExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	unlock := func() error {
+		file.Close()
+		return os.Remove(name)
+	}
+	return unlock, true, nil
+}
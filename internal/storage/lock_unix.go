@@ -0,0 +1,33 @@
+//go:build unix
+
+package storage
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// acquireFileLock пытается без ожидания захватить flock на файле-блокировке.
+// Сам файл не удаляется при освобождении - иначе два процесса могли бы
+// заблокировать разные inode с одинаковым именем.
+func acquireFileLock(name string) (func() error, bool, error) {
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_RDWR, fileMode644)
+	if err != nil {
+		return nil, false, err
+	}
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		file.Close()
+		return nil, false, nil
+	}
+	if err != nil {
+		file.Close()
+		return nil, false, err
+	}
+	unlock := func() error {
+		defer file.Close()
+		return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	}
+	return unlock, true, nil
+}
@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"todo_cli/internal/task"
+)
+
+// отступ вложенного пункта и описания задачи в списке Markdown
+const markdownIndent = "  "
+
+// разделы чек-листа Markdown в порядке вывода: заголовок раздела задаёт статус невыполненных задач верхнего уровня
+var markdownSections = []struct {
+	status task.Status
+	title  string
+}{
+	{task.StatusProgress, "В работе"},
+	{task.StatusPending, "Ожидает"},
+	{task.StatusCompleted, "Выполнено"},
+}
+
+// пункт чек-листа: отступ, маркер списка (-, * или +), отметка [ ] или [x] и текст пункта
+var markdownItem = regexp.MustCompile(`^([ \t]*)[-*+] \[([ xX])\](?:[ \t]+(.*))?$`)
+
+// MarkdownCodec - чек-лист в формате GitHub Flavored Markdown для вставки в PR и README.
+// Задачи сгруппированы по статусу в разделы "## В работе", "## Ожидает", "## Выполнено" и записаны пунктами
+// "- [ ] название +тег" или "- [x] название +тег". Подзадачи вложены в пункт родителя с отступом в два пробела,
+// описание записывается строками текста с отступом под пунктом задачи.
+// Остальные поля задачи (приоритет, сроки, оценка, связи, журнал времени) в чек-лист не попадают.
+type MarkdownCodec struct{}
+
+// Encode преобразует задачи в чек-лист Markdown. Подзадачи выводятся под родителем независимо от своего статуса,
+// задача, родителя которой нет среди выгружаемых, выводится в разделе своего статуса.
+func (c *MarkdownCodec) Encode(tasks []*task.Task) ([]byte, error) {
+	ids := make(map[int]bool, len(tasks))
+	for _, value := range tasks {
+		ids[value.ID] = true
+	}
+	children := make(map[int][]*task.Task)
+	for _, value := range tasks {
+		if value.ParentID != 0 && value.ParentID != value.ID && ids[value.ParentID] {
+			children[value.ParentID] = append(children[value.ParentID], value)
+		}
+	}
+	var buffer bytes.Buffer
+	for _, section := range markdownSections {
+		roots := make([]*task.Task, 0)
+		for _, value := range tasks {
+			if value.Status == section.status && (value.ParentID == 0 || value.ParentID == value.ID || !ids[value.ParentID]) {
+				roots = append(roots, value)
+			}
+		}
+		if len(roots) == 0 {
+			continue
+		}
+		if buffer.Len() > 0 {
+			buffer.WriteByte('\n')
+		}
+		buffer.WriteString("## " + section.title + "\n\n")
+		visited := make(map[int]bool)
+		for _, value := range roots {
+			writeMarkdownItem(&buffer, value, children, visited, "")
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// writeMarkdownItem записывает пункт задачи с описанием и вложенные пункты подзадач.
+// visited защищает от зацикленных ссылок на родителя.
+func writeMarkdownItem(buffer *bytes.Buffer, value *task.Task, children map[int][]*task.Task, visited map[int]bool, indent string) {
+	if visited[value.ID] {
+		return
+	}
+	visited[value.ID] = true
+	mark := " "
+	if value.Status == task.StatusCompleted {
+		mark = "x"
+	}
+	words := []string{value.Title}
+	for _, tag := range value.Tags {
+		words = append(words, "+"+tag)
+	}
+	buffer.WriteString(indent + "- [" + mark + "] " + strings.Join(words, " ") + "\n")
+	if value.Description != "" {
+		for _, line := range strings.Split(value.Description, "\n") {
+			if strings.TrimSpace(line) == "" {
+				buffer.WriteByte('\n')
+				continue
+			}
+			buffer.WriteString(indent + markdownIndent + line + "\n")
+		}
+	}
+	for _, child := range children[value.ID] {
+		writeMarkdownItem(buffer, child, children, visited, indent+markdownIndent)
+	}
+}
+
+// markdownEntry - разобранный пункт чек-листа: задача, ширина отступа пункта и строки описания.
+type markdownEntry struct {
+	task        *task.Task
+	indent      int
+	description []string
+}
+
+// Decode разбирает чек-лист Markdown. Задачей считается каждый пункт "- [ ]" или "- [x]", вложенные пункты
+// становятся подзадачами пункта уровнем выше, а текст с отступом под пунктом - описанием задачи.
+// Отмеченный пункт - выполненная задача, неотмеченный пункт верхнего уровня в разделе "В работе" - задача в работе,
+// остальные - ожидающие. Слова +tag в тексте пункта становятся тегами. Текст вне пунктов и обычные пункты
+// списка без отметки на верхнем уровне пропускаются. Задачи получают ID по порядку пунктов, начиная с 1.
+func (c *MarkdownCodec) Decode(data []byte) ([]*task.Task, []error, error) {
+	lines := strings.Split(string(bytes.TrimPrefix(data, []byte(utf8BOM))), "\n")
+	entries := make([]*markdownEntry, 0)
+	// открытые пункты от верхнего уровня к текущему
+	stack := make([]*markdownEntry, 0)
+	var section task.Status
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			blank = true
+			continue
+		}
+		indent := markdownIndentWidth(line)
+		if indent == 0 && strings.HasPrefix(line, "#") {
+			section = markdownSection(line)
+			stack = stack[:0]
+			blank = false
+			continue
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if match := markdownItem.FindStringSubmatch(line); match != nil {
+			entry := &markdownEntry{task: parseMarkdownItem(match[2], match[3]), indent: indent}
+			entry.task.ID = len(entries) + 1
+			if len(stack) > 0 {
+				entry.task.ParentID = stack[len(stack)-1].task.ID
+			} else if entry.task.Status == task.StatusPending && section == task.StatusProgress {
+				entry.task.Status = task.StatusProgress
+			}
+			entries = append(entries, entry)
+			stack = append(stack, entry)
+			blank = false
+			continue
+		}
+		if len(stack) > 0 {
+			entry := stack[len(stack)-1]
+			if blank && len(entry.description) > 0 {
+				entry.description = append(entry.description, "")
+			}
+			entry.description = append(entry.description, markdownTrimIndent(line, entry.indent+len(markdownIndent)))
+		}
+		blank = false
+	}
+	tasks := make([]*task.Task, 0, len(entries))
+	for _, entry := range entries {
+		entry.task.Description = strings.Join(entry.description, "\n")
+		tasks = append(tasks, entry.task)
+	}
+	return tasks, make([]error, len(tasks)), nil
+}
+
+// parseMarkdownItem собирает задачу из отметки и текста пункта чек-листа.
+func parseMarkdownItem(mark, text string) *task.Task {
+	value := &task.Task{Status: task.StatusPending}
+	if mark != " " {
+		value.Status = task.StatusCompleted
+	}
+	title, tags := task.ExtractTags(text)
+	value.Title = title
+	value.AddTags(tags...)
+	return value
+}
+
+// markdownSection возвращает статус по заголовку раздела: "## В работе" или "## in_progress".
+// Для незнакомого заголовка возвращает пустой статус.
+func markdownSection(line string) task.Status {
+	title := strings.TrimSpace(strings.TrimLeft(line, "#"))
+	for _, section := range markdownSections {
+		if strings.EqualFold(title, section.title) || strings.EqualFold(title, string(section.status)) {
+			return section.status
+		}
+	}
+	return ""
+}
+
+// markdownIndentWidth возвращает ширину отступа строки, табуляция считается за четыре пробела.
+func markdownIndentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width += 1
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// markdownTrimIndent убирает из строки описания отступ пункта шириной width, сохраняя отступ сверх него.
+func markdownTrimIndent(line string, width int) string {
+	removed := 0
+	for i, r := range line {
+		if removed >= width || (r != ' ' && r != '\t') {
+			return line[i:]
+		}
+		if r == '\t' {
+			removed += 4
+		} else {
+			removed += 1
+		}
+	}
+	return ""
+}
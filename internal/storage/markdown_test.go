@@ -0,0 +1,109 @@
+//go:build !production
+
+package storage
+
+import (
+	"testing"
+	"todo_cli/internal/task"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownCodec_Encode(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: 1, Title: "Релиз 2.0", Status: task.StatusProgress, Tags: []string{"release"}},
+		{ID: 2, Title: "Обновить README", Status: task.StatusCompleted, ParentID: 1, Description: "раздел установки\n\nи примеры"},
+		{ID: 3, Title: "Написать changelog", Status: task.StatusPending, ParentID: 1},
+		{ID: 4, Title: "Починить CI", Status: task.StatusCompleted},
+		{ID: 5, Title: "Сторонняя подзадача", Status: task.StatusPending, ParentID: 42},
+	}
+
+	data, err := (&MarkdownCodec{}).Encode(tasks)
+	require.NoError(t, err)
+
+	expected := "## В работе\n\n" +
+		"- [ ] Релиз 2.0 +release\n" +
+		"  - [x] Обновить README\n" +
+		"    раздел установки\n" +
+		"\n" +
+		"    и примеры\n" +
+		"  - [ ] Написать changelog\n" +
+		"\n## Ожидает\n\n" +
+		"- [ ] Сторонняя подзадача\n" +
+		"\n## Выполнено\n\n" +
+		"- [x] Починить CI\n"
+	assert.Equal(t, expected, string(data))
+}
+
+func TestMarkdownCodec_Decode(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []*task.Task
+	}{
+		{
+			name: "разделы по статусу",
+			data: "# План\n\n## В работе\n- [ ] Релиз\n\n## Выполнено\n- [X] Починить CI\n\n## Ожидает\n* [ ] Changelog\n",
+			expected: []*task.Task{
+				{ID: 1, Title: "Релиз", Status: task.StatusProgress},
+				{ID: 2, Title: "Починить CI", Status: task.StatusCompleted},
+				{ID: 3, Title: "Changelog", Status: task.StatusPending},
+			},
+		},
+		{
+			name: "вложенные пункты и описание",
+			data: "- [ ] Релиз +release\n  описание релиза\n  - [x] README\n\t- [ ] Тесты\n      с отступом\n  продолжение описания\n- [ ] Другое\n",
+			expected: []*task.Task{
+				{ID: 1, Title: "Релиз", Status: task.StatusPending, Tags: []string{"release"},
+					Description: "описание релиза\nпродолжение описания"},
+				{ID: 2, Title: "README", Status: task.StatusCompleted, ParentID: 1},
+				{ID: 3, Title: "Тесты", Status: task.StatusPending, ParentID: 2, Description: "с отступом"},
+				{ID: 4, Title: "Другое", Status: task.StatusPending},
+			},
+		},
+		{
+			name: "текст вне пунктов пропускается",
+			data: utf8BOM + "Список задач:\n\n- обычный пункт\n- [ ] Задача\n\nКонец.\n",
+			expected: []*task.Task{
+				{ID: 1, Title: "Задача", Status: task.StatusPending},
+			},
+		},
+		{
+			name:     "пункт без названия",
+			data:     "- [ ]\n",
+			expected: []*task.Task{{ID: 1, Status: task.StatusPending}},
+		},
+		{
+			name:     "пустой файл",
+			data:     "",
+			expected: []*task.Task{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, errs, err := (&MarkdownCodec{}).Decode([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tasks)
+			assert.Len(t, errs, len(tt.expected))
+		})
+	}
+}
+
+func TestMarkdownCodec_RoundTrip(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: 1, Title: "Релиз 2.0", Status: task.StatusProgress, Tags: []string{"release", "@office"}},
+		{ID: 2, Title: "Обновить README", Status: task.StatusCompleted, ParentID: 1, Description: "раздел установки\n\n  пример с отступом"},
+		{ID: 3, Title: "Написать changelog", Status: task.StatusPending, ParentID: 2},
+		{ID: 4, Title: "Починить CI", Status: task.StatusCompleted},
+	}
+	codec := &MarkdownCodec{}
+
+	data, err := codec.Encode(tasks)
+	require.NoError(t, err)
+	decoded, _, err := codec.Decode(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, tasks, decoded)
+}
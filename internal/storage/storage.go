@@ -1,15 +1,17 @@
 package storage
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 	"todo_cli/internal/task"
 )
 
-// getDefaultFilePath возвращает путь к файлу tasks.json в домашней директории пользователя.
-// Создаёт директорию ~/.todo если её нет.
-func getDefaultFilePath() (string, error) {
+// getTodoDir возвращает путь к директории ~/.todo, где хранятся списки задач и настройки.
+// Создаёт директорию если её нет.
+func getTodoDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("не удалось получить домашнюю директорию: %w", err)
@@ -21,7 +23,17 @@ func getDefaultFilePath() (string, error) {
 		return "", fmt.Errorf("не удалось создать директорию %s: %w", todoDir, err)
 	}
 
-	return filepath.Join(todoDir, "tasks.json"), nil
+	return todoDir, nil
+}
+
+// getDefaultFilePath возвращает путь к файлу tasks.json в домашней директории пользователя.
+// Создаёт директорию ~/.todo если её нет.
+func getDefaultFilePath() (string, error) {
+	todoDir, err := getTodoDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(todoDir, DefaultListName+listExt), nil
 }
 
 const (
@@ -41,7 +53,10 @@ const (
 
 // FileStorage реализует интерфейс Storage для работы с файловой системой.
 // Сохраняет и загружает задачи в формате JSON.
-type FileStorage struct{}
+// LockTimeout задаёт время ожидания блокировки файла (см. Lock).
+type FileStorage struct {
+	LockTimeout time.Duration
+}
 
 // checkExistsFile проверяет существование файла.
 // Если файл не существует, создаёт его с пустым массивом задач "[]".
@@ -50,7 +65,7 @@ func checkExistsFile(fileName string) error {
 	_, err := os.Stat(fileName)
 	if os.IsNotExist(err) {
 		emptyTasks := []byte("[]")
-		return os.WriteFile(fileName, emptyTasks, fileMode644)
+		return writeFileAtomic(fileName, emptyTasks, fileMode644)
 	}
 	return nil
 }
@@ -59,6 +74,8 @@ func checkExistsFile(fileName string) error {
 // Если newFileName = nil, использует дефолтный путь ~/.todo/tasks.json.
 // Если newFileName указан, сохраняет в файл с указанным именем.
 // Автоматически создаёт файл, если он не существует.
+// Запись атомарная: данные пишутся во временный файл и переименовываются поверх исходного,
+// а предыдущая версия сохраняется рядом в файле с суффиксом .bak.
 // Возвращает ошибку при проблемах с сериализацией или записью файла.
 func (fs *FileStorage) Save(tasks []*task.Task, newFileName *string) error {
 	var choiceNameFile string
@@ -80,13 +97,18 @@ func (fs *FileStorage) Save(tasks []*task.Task, newFileName *string) error {
 	if err != nil {
 		return fmt.Errorf("ошибка при преобразовании задачи: %w", err)
 	}
-	return os.WriteFile(choiceNameFile, taskToString, fileMode644)
+	err = rotateBackup(choiceNameFile, json.Valid)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(choiceNameFile, taskToString, fileMode644)
 }
 
 // Load загружает список задач из JSON-файла.
 // Если differentFileName = nil, загружает из дефолтного пути ~/.todo/tasks.json.
 // Если differentFileName указан, загружает из файла с указанным именем.
 // Автоматически создаёт файл с пустым списком, если он не существует.
+// Если основной файл повреждён, пытается загрузить задачи из резервной копии .bak.
 // Возвращает список задач или ошибку при проблемах с чтением или десериализацией.
 func (fs *FileStorage) Load(differentFileName *string) ([]*task.Task, error) {
 	var tasks []*task.Task
@@ -111,7 +133,26 @@ func (fs *FileStorage) Load(differentFileName *string) ([]*task.Task, error) {
 	}
 	err = JsonToData(stringToTasks, &tasks)
 	if err != nil {
-		return nil, fmt.Errorf("не удалось преобразовать задачи: %w", err)
+		backupTasks, backupErr := loadBackup(choiceNameFile)
+		if backupErr != nil {
+			return nil, fmt.Errorf("не удалось преобразовать задачи: %w", err)
+		}
+		return backupTasks, nil
+	}
+	return tasks, nil
+}
+
+// loadBackup загружает задачи из резервной копии файла (суффикс .bak).
+// Используется, когда основной файл не удалось разобрать.
+func loadBackup(fileName string) ([]*task.Task, error) {
+	var tasks []*task.Task
+	stringToTasks, err := os.ReadFile(backupPath(fileName))
+	if err != nil {
+		return nil, err
+	}
+	err = JsonToData(stringToTasks, &tasks)
+	if err != nil {
+		return nil, err
 	}
 	return tasks, nil
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 	"todo_cli/internal/task"
 	"todo_cli/internal/testutil"
 
@@ -65,7 +66,7 @@ func TestJsonToData(t *testing.T) {
 			var tasks []*task.Task
 			err := JsonToData([]byte(tt.jsonData), &tasks)
 			if tt.expectedErr {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, ErrDeserializeJson)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -206,3 +207,129 @@ func TestCheckExistsFile(t *testing.T) {
 	os.Remove(existingFile)
 	os.Remove(newFile)
 }
+
+func TestFileStorage_SaveBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "backup.json")
+	tasksSingle, err := testutil.SingleTask()
+	require.NoError(t, err)
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	fs := &FileStorage{}
+	require.NoError(t, fs.Save(tasksSingle, &testFile))
+	require.NoError(t, fs.Save(tasksMany, &testFile))
+
+	// в .bak лежит предыдущее поколение
+	var backup []*task.Task
+	backupData, err := os.ReadFile(testFile + ".bak")
+	require.NoError(t, err)
+	require.NoError(t, JsonToData(backupData, &backup))
+	assert.Len(t, backup, 1)
+
+	// временные файлы не остаются в директории
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestFileStorage_LoadFallbackToBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "broken.json")
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	fs := &FileStorage{}
+	require.NoError(t, fs.Save(tasksMany, &testFile))
+	require.NoError(t, fs.Save(tasksMany, &testFile))
+
+	tests := []struct {
+		name          string
+		removeBackup  bool
+		expectedCount int
+		expectedErr   bool
+	}{
+		{"обрезанный файл - загрузка из .bak", false, 6, false},
+		{"обрезанный файл без .bak - ошибка", true, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// имитируем оборванную на середине запись
+			require.NoError(t, os.WriteFile(testFile, []byte(`[{"id":1,"tit`), 0644))
+			if tt.removeBackup {
+				require.NoError(t, os.Remove(testFile+".bak"))
+			}
+			result, err := fs.Load(&testFile)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestFileStorage_SaveKeepsValidBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "keep.json")
+	tasksMany, err := testutil.ManyTasks()
+	require.NoError(t, err)
+
+	fs := &FileStorage{}
+	require.NoError(t, fs.Save(tasksMany, &testFile))
+	require.NoError(t, fs.Save(tasksMany, &testFile))
+
+	// испорченный основной файл не должен затирать рабочую резервную копию
+	require.NoError(t, os.WriteFile(testFile, []byte(`{broken`), 0644))
+	require.NoError(t, fs.Save(testutil.EmptyTasks(), &testFile))
+
+	var backup []*task.Task
+	backupData, err := os.ReadFile(testFile + ".bak")
+	require.NoError(t, err)
+	require.NoError(t, JsonToData(backupData, &backup))
+	assert.Len(t, backup, 6)
+}
+
+func TestFileStorage_Lock(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "locked.json")
+
+	holder := &FileStorage{LockTimeout: 100 * time.Millisecond}
+	unlock, err := holder.Lock(&testFile)
+	require.NoError(t, err)
+
+	// второй "процесс" не должен получить блокировку, пока она удерживается
+	waiter := &FileStorage{LockTimeout: 100 * time.Millisecond}
+	_, err = waiter.Lock(&testFile)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	require.NoError(t, unlock())
+
+	unlockAgain, err := waiter.Lock(&testFile)
+	assert.NoError(t, err)
+	require.NoError(t, unlockAgain())
+}
+
+func TestFileStorage_LockWaits(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "wait.json")
+
+	holder := &FileStorage{}
+	unlock, err := holder.Lock(&testFile)
+	require.NoError(t, err)
+
+	// блокировка освобождается раньше таймаута ожидающего
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		unlock()
+	}()
+
+	waiter := &FileStorage{LockTimeout: 2 * time.Second}
+	unlockWaiter, err := waiter.Lock(&testFile)
+	require.NoError(t, err)
+	require.NoError(t, unlockWaiter())
+}
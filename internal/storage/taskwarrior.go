@@ -0,0 +1,460 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"todo_cli/internal/task"
+)
+
+// формат дат в JSON Taskwarrior
+const taskwarriorTime = "20060102T150405Z"
+
+// проект Taskwarrior хранится тегом с этим префиксом: project home.garden - тег project:home.garden
+const taskwarriorProjectTag = "project:"
+
+// именованные периоды повтора Taskwarrior (recur)
+var taskwarriorPeriods = map[string]task.Recurrence{
+	"daily":      {Freq: task.FreqDaily, Interval: 1},
+	"day":        {Freq: task.FreqDaily, Interval: 1},
+	"weekly":     {Freq: task.FreqWeekly, Interval: 1},
+	"week":       {Freq: task.FreqWeekly, Interval: 1},
+	"sennight":   {Freq: task.FreqWeekly, Interval: 1},
+	"biweekly":   {Freq: task.FreqWeekly, Interval: 2},
+	"fortnight":  {Freq: task.FreqWeekly, Interval: 2},
+	"monthly":    {Freq: task.FreqMonthly, Interval: 1},
+	"month":      {Freq: task.FreqMonthly, Interval: 1},
+	"bimonthly":  {Freq: task.FreqMonthly, Interval: 2},
+	"quarterly":  {Freq: task.FreqMonthly, Interval: 3},
+	"semiannual": {Freq: task.FreqMonthly, Interval: 6},
+	"annual":     {Freq: task.FreqYearly, Interval: 1},
+	"yearly":     {Freq: task.FreqYearly, Interval: 1},
+	"year":       {Freq: task.FreqYearly, Interval: 1},
+	"biannual":   {Freq: task.FreqYearly, Interval: 2},
+	"biyearly":   {Freq: task.FreqYearly, Interval: 2},
+}
+
+// единицы периода повтора Taskwarrior вида 2wk, 3mo, 1q: частота и число её интервалов в одной единице
+var taskwarriorUnits = map[string]task.Recurrence{
+	"d": {Freq: task.FreqDaily, Interval: 1}, "day": {Freq: task.FreqDaily, Interval: 1}, "days": {Freq: task.FreqDaily, Interval: 1},
+	"w": {Freq: task.FreqWeekly, Interval: 1}, "wk": {Freq: task.FreqWeekly, Interval: 1}, "wks": {Freq: task.FreqWeekly, Interval: 1},
+	"week": {Freq: task.FreqWeekly, Interval: 1}, "weeks": {Freq: task.FreqWeekly, Interval: 1},
+	"mo": {Freq: task.FreqMonthly, Interval: 1}, "mos": {Freq: task.FreqMonthly, Interval: 1},
+	"month": {Freq: task.FreqMonthly, Interval: 1}, "months": {Freq: task.FreqMonthly, Interval: 1},
+	"q": {Freq: task.FreqMonthly, Interval: 3}, "qtr": {Freq: task.FreqMonthly, Interval: 3}, "qtrs": {Freq: task.FreqMonthly, Interval: 3},
+	"quarter": {Freq: task.FreqMonthly, Interval: 3}, "quarters": {Freq: task.FreqMonthly, Interval: 3},
+	"y": {Freq: task.FreqYearly, Interval: 1}, "yr": {Freq: task.FreqYearly, Interval: 1}, "yrs": {Freq: task.FreqYearly, Interval: 1},
+	"year": {Freq: task.FreqYearly, Interval: 1}, "years": {Freq: task.FreqYearly, Interval: 1},
+	// единицы длительности ISO 8601 (P2W, P3M)
+	"D": {Freq: task.FreqDaily, Interval: 1}, "W": {Freq: task.FreqWeekly, Interval: 1},
+	"M": {Freq: task.FreqMonthly, Interval: 1}, "Y": {Freq: task.FreqYearly, Interval: 1},
+}
+
+// период повтора Taskwarrior: число и единица (2wk, 3 days) или длительность ISO 8601 (P2W)
+var taskwarriorPeriodPattern = regexp.MustCompile(`^(?:P(\d+)([DWMY])|(\d*)\s*([a-z]+))$`)
+
+// статусы задач Taskwarrior
+const (
+	taskwarriorPending   = "pending"
+	taskwarriorCompleted = "completed"
+	taskwarriorDeleted   = "deleted"
+	taskwarriorWaiting   = "waiting"
+	taskwarriorRecurring = "recurring"
+)
+
+// приоритеты Taskwarrior: H, M, L; критический приоритет выгружается как H с замечанием (см. ExportWarnings)
+var taskwarriorPriorities = map[string]task.Priority{
+	"H": task.PriorityHigh,
+	"M": task.PriorityMedium,
+	"L": task.PriorityLow,
+}
+
+// taskwarriorTask - задача в JSON команд task export и task import.
+// Depends в старых версиях Taskwarrior - строка UUID через запятую, в новых - массив, поэтому разбирается отдельно.
+type taskwarriorTask struct {
+	UUID        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Entry       string                  `json:"entry,omitempty"`
+	Modified    string                  `json:"modified,omitempty"`
+	Start       string                  `json:"start,omitempty"`
+	End         string                  `json:"end,omitempty"`
+	Due         string                  `json:"due,omitempty"`
+	Project     string                  `json:"project,omitempty"`
+	Priority    string                  `json:"priority,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Depends     json.RawMessage         `json:"depends,omitempty"`
+	Recur       string                  `json:"recur,omitempty"`
+	Parent      string                  `json:"parent,omitempty"`
+	Annotations []taskwarriorAnnotation `json:"annotations,omitempty"`
+}
+
+// taskwarriorAnnotation - заметка к задаче Taskwarrior.
+type taskwarriorAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// TaskwarriorCodec - JSON команды task export Taskwarrior, который загружается обратно командой task import.
+// Название задачи - description, описание - заметки (annotations), теги - tags, проект - тег project:имя,
+// блокирующие задачи - depends, даты создания, начала, завершения и срок - entry, start, end и due.
+// Задача в работе выгружается со статусом pending и датой start, задача из корзины - со статусом deleted.
+// Правило повтора задачи со сроком выгружается в recur (см. taskwarriorRecur).
+// Подзадачи, оценка, журнал времени, помодоро и история статусов в Taskwarrior не передаются.
+type TaskwarriorCodec struct{}
+
+// Encode преобразует задачи в JSON Taskwarrior. UUID задачи вычисляется из её ID и даты создания,
+// поэтому повторная выгрузка тех же задач даёт те же UUID.
+func (c *TaskwarriorCodec) Encode(tasks []*task.Task) ([]byte, error) {
+	byID := make(map[int]*task.Task, len(tasks))
+	for _, value := range tasks {
+		byID[value.ID] = value
+	}
+	records := make([]taskwarriorTask, 0, len(tasks))
+	for _, value := range tasks {
+		modified := lastChange(value)
+		record := taskwarriorTask{
+			UUID:        taskwarriorUUID(value),
+			Description: value.Title,
+			Status:      taskwarriorPending,
+			Entry:       taskwarriorFormat(&value.CreatedAt),
+			Modified:    taskwarriorFormat(&modified),
+			Start:       taskwarriorFormat(value.StartedAt),
+			Due:         taskwarriorFormat(value.Due),
+		}
+		record.Recur, _ = taskwarriorRecur(value)
+		switch {
+		case value.DeletedAt != nil:
+			record.Status = taskwarriorDeleted
+			record.End = taskwarriorFormat(value.DeletedAt)
+		case value.Status == task.StatusCompleted:
+			record.Status = taskwarriorCompleted
+			record.End = taskwarriorFormat(value.CompletedAt)
+		}
+		switch value.Priority {
+		case task.PriorityCritical, task.PriorityHigh:
+			record.Priority = "H"
+		case task.PriorityMedium:
+			record.Priority = "M"
+		case task.PriorityLow:
+			record.Priority = "L"
+		}
+		for _, tag := range value.Tags {
+			if project, ok := strings.CutPrefix(tag, taskwarriorProjectTag); ok && record.Project == "" {
+				record.Project = project
+				continue
+			}
+			record.Tags = append(record.Tags, tag)
+		}
+		depends := make([]string, 0, len(value.BlockedBy))
+		for _, id := range value.BlockedBy {
+			if blocker, ok := byID[id]; ok {
+				depends = append(depends, taskwarriorUUID(blocker))
+			}
+		}
+		if len(depends) > 0 {
+			data, err := json.Marshal(depends)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка: %w. Задача #%d: %v", ErrSerializeJson, value.ID, err)
+			}
+			record.Depends = data
+		}
+		if value.Description != "" {
+			record.Annotations = []taskwarriorAnnotation{{Entry: record.Entry, Description: value.Description}}
+		}
+		records = append(records, record)
+	}
+	return DataToJson(&records)
+}
+
+// ExportWarnings возвращает замечания о значениях задач, которые Taskwarrior не может сохранить точно:
+// критический приоритет выгружается как H, а правило повтора - неточно или не выгружается (см. taskwarriorRecur).
+func (c *TaskwarriorCodec) ExportWarnings(tasks []*task.Task) []error {
+	warnings := make([]error, 0)
+	for _, value := range tasks {
+		if value.Priority == task.PriorityCritical {
+			warnings = append(warnings, fmt.Errorf("задача #%d: приоритет critical выгружен как H, в Taskwarrior нет критического приоритета", value.ID))
+		}
+		if _, err := taskwarriorRecur(value); err != nil {
+			warnings = append(warnings, fmt.Errorf("задача #%d: %w", value.ID, err))
+		}
+	}
+	return warnings
+}
+
+// taskwarriorRecur возвращает значение recur Taskwarrior для правила повтора задачи: weekdays, именованный
+// период (daily, weekly, monthly, yearly) или интервал (2wk, 3d, 6mo, 2y). Taskwarrior отсчитывает повторы
+// от срока задачи, поэтому задача без срока выгружается без повтора, а день недели и число месяца берутся из срока.
+// Возвращает замечание, если правило не выгружено или выгружено неточно: несколько дней недели (кроме будней)
+// или отсчёт от конца месяца.
+func taskwarriorRecur(value *task.Task) (string, error) {
+	if value.Recur == "" {
+		return "", nil
+	}
+	if value.Due == nil {
+		return "", fmt.Errorf("правило повтора %s не выгружено, в Taskwarrior повторяющейся задаче нужен срок", value.Recur)
+	}
+	recurrence, err := task.ParseRecurrence(value.Recur)
+	if err != nil {
+		return "", fmt.Errorf("правило повтора не выгружено: %w", err)
+	}
+	if recurrence.IsWeekdays() {
+		return "weekdays", nil
+	}
+	periods := map[task.Frequency]struct{ name, unit string }{
+		task.FreqDaily:   {"daily", "d"},
+		task.FreqWeekly:  {"weekly", "wk"},
+		task.FreqMonthly: {"monthly", "mo"},
+		task.FreqYearly:  {"yearly", "y"},
+	}
+	period := periods[recurrence.Freq].name
+	if recurrence.Interval > 1 {
+		period = fmt.Sprintf("%d%s", recurrence.Interval, periods[recurrence.Freq].unit)
+	}
+	if len(recurrence.ByDay) > 1 || recurrence.ByMonthDay < 0 {
+		return period, fmt.Errorf("правило повтора %s выгружено неточно, как recur:%s от срока задачи", value.Recur, period)
+	}
+	return period, nil
+}
+
+// taskwarriorUUID возвращает UUID задачи (версия 5, на основе SHA-1 от UID задачи в iCalendar).
+func taskwarriorUUID(value *task.Task) string {
+	sum := sha1.Sum([]byte(icsUID(value)))
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// taskwarriorFormat возвращает дату в формате Taskwarrior или пустую строку, если даты нет.
+func taskwarriorFormat(value *time.Time) string {
+	if value == nil || value.IsZero() {
+		return ""
+	}
+	return value.UTC().Format(taskwarriorTime)
+}
+
+// Decode разбирает JSON Taskwarrior: массив задач или задачи через запятую, как в старых версиях task export.
+// Задачи получают ID по порядку записей, начиная с 1, ссылки depends на UUID задач файла становятся
+// блокирующими задачами, а экземпляры одной повторяющейся задачи (parent) - одной серией повторов.
+// Шаблоны повторяющихся задач (status recurring) и записи с некорректными значениями считаются ошибочными,
+// а задача с неизвестным правилом повтора (см. taskwarriorRecurrence) создаётся без повтора с замечанием.
+func (c *TaskwarriorCodec) Decode(data []byte) ([]*task.Task, []error, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte(utf8BOM)))
+	if !bytes.HasPrefix(data, []byte("[")) {
+		data = append(append([]byte("["), bytes.TrimSuffix(data, []byte(","))...), ']')
+	}
+	var raw []json.RawMessage
+	err := JsonToData(data, &raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	tasks := make([]*task.Task, len(raw))
+	errs := make([]error, len(raw))
+	uuids := make(map[string]int, len(raw))
+	depends := make(map[int][]string, len(raw))
+	series := make(map[string]int)
+	for i, message := range raw {
+		var record taskwarriorTask
+		if err := json.Unmarshal(message, &record); err != nil {
+			errs[i] = fmt.Errorf("%w: %v", ErrDeserializeJson, err)
+			continue
+		}
+		value, err := taskwarriorToTask(record)
+		if err == nil {
+			depends[i+1], err = taskwarriorDepends(record.Depends)
+		}
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		value.ID = i + 1
+		tasks[i] = value
+		if record.Recur != "" {
+			recurrence, err := taskwarriorRecurrence(record.Recur)
+			if err != nil {
+				errs[i] = fmt.Errorf("поле recur: правило повтора %s не поддерживается, задача создана без повтора", record.Recur)
+			} else {
+				value.Recur = recurrence.String()
+			}
+		}
+		if record.UUID != "" {
+			uuids[record.UUID] = value.ID
+		}
+		if record.Parent != "" && value.Recur != "" {
+			if _, ok := series[record.Parent]; !ok {
+				series[record.Parent] = value.ID
+			}
+			value.SeriesID = series[record.Parent]
+		}
+	}
+	for _, value := range tasks {
+		if value == nil {
+			continue
+		}
+		for _, uuid := range depends[value.ID] {
+			if id, ok := uuids[uuid]; ok && id != value.ID {
+				value.BlockedBy = append(value.BlockedBy, id)
+			}
+		}
+	}
+	return tasks, errs, nil
+}
+
+// taskwarriorToTask собирает задачу из записи Taskwarrior. Ожидающая задача с датой start считается задачей в работе,
+// удалённая задача попадает в корзину с датой end, задача в ожидании (waiting) считается ожидающей.
+func taskwarriorToTask(record taskwarriorTask) (*task.Task, error) {
+	value := &task.Task{Title: strings.TrimSpace(record.Description), Status: task.StatusPending}
+	dates := []struct {
+		name   string
+		raw    string
+		target **time.Time
+	}{
+		{"start", record.Start, &value.StartedAt},
+		{"due", record.Due, &value.Due},
+	}
+	for _, date := range dates {
+		parsed, err := parseTaskwarriorTime(date.name, date.raw)
+		if err != nil {
+			return nil, err
+		}
+		*date.target = parsed
+	}
+	entry, err := parseTaskwarriorTime("entry", record.Entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		value.CreatedAt = *entry
+	}
+	end, err := parseTaskwarriorTime("end", record.End)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := parseTaskwarriorTime("modified", record.Modified)
+	if err != nil {
+		return nil, err
+	}
+	switch record.Status {
+	case taskwarriorPending, taskwarriorWaiting, "":
+		if value.StartedAt != nil {
+			value.Status = task.StatusProgress
+		}
+	case taskwarriorCompleted:
+		value.Status = task.StatusCompleted
+		value.CompletedAt = end
+	case taskwarriorDeleted:
+		value.DeletedAt = end
+		if value.DeletedAt == nil {
+			value.DeletedAt = modified
+		}
+		if value.DeletedAt == nil {
+			value.DeletedAt = &value.CreatedAt
+		}
+	case taskwarriorRecurring:
+		return nil, errors.New("шаблон повторяющейся задачи не загружается, загружаются его экземпляры")
+	default:
+		return nil, fmt.Errorf("поле status: ошибка валидации (%w): %s", task.ErrInvalidStatus, record.Status)
+	}
+	if record.Priority != "" {
+		priority, ok := taskwarriorPriorities[strings.ToUpper(record.Priority)]
+		if !ok {
+			return nil, fmt.Errorf("поле priority: ошибка валидации (%w): %s", task.ErrInvalidPriority, record.Priority)
+		}
+		value.Priority = priority
+	}
+	for _, raw := range taskwarriorTags(record.Tags, record.Project) {
+		tag, err := task.NormalizeTag(raw)
+		if err != nil {
+			return nil, fmt.Errorf("поле tags: %w", err)
+		}
+		value.AddTags(tag)
+	}
+	descriptions := make([]string, 0, len(record.Annotations))
+	for _, annotation := range record.Annotations {
+		descriptions = append(descriptions, annotation.Description)
+	}
+	value.Description = strings.Join(descriptions, "\n")
+	return value, nil
+}
+
+// taskwarriorTags возвращает теги задачи Taskwarrior вместе с тегом проекта, если проект задан.
+func taskwarriorTags(tags []string, project string) []string {
+	if project == "" {
+		return tags
+	}
+	return append([]string{taskwarriorProjectTag + project}, tags...)
+}
+
+// taskwarriorDepends разбирает depends: массив UUID или строку UUID через запятую.
+func taskwarriorDepends(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, nil
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("поле depends: ожидается список UUID: %s", raw)
+	}
+	list = make([]string, 0)
+	for _, uuid := range strings.Split(value, ",") {
+		if uuid = strings.TrimSpace(uuid); uuid != "" {
+			list = append(list, uuid)
+		}
+	}
+	return list, nil
+}
+
+// parseTaskwarriorTime разбирает дату Taskwarrior из поля name, пустое значение - отсутствие даты.
+func parseTaskwarriorTime(name, raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	parsed, err := time.ParseInLocation(taskwarriorTime, raw, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("поле %s: некорректная дата: %s, ожидается 20060102T150405Z", name, raw)
+	}
+	return &parsed, nil
+}
+
+// taskwarriorRecurrence возвращает правило повтора для значения recur Taskwarrior: именованного периода
+// (weekly, biweekly, quarterly, weekdays), периода с единицей (2wk, 3mo, 1q, 2y), длительности ISO 8601 (P2W)
+// или правила в форматах task.ParseRecurrence. Возвращает ошибку ErrInvalidRecurrence, если правило не распознано.
+func taskwarriorRecurrence(value string) (task.Recurrence, error) {
+	value = strings.TrimSpace(value)
+	phrase := strings.ToLower(value)
+	if recurrence, ok := taskwarriorPeriods[phrase]; ok {
+		return recurrence, nil
+	}
+	match := taskwarriorPeriodPattern.FindStringSubmatch(value)
+	if match == nil {
+		match = taskwarriorPeriodPattern.FindStringSubmatch(phrase)
+	}
+	if match != nil {
+		count, unit := match[3], match[4]
+		if match[2] != "" {
+			count, unit = match[1], match[2]
+		}
+		if recurrence, ok := taskwarriorUnits[unit]; ok {
+			interval := 1
+			if count != "" {
+				var err error
+				interval, err = strconv.Atoi(count)
+				if err != nil || interval <= 0 {
+					return task.Recurrence{}, fmt.Errorf("ошибка валидации (%w): %s", task.ErrInvalidRecurrence, value)
+				}
+			}
+			recurrence.Interval *= interval
+			return recurrence, nil
+		}
+	}
+	return task.ParseRecurrence(value)
+}
@@ -0,0 +1,164 @@
+//go:build !production
+
+package storage
+
+import (
+	"testing"
+	"time"
+	"todo_cli/internal/task"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskwarriorCodec_RoundTrip(t *testing.T) {
+	at := func(hour int) *time.Time {
+		value := time.Date(2025, time.October, 15, hour, 30, 0, 0, time.UTC)
+		return &value
+	}
+	tasks := []*task.Task{
+		{ID: 1, Title: "Починить CI", Status: task.StatusCompleted, Priority: task.PriorityHigh,
+			CreatedAt: *at(9), CompletedAt: at(12)},
+		{ID: 2, Title: "Релиз 2.0", Description: "первая строка\nвторая", Status: task.StatusProgress,
+			Priority: task.PriorityMedium, CreatedAt: *at(9), StartedAt: at(10), Due: at(18),
+			Tags: []string{"project:home.garden", "release"}, BlockedBy: []int{1}},
+		{ID: 3, Title: "Старая задача", Status: task.StatusPending, Priority: task.PriorityLow,
+			CreatedAt: *at(8), DeletedAt: at(11)},
+		{ID: 4, Title: "Отчёт", Status: task.StatusPending, CreatedAt: *at(8), Due: at(17), Recur: "FREQ=WEEKLY;INTERVAL=2"},
+	}
+	codec := &TaskwarriorCodec{}
+
+	data, err := codec.Encode(tasks)
+	require.NoError(t, err)
+	decoded, errs, err := codec.Decode(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []error{nil, nil, nil, nil}, errs)
+	assert.Equal(t, tasks, decoded)
+	assert.Contains(t, string(data), `"recur": "2wk"`)
+	assert.Empty(t, codec.ExportWarnings(tasks))
+	assert.Regexp(t, `"uuid": "[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}"`, string(data))
+}
+
+func TestTaskwarriorCodec_Decode(t *testing.T) {
+	// формат task export старых версий: задачи через запятую, depends - строка
+	data := `{"id":1,"description":"Полить цветы","entry":"20251015T093000Z","modified":"20251015T093000Z",` +
+		`"status":"waiting","uuid":"a1","project":"Home.Garden","tags":["Дача"],"priority":"L",` +
+		`"depends":"b2,unknown","urgency":1.2},
+{"id":0,"description":"Купить лейку","end":"20251016T100000Z","entry":"20251014T080000Z","status":"completed","uuid":"b2",` +
+		`"annotations":[{"entry":"20251014T080000Z","description":"зелёную"},{"entry":"20251014T090000Z","description":"на 10 л"}]},
+{"description":"Отчёт","status":"recurring","uuid":"c3","recur":"weekly"},
+{"description":"Отчёт","status":"pending","uuid":"d4","recur":"weekly","parent":"c3","due":"20251020T000000Z"},
+{"description":"Отчёт","status":"pending","uuid":"e5","recur":"weekly","parent":"c3","due":"20251027T000000Z"},
+{"description":"Мусор","status":"deleted","uuid":"f6","modified":"20251017T000000Z"},
+{"description":"Некорректная","status":"pending","due":"завтра"},
+{"description":"Срочная","status":"pending","priority":"X"},
+{"description":"Каждый час","status":"pending","recur":"1h"},
+`
+
+	tasks, errs, err := (&TaskwarriorCodec{}).Decode([]byte(data))
+	require.NoError(t, err)
+
+	require.Len(t, tasks, 9)
+	created := time.Date(2025, time.October, 15, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, &task.Task{ID: 1, Title: "Полить цветы", Status: task.StatusPending, Priority: task.PriorityLow,
+		CreatedAt: created, Tags: []string{"project:home.garden", "дача"}, BlockedBy: []int{2}}, tasks[0])
+	completed := time.Date(2025, time.October, 16, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, &task.Task{ID: 2, Title: "Купить лейку", Description: "зелёную\nна 10 л", Status: task.StatusCompleted,
+		CreatedAt: time.Date(2025, time.October, 14, 8, 0, 0, 0, time.UTC), CompletedAt: &completed}, tasks[1])
+	assert.Nil(t, tasks[2])
+	assert.Error(t, errs[2])
+	assert.Equal(t, "FREQ=WEEKLY", tasks[3].Recur)
+	assert.Equal(t, 4, tasks[3].SeriesID)
+	assert.Equal(t, 4, tasks[4].SeriesID)
+	deleted := time.Date(2025, time.October, 17, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, &deleted, tasks[5].DeletedAt)
+	assert.Nil(t, tasks[6])
+	assert.Error(t, errs[6])
+	assert.Nil(t, tasks[7])
+	assert.ErrorIs(t, errs[7], task.ErrInvalidPriority)
+	// неизвестное правило повтора - замечание, задача создаётся без повтора
+	require.NotNil(t, tasks[8])
+	assert.Empty(t, tasks[8].Recur)
+	assert.ErrorContains(t, errs[8], "1h")
+	for _, i := range []int{0, 1, 3, 4, 5} {
+		assert.NoError(t, errs[i])
+	}
+}
+
+func TestTaskwarriorCodec_ExportWarnings(t *testing.T) {
+	due := time.Date(2025, time.October, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		value         *task.Task
+		expectedRecur string
+		warning       string
+	}{
+		{"критический приоритет", &task.Task{ID: 1, Priority: task.PriorityCritical}, "", "critical выгружен как H"},
+		{"повтор без срока", &task.Task{ID: 2, Recur: "FREQ=DAILY"}, "", "нужен срок"},
+		{"по будням", &task.Task{ID: 3, Due: &due, Recur: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"}, "weekdays", ""},
+		{"день недели из срока", &task.Task{ID: 4, Due: &due, Recur: "FREQ=WEEKLY;BYDAY=MO"}, "weekly", ""},
+		{"раз в полгода", &task.Task{ID: 5, Due: &due, Recur: "FREQ=MONTHLY;INTERVAL=6"}, "6mo", ""},
+		{"несколько дней недели", &task.Task{ID: 6, Due: &due, Recur: "FREQ=WEEKLY;BYDAY=MO,TH"}, "weekly", "выгружено неточно"},
+		{"последний день месяца", &task.Task{ID: 7, Due: &due, Recur: "FREQ=MONTHLY;BYMONTHDAY=-1"}, "monthly", "выгружено неточно"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec := &TaskwarriorCodec{}
+			recur, _ := taskwarriorRecur(tt.value)
+			assert.Equal(t, tt.expectedRecur, recur)
+
+			warnings := codec.ExportWarnings([]*task.Task{tt.value})
+			if tt.warning == "" {
+				assert.Empty(t, warnings)
+				return
+			}
+			require.Len(t, warnings, 1)
+			assert.ErrorContains(t, warnings[0], tt.warning)
+		})
+	}
+}
+
+func TestTaskwarriorCodec_DecodeInvalid(t *testing.T) {
+	_, _, err := (&TaskwarriorCodec{}).Decode([]byte("description,status\nзадача,pending\n"))
+	assert.ErrorIs(t, err, ErrDeserializeJson)
+}
+
+func TestTaskwarriorRecurrence(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{"weekly", "FREQ=WEEKLY", false},
+		{"biweekly", "FREQ=WEEKLY;INTERVAL=2", false},
+		{"quarterly", "FREQ=MONTHLY;INTERVAL=3", false},
+		{"semiannual", "FREQ=MONTHLY;INTERVAL=6", false},
+		{"annual", "FREQ=YEARLY", false},
+		{"weekdays", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", false},
+		{"3d", "FREQ=DAILY;INTERVAL=3", false},
+		{"2wk", "FREQ=WEEKLY;INTERVAL=2", false},
+		{"2 weeks", "FREQ=WEEKLY;INTERVAL=2", false},
+		{"6mo", "FREQ=MONTHLY;INTERVAL=6", false},
+		{"2q", "FREQ=MONTHLY;INTERVAL=6", false},
+		{"1y", "FREQ=YEARLY", false},
+		{"P2W", "FREQ=WEEKLY;INTERVAL=2", false},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "FREQ=MONTHLY;BYMONTHDAY=-1", false},
+		{"0wk", "", true},
+		{"1h", "", true},
+		{"hourly", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			recurrence, err := taskwarriorRecurrence(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, task.ErrInvalidRecurrence)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, recurrence.String())
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"todo_cli/internal/task"
+)
+
+// метки строки todo.txt: выполненная задача, проект и контекст
+const (
+	todoTxtDone    = "x"
+	todoTxtProject = "+"
+	todoTxtContext = "@"
+)
+
+// расширения key:value, которые todo.txt сопоставляет полям задачи
+const (
+	todoTxtDue      = "due"
+	todoTxtID       = "id"
+	todoTxtParent   = "parent"
+	todoTxtBlocked  = "blocked"
+	todoTxtEstimate = "est"
+	todoTxtRecur    = "rec"
+	todoTxtSeries   = "series"
+	todoTxtStarted  = "started"
+	todoTxtDeleted  = "deleted"
+	// приоритет выполненной задачи: у строки с "x" нет места для (A)
+	todoTxtPri = "pri"
+)
+
+// буквы приоритетов todo.txt: A - самый важный, буквы после D читаются как низкий приоритет
+var todoTxtPriorities = map[task.Priority]string{
+	task.PriorityCritical: "A",
+	task.PriorityHigh:     "B",
+	task.PriorityMedium:   "C",
+	task.PriorityLow:      "D",
+}
+
+// errTodoTxtExtension - слово вида key:value не является известным расширением, оно остаётся в названии задачи
+var errTodoTxtExtension = errors.New("неизвестное расширение todo.txt")
+
+// TodoTxtCodec - файл в формате todo.txt: одна задача на строку.
+// Строка состоит из отметки выполнения "x" с датой завершения, приоритета (A)-(D), даты создания, названия,
+// проектов +project (теги задачи), контекстов @context (теги с "@") и расширений key:value:
+// due, id, parent, blocked, est, rec, series, started, deleted, pri. Даты хранятся с точностью до дня.
+// Описание, журнал времени, помодоро и история статусов в todo.txt не сохраняются.
+// Незнакомые расширения и некорректные значения остаются в названии задачи и не теряются при записи.
+type TodoTxtCodec struct{}
+
+// Encode преобразует задачи в строки todo.txt.
+func (c *TodoTxtCodec) Encode(tasks []*task.Task) ([]byte, error) {
+	var buffer bytes.Buffer
+	for _, value := range tasks {
+		buffer.WriteString(todoTxtLine(value))
+		buffer.WriteByte('\n')
+	}
+	return buffer.Bytes(), nil
+}
+
+// todoTxtLine возвращает строку todo.txt для задачи.
+func todoTxtLine(value *task.Task) string {
+	words := make([]string, 0)
+	priority := todoTxtPriorities[value.Priority]
+	completed := value.Status == task.StatusCompleted
+	switch {
+	case completed:
+		words = append(words, todoTxtDone)
+		if value.CompletedAt != nil {
+			words = append(words, value.CompletedAt.Format(time.DateOnly))
+		}
+	case priority != "":
+		words = append(words, "("+priority+")")
+	}
+	// у выполненной задачи дата создания пишется только после даты завершения, иначе её прочитают как дату завершения
+	if !value.CreatedAt.IsZero() && (!completed || value.CompletedAt != nil) {
+		words = append(words, value.CreatedAt.Format(time.DateOnly))
+	}
+	if value.Title != "" {
+		words = append(words, value.Title)
+	}
+	for _, tag := range value.Tags {
+		if strings.HasPrefix(tag, todoTxtContext) {
+			words = append(words, tag)
+		} else {
+			words = append(words, todoTxtProject+tag)
+		}
+	}
+	extension := func(key, value string) {
+		words = append(words, key+":"+value)
+	}
+	if completed && priority != "" {
+		extension(todoTxtPri, priority)
+	}
+	if value.StartedAt != nil {
+		extension(todoTxtStarted, value.StartedAt.Format(time.DateOnly))
+	}
+	if value.Due != nil {
+		extension(todoTxtDue, value.Due.Format(time.DateOnly))
+	}
+	if value.Estimate != nil {
+		extension(todoTxtEstimate, value.Estimate.String())
+	}
+	if value.Recur != "" {
+		extension(todoTxtRecur, value.Recur)
+	}
+	if value.SeriesID != 0 {
+		extension(todoTxtSeries, strconv.Itoa(value.SeriesID))
+	}
+	if value.ParentID != 0 {
+		extension(todoTxtParent, strconv.Itoa(value.ParentID))
+	}
+	if len(value.BlockedBy) > 0 {
+		blockedBy := make([]string, 0, len(value.BlockedBy))
+		for _, id := range value.BlockedBy {
+			blockedBy = append(blockedBy, strconv.Itoa(id))
+		}
+		extension(todoTxtBlocked, strings.Join(blockedBy, ","))
+	}
+	if value.DeletedAt != nil {
+		extension(todoTxtDeleted, value.DeletedAt.Format(time.DateOnly))
+	}
+	if value.ID != 0 {
+		extension(todoTxtID, strconv.Itoa(value.ID))
+	}
+	return strings.Join(words, " ")
+}
+
+// Decode разбирает строки todo.txt, пустые строки пропускаются. Любая непустая строка - задача,
+// поэтому ошибок по записям не бывает. Задачи без расширения id получают ID 0.
+func (c *TodoTxtCodec) Decode(data []byte) ([]*task.Task, []error, error) {
+	lines := strings.Split(string(bytes.TrimPrefix(data, []byte(utf8BOM))), "\n")
+	tasks := make([]*task.Task, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		tasks = append(tasks, parseTodoTxtLine(line))
+	}
+	return tasks, make([]error, len(tasks)), nil
+}
+
+// parseTodoTxtLine собирает задачу из строки todo.txt. Задача с датой начала (started) и без отметки
+// выполнения считается задачей в работе. Если в строке нет слов названия, названием становится вся строка.
+func parseTodoTxtLine(line string) *task.Task {
+	value := &task.Task{Status: task.StatusPending}
+	words := strings.Fields(line)
+	if words[0] == todoTxtDone {
+		value.Status = task.StatusCompleted
+		words = words[1:]
+		if date, ok := todoTxtDate(words); ok {
+			value.CompletedAt = &date
+			words = words[1:]
+		}
+	} else if priority, ok := todoTxtPriority(words[0]); ok {
+		value.Priority = priority
+		words = words[1:]
+	}
+	if date, ok := todoTxtDate(words); ok {
+		value.CreatedAt = date
+		words = words[1:]
+	}
+	title := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(word) > 1 && (strings.HasPrefix(word, todoTxtProject) || strings.HasPrefix(word, todoTxtContext)) {
+			// NormalizeTag убирает только "+", поэтому контекст сохраняется тегом с "@"
+			if tag, err := task.NormalizeTag(word); err == nil {
+				value.AddTags(tag)
+				continue
+			}
+		}
+		if key, raw, ok := strings.Cut(word, ":"); ok && setTodoTxtExtension(value, key, raw) == nil {
+			continue
+		}
+		title = append(title, word)
+	}
+	value.Title = strings.Join(title, " ")
+	if value.Title == "" {
+		value.Title = line
+	}
+	if value.Status == task.StatusPending && value.StartedAt != nil {
+		value.Status = task.StatusProgress
+	}
+	return value
+}
+
+// todoTxtPriority разбирает приоритет в формате (A).
+func todoTxtPriority(word string) (task.Priority, bool) {
+	if len(word) != 3 || word[0] != '(' || word[2] != ')' || word[1] < 'A' || word[1] > 'Z' {
+		return task.PriorityNone, false
+	}
+	return todoTxtPriorityLetter(word[1:2]), true
+}
+
+// todoTxtPriorityLetter возвращает приоритет задачи для буквы todo.txt.
+func todoTxtPriorityLetter(letter string) task.Priority {
+	for priority, value := range todoTxtPriorities {
+		if value == letter {
+			return priority
+		}
+	}
+	return task.PriorityLow
+}
+
+// todoTxtDate разбирает дату YYYY-MM-DD из первого слова.
+func todoTxtDate(words []string) (time.Time, bool) {
+	if len(words) == 0 {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation(time.DateOnly, words[0], time.Local)
+	return date, err == nil
+}
+
+// setTodoTxtExtension записывает в задачу значение расширения key:value.
+// Возвращает ошибку, если расширение неизвестно или значение некорректно - тогда слово остаётся в названии,
+// а задача не меняется.
+func setTodoTxtExtension(value *task.Task, key, raw string) error {
+	switch key {
+	case todoTxtDue, todoTxtStarted, todoTxtDeleted:
+		date, ok := todoTxtDate([]string{raw})
+		if !ok {
+			return fmt.Errorf("некорректная дата: %s", raw)
+		}
+		switch key {
+		case todoTxtDue:
+			value.Due = &date
+		case todoTxtStarted:
+			value.StartedAt = &date
+		case todoTxtDeleted:
+			value.DeletedAt = &date
+		}
+	case todoTxtID, todoTxtParent, todoTxtSeries:
+		id, err := parseCsvID(raw)
+		if err != nil {
+			return err
+		}
+		switch key {
+		case todoTxtID:
+			value.ID = id
+		case todoTxtParent:
+			value.ParentID = id
+		case todoTxtSeries:
+			value.SeriesID = id
+		}
+	case todoTxtBlocked:
+		blockedBy := make([]int, 0)
+		for _, part := range strings.Split(raw, ",") {
+			id, err := parseCsvID(part)
+			if err != nil {
+				return err
+			}
+			blockedBy = append(blockedBy, id)
+		}
+		value.BlockedBy = blockedBy
+	case todoTxtEstimate:
+		estimate, err := task.ParseEstimate(raw)
+		if err != nil {
+			return err
+		}
+		value.Estimate = &estimate
+	case todoTxtRecur:
+		recurrence, err := task.ParseRecurrence(raw)
+		if err != nil {
+			return err
+		}
+		value.Recur = recurrence.String()
+	case todoTxtPri:
+		if len(raw) != 1 || raw[0] < 'A' || raw[0] > 'Z' {
+			return fmt.Errorf("некорректный приоритет: %s", raw)
+		}
+		value.Priority = todoTxtPriorityLetter(raw)
+	default:
+		return errTodoTxtExtension
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"todo_cli/internal/journal"
+	"todo_cli/internal/task"
+	"unicode/utf8"
+)
+
+var ErrUnknownBackend = errors.New("неизвестное хранилище задач")
+
+// имя файла todo.txt в директории ~/.todo, если путь не задан в настройках
+const todoTxtFileName = "todo.txt"
+
+// суффикс файла с полями задач, которых нет в формате todo.txt
+const todoTxtMetaSuffix = ".meta"
+
+// todoTxtMeta - поля задачи, которые не помещаются в строку todo.txt: описание, журнал времени, помодоро,
+// история статусов, статус незавершённой задачи и даты с точностью до секунды (в todo.txt только день).
+type todoTxtMeta struct {
+	Description   string              `json:"description,omitempty"`
+	Status        task.Status         `json:"status,omitempty"`
+	CreatedAt     *time.Time          `json:"created,omitempty"`
+	StartedAt     *time.Time          `json:"started,omitempty"`
+	CompletedAt   *time.Time          `json:"completed,omitempty"`
+	Due           *time.Time          `json:"due,omitempty"`
+	DeletedAt     *time.Time          `json:"deleted,omitempty"`
+	TimeLog       []task.Interval     `json:"time_log,omitempty"`
+	Pomodoros     []task.Pomodoro     `json:"pomodoros,omitempty"`
+	StatusHistory []task.StatusChange `json:"status_history,omitempty"`
+}
+
+// todoTxtMetaPath возвращает путь к файлу с дополнительными полями задач для файла todo.txt.
+func todoTxtMetaPath(fileName string) string {
+	return fileName + todoTxtMetaSuffix
+}
+
+// TodoTxtStorage реализует интерфейс Storage поверх одного файла в формате todo.txt (см. TodoTxtCodec),
+// например синхронизируемого с мобильными приложениями. Именованных списков у этого хранилища нет.
+// Резервная копия .bak, история операций .history и блокировка .lock хранятся рядом с файлом, как у FileStorage.
+// Поля, которых нет в формате todo.txt (описание, журнал времени, помодоро, история статусов, точное время),
+// хранятся рядом в JSON-файле .meta по ID задачи, поэтому работа через это хранилище не теряет данных.
+// Path - путь к файлу (~/ раскрывается в домашнюю директорию), по умолчанию ~/.todo/todo.txt.
+// LockTimeout задаёт время ожидания блокировки файла (см. FileStorage.Lock).
+type TodoTxtStorage struct {
+	Path        string
+	LockTimeout time.Duration
+}
+
+// path возвращает путь к файлу todo.txt: переданный явно, из настроек или по умолчанию.
+func (ts *TodoTxtStorage) path(fileName *string) (string, error) {
+	if fileName != nil {
+		return *fileName, nil
+	}
+	if ts.Path == "" {
+		todoDir, err := getTodoDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(todoDir, todoTxtFileName), nil
+	}
+	if rest, ok := strings.CutPrefix(ts.Path, "~/"); ok {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("не удалось получить домашнюю директорию: %w", err)
+		}
+		return filepath.Join(homeDir, rest), nil
+	}
+	return ts.Path, nil
+}
+
+// Save сохраняет задачи в файл todo.txt и поля, которых нет в todo.txt, в файл .meta (см. todoTxtMeta).
+// Запись атомарная, предыдущая версия файла todo.txt сохраняется в .bak.
+func (ts *TodoTxtStorage) Save(tasks []*task.Task, newFileName *string) error {
+	fileName, err := ts.path(newFileName)
+	if err != nil {
+		return err
+	}
+	data, err := (&TodoTxtCodec{}).Encode(tasks)
+	if err != nil {
+		return fmt.Errorf("ошибка при преобразовании задачи: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return fmt.Errorf("не удалось создать директорию для %s: %w", fileName, err)
+	}
+	meta := make(map[int]todoTxtMeta, len(tasks))
+	for _, value := range tasks {
+		meta[value.ID] = newTodoTxtMeta(value)
+	}
+	metaData, err := DataToJson(&meta)
+	if err != nil {
+		return err
+	}
+	// файл .meta пишется первым: при сбое между записями его поля применяются только к совпадающим задачам todo.txt
+	err = writeFileAtomic(todoTxtMetaPath(fileName), metaData, fileMode644)
+	if err != nil {
+		return err
+	}
+	err = rotateBackup(fileName, utf8.Valid)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(fileName, data, fileMode644)
+}
+
+// newTodoTxtMeta возвращает поля задачи, которые не сохраняются в строке todo.txt.
+func newTodoTxtMeta(value *task.Task) todoTxtMeta {
+	meta := todoTxtMeta{
+		Description:   value.Description,
+		StartedAt:     value.StartedAt,
+		CompletedAt:   value.CompletedAt,
+		Due:           value.Due,
+		DeletedAt:     value.DeletedAt,
+		TimeLog:       value.TimeLog,
+		Pomodoros:     value.Pomodoros,
+		StatusHistory: value.StatusHistory,
+	}
+	if value.Status != task.StatusCompleted {
+		meta.Status = value.Status
+	}
+	if !value.CreatedAt.IsZero() {
+		created := value.CreatedAt
+		meta.CreatedAt = &created
+	}
+	return meta
+}
+
+// loadTodoTxtMeta загружает дополнительные поля задач. Отсутствующий файл .meta означает, что полей нет,
+// например файл todo.txt создан другим приложением.
+func loadTodoTxtMeta(fileName string) (map[int]todoTxtMeta, error) {
+	meta := make(map[int]todoTxtMeta)
+	data, err := os.ReadFile(todoTxtMetaPath(fileName))
+	if os.IsNotExist(err) {
+		return meta, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить дополнительные поля задач: %w", err)
+	}
+	err = JsonToData(data, &meta)
+	if err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// applyTodoTxtMeta дополняет задачи из todo.txt полями из файла .meta по ID задачи.
+// Строка todo.txt, изменённая другим приложением, важнее: точное время применяется, только если день совпадает
+// с датой в строке, а статус - только если отметка выполнения "x" в строке не менялась.
+func applyTodoTxtMeta(tasks []*task.Task, meta map[int]todoTxtMeta) {
+	applied := make(map[int]bool, len(tasks))
+	for _, value := range tasks {
+		fields, ok := meta[value.ID]
+		if !ok || value.ID == 0 || applied[value.ID] {
+			continue
+		}
+		applied[value.ID] = true
+		value.Description = fields.Description
+		value.TimeLog = fields.TimeLog
+		value.Pomodoros = fields.Pomodoros
+		value.StatusHistory = fields.StatusHistory
+		if fields.Status != "" && value.Status != task.StatusCompleted {
+			value.Status = fields.Status
+		}
+		// у выполненной задачи без даты завершения дата создания в строку не пишется (см. todoTxtLine)
+		if fields.CreatedAt != nil && value.CreatedAt.IsZero() {
+			value.CreatedAt = *fields.CreatedAt
+		} else if created := preciseTodoTxtTime(&value.CreatedAt, fields.CreatedAt); created != nil {
+			value.CreatedAt = *created
+		}
+		value.StartedAt = preciseTodoTxtTime(value.StartedAt, fields.StartedAt)
+		value.CompletedAt = preciseTodoTxtTime(value.CompletedAt, fields.CompletedAt)
+		value.Due = preciseTodoTxtTime(value.Due, fields.Due)
+		value.DeletedAt = preciseTodoTxtTime(value.DeletedAt, fields.DeletedAt)
+	}
+}
+
+// preciseTodoTxtTime возвращает точное время из файла .meta, если оно приходится на день из строки todo.txt,
+// иначе - дату из строки.
+func preciseTodoTxtTime(day, precise *time.Time) *time.Time {
+	if day == nil || day.IsZero() || precise == nil || precise.Equal(*day) {
+		return day
+	}
+	if precise.Format(time.DateOnly) != day.Format(time.DateOnly) {
+		return day
+	}
+	return precise
+}
+
+// Load загружает задачи из файла todo.txt и дополняет их полями из файла .meta. Отсутствующий файл означает пустой список.
+// Строки, добавленные другими приложениями без расширения id, получают новые ID по порядку строк,
+// а задачи без даты создания считаются созданными сегодня - при следующей записи это сохранится в файле.
+func (ts *TodoTxtStorage) Load(differentFileName *string) ([]*task.Task, error) {
+	fileName, err := ts.path(differentFileName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return []*task.Task{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить задачи: %w", err)
+	}
+	tasks, _, err := (&TodoTxtCodec{}).Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось преобразовать задачи: %w", err)
+	}
+	meta, err := loadTodoTxtMeta(fileName)
+	if err != nil {
+		return nil, err
+	}
+	applyTodoTxtMeta(tasks, meta)
+	fillTodoTxtTasks(tasks, time.Now())
+	return tasks, nil
+}
+
+// fillTodoTxtTasks назначает ID задачам без ID или с повторяющимся ID и дату создания задачам без неё.
+func fillTodoTxtTasks(tasks []*task.Task, now time.Time) {
+	used := make(map[int]bool, len(tasks))
+	nextID := 1
+	for _, value := range tasks {
+		nextID = max(nextID, value.ID+1)
+	}
+	for _, value := range tasks {
+		if value.ID == 0 || used[value.ID] {
+			value.ID = nextID
+			nextID += 1
+		}
+		used[value.ID] = true
+		if value.CreatedAt.IsZero() {
+			value.CreatedAt = task.StartOfDay(now)
+		}
+	}
+}
+
+// Clear удаляет файл todo.txt и файл .meta с дополнительными полями задач.
+func (ts *TodoTxtStorage) Clear(differentFileName *string) error {
+	fileName, err := ts.path(differentFileName)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(todoTxtMetaPath(fileName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Remove(fileName)
+}
+
+// Lock захватывает блокировку файла todo.txt (см. FileStorage.Lock).
+func (ts *TodoTxtStorage) Lock(differentFileName *string) (func() error, error) {
+	fileName, err := ts.path(differentFileName)
+	if err != nil {
+		return nil, err
+	}
+	return (&FileStorage{LockTimeout: ts.LockTimeout}).Lock(&fileName)
+}
+
+// LoadHistory загружает журнал операций для файла todo.txt (см. FileStorage.LoadHistory).
+func (ts *TodoTxtStorage) LoadHistory(differentFileName *string) (*journal.History, error) {
+	fileName, err := ts.path(differentFileName)
+	if err != nil {
+		return nil, err
+	}
+	return (&FileStorage{}).LoadHistory(&fileName)
+}
+
+// SaveHistory сохраняет журнал операций рядом с файлом todo.txt (см. FileStorage.SaveHistory).
+func (ts *TodoTxtStorage) SaveHistory(history *journal.History, newFileName *string) error {
+	fileName, err := ts.path(newFileName)
+	if err != nil {
+		return err
+	}
+	return (&FileStorage{}).SaveHistory(history, &fileName)
+}
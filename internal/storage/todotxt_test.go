@@ -0,0 +1,171 @@
+//go:build !production
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"todo_cli/internal/task"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoTxtCodec_Decode(t *testing.T) {
+	date := func(month time.Month, day int) *time.Time {
+		value := time.Date(2025, month, day, 0, 0, 0, 0, time.Local)
+		return &value
+	}
+
+	tests := []struct {
+		name     string
+		line     string
+		expected *task.Task
+	}{
+		{
+			"приоритет, дата создания, проект, контекст и срок",
+			"(A) 2025-10-01 Call mom +Family @phone due:2025-10-20",
+			&task.Task{Title: "Call mom", Status: task.StatusPending, Priority: task.PriorityCritical,
+				CreatedAt: *date(time.October, 1), Tags: []string{"family", "@phone"}, Due: date(time.October, 20)},
+		},
+		{
+			"выполненная задача с датами и приоритетом в расширении",
+			"x 2025-10-05 2025-10-01 Pay rent pri:B id:4",
+			&task.Task{ID: 4, Title: "Pay rent", Status: task.StatusCompleted, Priority: task.PriorityHigh,
+				CreatedAt: *date(time.October, 1), CompletedAt: date(time.October, 5)},
+		},
+		{
+			"задача в работе со связями, оценкой и повтором",
+			"(E) Review PR started:2025-10-02 est:3pt rec:weekly series:2 parent:1 blocked:2,3",
+			&task.Task{Title: "Review PR", Status: task.StatusProgress, Priority: task.PriorityLow,
+				StartedAt: date(time.October, 2), Estimate: &task.Estimate{Points: 3}, Recur: "FREQ=WEEKLY",
+				SeriesID: 2, ParentID: 1, BlockedBy: []int{2, 3}},
+		},
+		{
+			"незнакомые расширения и некорректные значения остаются в названии",
+			"Buy milk t:2025-11-01 due:soon http://example.com",
+			&task.Task{Title: "Buy milk t:2025-11-01 due:soon http://example.com", Status: task.StatusPending},
+		},
+		{
+			"строка без слов названия",
+			"+work",
+			&task.Task{Title: "+work", Status: task.StatusPending, Tags: []string{"work"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, errs, err := (&TodoTxtCodec{}).Decode([]byte("\n" + tt.line + "\r\n\n"))
+			require.NoError(t, err)
+			assert.Equal(t, []error{nil}, errs)
+			assert.Equal(t, []*task.Task{tt.expected}, tasks)
+		})
+	}
+}
+
+func TestTodoTxtCodec_RoundTrip(t *testing.T) {
+	value := fullTask()
+	// todo.txt хранит даты с точностью до дня и не хранит описание, журнал времени, помодоро и историю статусов
+	day := func(at *time.Time) *time.Time {
+		value := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.Local)
+		return &value
+	}
+	value.Tags = append(value.Tags, "@office")
+	expected := &task.Task{
+		ID: value.ID, Title: value.Title, Status: value.Status, Priority: value.Priority,
+		CreatedAt: *day(&value.CreatedAt), StartedAt: day(value.StartedAt), CompletedAt: day(value.CompletedAt),
+		Due: day(value.Due), Estimate: value.Estimate, Tags: value.Tags, DeletedAt: day(value.DeletedAt),
+		ParentID: value.ParentID, BlockedBy: value.BlockedBy, Recur: value.Recur, SeriesID: value.SeriesID,
+	}
+	codec := &TodoTxtCodec{}
+
+	data, err := codec.Encode([]*task.Task{value})
+	require.NoError(t, err)
+	assert.Equal(t, "x 2025-10-15 2025-10-15 Перевести API на v2 +backend +api @office pri:B started:2025-10-15 "+
+		"due:2025-10-15 est:1h30m rec:FREQ=WEEKLY series:7 parent:3 blocked:4,5 deleted:2025-10-15 id:7\n", string(data))
+	decoded, _, err := codec.Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, []*task.Task{expected}, decoded)
+}
+
+func TestTodoTxtStorage_SaveLoad(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "sync", "todo.txt")
+	storage := &TodoTxtStorage{Path: fileName}
+
+	tasks, err := storage.Load(nil)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	// строки без id и с повторяющимся id дописаны другим приложением
+	require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0755))
+	require.NoError(t, os.WriteFile(fileName, []byte("first id:5\nsecond\n2025-10-01 third id:5\n"), 0644))
+	tasks, err = storage.Load(nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+	assert.Equal(t, []int{5, 6, 7}, []int{tasks[0].ID, tasks[1].ID, tasks[2].ID})
+	assert.Equal(t, task.StartOfDay(time.Now()), tasks[1].CreatedAt)
+
+	tasks[1].Priority = task.PriorityMedium
+	require.NoError(t, storage.Save(tasks, nil))
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "(C) "+time.Now().Format(time.DateOnly)+" second id:6\n")
+	backup, err := os.ReadFile(backupPath(fileName))
+	require.NoError(t, err)
+	assert.Equal(t, "first id:5\nsecond\n2025-10-01 third id:5\n", string(backup))
+
+	reloaded, err := storage.Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, tasks, reloaded)
+
+	unlock, err := storage.Lock(nil)
+	require.NoError(t, err)
+	require.NoError(t, unlock())
+	require.NoError(t, storage.Clear(nil))
+	assert.NoFileExists(t, fileName)
+}
+
+func TestTodoTxtStorage_SaveLoadAllFields(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "todo.txt")
+	storage := &TodoTxtStorage{Path: fileName}
+	at := func(hour int) *time.Time {
+		value := time.Date(2025, time.October, 16, hour, 15, 0, 0, time.UTC)
+		return &value
+	}
+	// выполненная задача со всеми полями, задача в работе с идущим учётом времени и приостановленная задача
+	tasks := []*task.Task{
+		fullTask(),
+		{ID: 8, Title: "Учёт времени", Description: "описание", Status: task.StatusProgress, CreatedAt: *at(9),
+			StartedAt: at(10), TimeLog: []task.Interval{{Start: *at(10)}},
+			Pomodoros:     []task.Pomodoro{{Start: *at(10), End: *at(11)}},
+			StatusHistory: []task.StatusChange{{Status: task.StatusPending, At: *at(9)}, {Status: task.StatusProgress, At: *at(10)}}},
+		{ID: 9, Title: "Приостановлена", Status: task.StatusPending, CreatedAt: *at(9), StartedAt: at(10)},
+	}
+
+	require.NoError(t, storage.Save(tasks, nil))
+	reloaded, err := storage.Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, tasks, reloaded)
+
+	// другое приложение отметило задачу выполненной и перенесло срок: строка todo.txt важнее файла .meta
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		if strings.HasSuffix(line, "id:8") {
+			lines[i] = "x 2025-10-17 2025-10-16 Учёт времени started:2025-10-16 due:2025-10-20 id:8"
+		}
+	}
+	require.NoError(t, os.WriteFile(fileName, []byte(strings.Join(lines, "\n")), 0644))
+	reloaded, err = storage.Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, task.StatusCompleted, reloaded[1].Status)
+	assert.Equal(t, "описание", reloaded[1].Description)
+	assert.Equal(t, "2025-10-20", reloaded[1].Due.Format(time.DateOnly))
+
+	require.NoError(t, storage.Clear(nil))
+	assert.NoFileExists(t, todoTxtMetaPath(fileName))
+}
@@ -0,0 +1,65 @@
+package task
+
+import "slices"
+
+// AddBlockers добавляет задаче блокирующие задачи, пропуская уже существующие.
+// ID хранятся по возрастанию.
+func (t *Task) AddBlockers(ids ...int) {
+	for _, id := range ids {
+		if !slices.Contains(t.BlockedBy, id) {
+			t.BlockedBy = append(t.BlockedBy, id)
+		}
+	}
+	slices.Sort(t.BlockedBy)
+}
+
+// RemoveBlockers удаляет у задачи указанные блокирующие задачи.
+func (t *Task) RemoveBlockers(ids ...int) {
+	t.BlockedBy = slices.DeleteFunc(t.BlockedBy, func(id int) bool {
+		return slices.Contains(ids, id)
+	})
+	if len(t.BlockedBy) == 0 {
+		t.BlockedBy = nil
+	}
+}
+
+// OpenBlockers возвращает невыполненные задачи, блокирующие задачу value.
+// Блокирующие задачи из корзины и отсутствующие в списке не учитываются.
+func OpenBlockers(tasks []*Task, value *Task) []*Task {
+	open := make([]*Task, 0)
+	for _, blocker := range tasks {
+		if slices.Contains(value.BlockedBy, blocker.ID) && !blocker.IsDeleted() && blocker.Status != StatusCompleted {
+			open = append(open, blocker)
+		}
+	}
+	return open
+}
+
+// DependencyPath возвращает цепочку зависимостей от задачи from до задачи to:
+// from заблокирована следующей задачей цепочки, та - следующей и так далее до to.
+// Возвращает nil, если from не зависит от to ни напрямую, ни через другие задачи.
+// Используется для проверки, не создаст ли новая зависимость цикл.
+func DependencyPath(tasks []*Task, from, to int) []int {
+	blockers := make(map[int][]int, len(tasks))
+	for _, value := range tasks {
+		blockers[value.ID] = value.BlockedBy
+	}
+	visited := make(map[int]bool)
+	var walk func(id int) []int
+	walk = func(id int) []int {
+		if id == to {
+			return []int{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		for _, next := range blockers[id] {
+			if path := walk(next); path != nil {
+				return append([]int{id}, path...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
@@ -0,0 +1,60 @@
+//go:build !production
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dependencies возвращает задачи с зависимостями: #4 ждёт #2 и #3, #3 ждёт #1.
+// #2 выполнена.
+func dependencies(t *testing.T) []*Task {
+	tasks := make([]*Task, 0, 4)
+	for id := 1; id <= 4; id++ {
+		value, err := NewTask(id, "task", "", StatusPending.String())
+		require.NoError(t, err)
+		tasks = append(tasks, value)
+	}
+	tasks[1].Status = StatusCompleted
+	tasks[2].AddBlockers(1)
+	tasks[3].AddBlockers(3, 2, 3)
+	return tasks
+}
+
+func TestBlockers(t *testing.T) {
+	tasks := dependencies(t)
+
+	assert.Equal(t, []int{2, 3}, tasks[3].BlockedBy)
+	assert.Equal(t, []int{3}, ids(OpenBlockers(tasks, tasks[3])))
+	assert.Empty(t, OpenBlockers(tasks, tasks[0]))
+
+	tasks[3].RemoveBlockers(3)
+	assert.Equal(t, []int{2}, tasks[3].BlockedBy)
+	tasks[3].RemoveBlockers(2, 5)
+	assert.Nil(t, tasks[3].BlockedBy)
+}
+
+func TestDependencyPath(t *testing.T) {
+	tasks := dependencies(t)
+
+	tests := []struct {
+		name     string
+		from     int
+		to       int
+		expected []int
+	}{
+		{"прямая зависимость", 3, 1, []int{3, 1}},
+		{"зависимость через задачу", 4, 1, []int{4, 3, 1}},
+		{"нет зависимости", 1, 4, nil},
+		{"задача сама с собой", 2, 2, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DependencyPath(tasks, tt.from, tt.to))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidDue    = errors.New("некорректный срок")
+	ErrInvalidPeriod = errors.New("некорректный период")
+)
+
+// форматы дат, которые принимаются в явном виде
+var dueLayouts = []string{"2006-01-02", "02.01.2006"}
+
+// относительный срок вида +3d, +2w, +1m
+var relativeDue = regexp.MustCompile(`^\+(\d+)([dwmy])$`)
+
+// период вида 12h, 30d, 2w
+var periodPattern = regexp.MustCompile(`^(\d+)([hdw])$`)
+
+// имена дней недели на английском (полные и сокращённые) и русском
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday, "вс": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday, "пн": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday, "вт": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday, "ср": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday, "чт": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday, "пт": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday, "сб": time.Saturday,
+}
+
+// StartOfDay возвращает полночь того же дня в часовом поясе переданного времени.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// ParseDue преобразует строку в срок выполнения задачи относительно now.
+// Срок хранится с точностью до дня (полночь), поддерживаются:
+//   - даты 2006-01-02 и 02.01.2006;
+//   - today, tomorrow, yesterday (сегодня, завтра, вчера);
+//   - дни недели mon..sun, monday..sunday, пн..вс и "next fri" - ближайший такой день после сегодняшнего;
+//   - +3d, +2w, +1m, +1y - смещение в днях, неделях, месяцах и годах;
+//   - next week, next month, next year.
+//
+// Возвращает ошибку ErrInvalidDue, если строку не удалось разобрать.
+func ParseDue(value string, now time.Time) (time.Time, error) {
+	today := StartOfDay(now)
+	phrase := strings.Join(strings.Fields(strings.ToLower(value)), " ")
+
+	for _, layout := range dueLayouts {
+		if due, err := time.ParseInLocation(layout, phrase, now.Location()); err == nil {
+			return due, nil
+		}
+	}
+
+	switch phrase {
+	case "today", "сегодня":
+		return today, nil
+	case "tomorrow", "завтра":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday", "вчера":
+		return today.AddDate(0, 0, -1), nil
+	case "next week":
+		return today.AddDate(0, 0, 7), nil
+	case "next month":
+		return today.AddDate(0, 1, 0), nil
+	case "next year":
+		return today.AddDate(1, 0, 0), nil
+	}
+
+	if weekday, ok := weekdays[strings.TrimPrefix(phrase, "next ")]; ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	if match := relativeDue.FindStringSubmatch(phrase); match != nil {
+		amount, err := strconv.Atoi(match[1])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("ошибка валидации (%w): %s", ErrInvalidDue, value)
+		}
+		switch match[2] {
+		case "d":
+			return today.AddDate(0, 0, amount), nil
+		case "w":
+			return today.AddDate(0, 0, 7*amount), nil
+		case "m":
+			return today.AddDate(0, amount, 0), nil
+		case "y":
+			return today.AddDate(amount, 0, 0), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("ошибка валидации (%w): %s", ErrInvalidDue, value)
+}
+
+// IsOverdue сообщает, просрочена ли задача на момент now.
+// Задача просрочена, если у неё есть срок, он раньше текущего дня и задача не выполнена.
+func (t *Task) IsOverdue(now time.Time) bool {
+	if t.Due == nil || t.Status == StatusCompleted {
+		return false
+	}
+	return StartOfDay(*t.Due).Before(StartOfDay(now))
+}
+
+// ParsePeriod преобразует строку вида 12h, 30d или 2w (часы, дни, недели) в длительность.
+// Возвращает ошибку ErrInvalidPeriod, если строку не удалось разобрать.
+func ParsePeriod(value string) (time.Duration, error) {
+	match := periodPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, fmt.Errorf("ошибка валидации (%w): %s", ErrInvalidPeriod, value)
+	}
+	amount, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, fmt.Errorf("ошибка валидации (%w): %s", ErrInvalidPeriod, value)
+	}
+	switch match[2] {
+	case "h":
+		return time.Duration(amount) * time.Hour, nil
+	case "d":
+		return time.Duration(amount) * 24 * time.Hour, nil
+	}
+	return time.Duration(amount) * 7 * 24 * time.Hour, nil
+}
@@ -0,0 +1,104 @@
+//go:build !production
+
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDue(t *testing.T) {
+	// среда, 15 октября 2025
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Time
+		expectedErr error
+	}{
+		{"ISO дата", "2025-12-31", date(2025, time.December, 31), nil},
+		{"дата через точку", "01.11.2025", date(2025, time.November, 1), nil},
+		{"сегодня", "today", date(2025, time.October, 15), nil},
+		{"завтра", "Tomorrow", date(2025, time.October, 16), nil},
+		{"завтра по-русски", "завтра", date(2025, time.October, 16), nil},
+		{"пятница", "fri", date(2025, time.October, 17), nil},
+		{"следующая среда - через неделю", "next wed", date(2025, time.October, 22), nil},
+		{"понедельник по-русски", "пн", date(2025, time.October, 20), nil},
+		{"через 3 дня", "+3d", date(2025, time.October, 18), nil},
+		{"через 2 недели", "+2w", date(2025, time.October, 29), nil},
+		{"через месяц", "+1m", date(2025, time.November, 15), nil},
+		{"следующий месяц", "next  month", date(2025, time.November, 15), nil},
+		{"следующая неделя", "next week", date(2025, time.October, 22), nil},
+		{"неизвестная фраза", "someday", time.Time{}, ErrInvalidDue},
+		{"некорректная дата", "2025-13-01", time.Time{}, ErrInvalidDue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDue(tt.value, now)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsOverdue(t *testing.T) {
+	now := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+	today := StartOfDay(now)
+
+	tests := []struct {
+		name     string
+		task     *Task
+		expected bool
+	}{
+		{"без срока", &Task{Status: StatusPending}, false},
+		{"срок вчера", &Task{Status: StatusPending, Due: &yesterday}, true},
+		{"срок сегодня", &Task{Status: StatusProgress, Due: &today}, false},
+		{"выполненная задача со сроком вчера", &Task{Status: StatusCompleted, Due: &yesterday}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.task.IsOverdue(now))
+		})
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Duration
+		expectedErr error
+	}{
+		{"часы", "12h", 12 * time.Hour, nil},
+		{"дни", "30d", 30 * 24 * time.Hour, nil},
+		{"недели с пробелами", " 2W ", 14 * 24 * time.Hour, nil},
+		{"без единицы", "30", 0, ErrInvalidPeriod},
+		{"месяцы не поддерживаются", "1m", 0, ErrInvalidPeriod},
+		{"пустая строка", "", 0, ErrInvalidPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParsePeriod(tt.value)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
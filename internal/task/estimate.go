@@ -0,0 +1,84 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidEstimate = errors.New("некорректная оценка")
+
+// суффикс оценки в story points
+const pointsSuffix = "pt"
+
+// Estimate - оценка трудоёмкости задачи: временем (Duration) или в story points (Points).
+// Задано ровно одно из полей. В JSON оценка хранится строкой, как её вводит пользователь: "2h", "3pt".
+type Estimate struct {
+	Duration time.Duration
+	Points   float64
+}
+
+// ParseEstimate разбирает оценку: время в формате 2h, 90m, 1h30m, 1.5h или story points в формате 3pt, 0.5pt.
+// Возвращает ошибку ErrInvalidEstimate для неположительной или нераспознанной оценки.
+func ParseEstimate(value string) (Estimate, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if number, ok := strings.CutSuffix(normalized, pointsSuffix); ok {
+		points, err := strconv.ParseFloat(number, 64)
+		if err != nil || points <= 0 {
+			return Estimate{}, fmt.Errorf("ошибка валидации (%w): %s", ErrInvalidEstimate, value)
+		}
+		return Estimate{Points: points}, nil
+	}
+	duration, err := time.ParseDuration(normalized)
+	if err != nil || duration < time.Minute {
+		return Estimate{}, fmt.Errorf("ошибка валидации (%w): ожидается время (2h, 90m, 1h30m) или story points (3pt): %s",
+			ErrInvalidEstimate, value)
+	}
+	return Estimate{Duration: duration.Round(time.Minute)}, nil
+}
+
+// IsPoints сообщает, задана ли оценка в story points.
+func (e Estimate) IsPoints() bool {
+	return e.Points > 0
+}
+
+// String возвращает оценку в формате ввода: 3pt, 2h, 1h30m, 45m.
+func (e Estimate) String() string {
+	if e.IsPoints() {
+		return strconv.FormatFloat(e.Points, 'f', -1, 64) + pointsSuffix
+	}
+	hours, minutes := int(e.Duration.Hours()), int(e.Duration.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%dm", hours, minutes)
+}
+
+// MarshalText сохраняет оценку строкой в формате ввода.
+func (e Estimate) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText разбирает оценку из строки (см. ParseEstimate).
+func (e *Estimate) UnmarshalText(data []byte) error {
+	estimate, err := ParseEstimate(string(data))
+	if err != nil {
+		return err
+	}
+	*e = estimate
+	return nil
+}
+
+// Remaining возвращает оставшуюся по оценке работу: оценку временем минус затраченное на задачу время до now.
+// Второе значение false, если у задачи нет оценки временем. Превышение оценки возвращается отрицательным.
+func (t *Task) Remaining(now time.Time) (time.Duration, bool) {
+	if t.Estimate == nil || t.Estimate.IsPoints() {
+		return 0, false
+	}
+	return t.Estimate.Duration - t.TimeSpent(now), true
+}
@@ -0,0 +1,79 @@
+//go:build !production
+
+package task
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Estimate
+		label    string
+	}{
+		{"2h", Estimate{Duration: 2 * time.Hour}, "2h"},
+		{"90m", Estimate{Duration: 90 * time.Minute}, "1h30m"},
+		{"1.5H", Estimate{Duration: 90 * time.Minute}, "1h30m"},
+		{"45m", Estimate{Duration: 45 * time.Minute}, "45m"},
+		{"3pt", Estimate{Points: 3}, "3pt"},
+		{" 0.5PT ", Estimate{Points: 0.5}, "0.5pt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			estimate, err := ParseEstimate(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, estimate)
+			assert.Equal(t, tt.label, estimate.String())
+		})
+	}
+
+	for _, value := range []string{"", "2", "abc", "-1h", "30s", "0pt", "pt", "2d"} {
+		t.Run("ошибка "+value, func(t *testing.T) {
+			_, err := ParseEstimate(value)
+			assert.ErrorIs(t, err, ErrInvalidEstimate)
+		})
+	}
+}
+
+func TestEstimateJSON(t *testing.T) {
+	task, err := NewTask(1, "single task", "description", StatusPending.String())
+	require.NoError(t, err)
+	task.Estimate = &Estimate{Duration: 90 * time.Minute}
+
+	data, err := json.Marshal(task)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"estimate":"1h30m"`)
+
+	var loaded Task
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	assert.Equal(t, task.Estimate, loaded.Estimate)
+	assert.Error(t, json.Unmarshal([]byte(`{"estimate":"soon"}`), &loaded))
+}
+
+func TestRemaining(t *testing.T) {
+	start := time.Date(2025, time.October, 15, 10, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	task := &Task{TimeLog: []Interval{{Start: start, End: &end}}}
+
+	_, ok := task.Remaining(end)
+	assert.False(t, ok, "нет оценки")
+	task.Estimate = &Estimate{Points: 3}
+	_, ok = task.Remaining(end)
+	assert.False(t, ok, "оценка в story points")
+
+	task.Estimate = &Estimate{Duration: 2 * time.Hour}
+	remaining, ok := task.Remaining(end)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Minute, remaining)
+
+	task.Estimate = &Estimate{Duration: time.Hour}
+	remaining, _ = task.Remaining(end)
+	assert.Equal(t, -30*time.Minute, remaining, "превышение оценки")
+}
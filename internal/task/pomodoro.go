@@ -0,0 +1,23 @@
+package task
+
+import "time"
+
+// Pomodoro - запись о помодоро по задаче: время работы и признак того, что помодоро
+// отработан полностью, а не прерван.
+type Pomodoro struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Completed bool      `json:"completed"`
+}
+
+// PomodoroCount возвращает количество полностью отработанных и прерванных помодоро по задаче.
+func (t *Task) PomodoroCount() (completed, interrupted int) {
+	for _, pomodoro := range t.Pomodoros {
+		if pomodoro.Completed {
+			completed += 1
+		} else {
+			interrupted += 1
+		}
+	}
+	return completed, interrupted
+}
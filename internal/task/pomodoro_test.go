@@ -0,0 +1,27 @@
+//go:build !production
+
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPomodoroCount(t *testing.T) {
+	start := time.Date(2025, time.October, 15, 10, 0, 0, 0, time.UTC)
+	task := &Task{Pomodoros: []Pomodoro{
+		{Start: start, End: start.Add(25 * time.Minute), Completed: true},
+		{Start: start.Add(30 * time.Minute), End: start.Add(40 * time.Minute)},
+		{Start: start.Add(45 * time.Minute), End: start.Add(70 * time.Minute), Completed: true},
+	}}
+
+	completed, interrupted := task.PomodoroCount()
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 1, interrupted)
+
+	clone := task.Clone()
+	clone.Pomodoros[0].Completed = false
+	assert.True(t, task.Pomodoros[0].Completed, "копия не делит журнал помодоро с оригиналом")
+}
@@ -0,0 +1,221 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRecurrence = errors.New("некорректное правило повтора")
+
+// Frequency - частота повтора задачи, как FREQ в iCalendar RRULE
+type Frequency string
+
+const (
+	FreqDaily   Frequency = "DAILY"
+	FreqWeekly  Frequency = "WEEKLY"
+	FreqMonthly Frequency = "MONTHLY"
+	FreqYearly  Frequency = "YEARLY"
+)
+
+// LastDayOfMonth - значение ByMonthDay для повтора в последний день месяца (BYMONTHDAY=-1)
+const LastDayOfMonth = -1
+
+// Recurrence - правило повтора задачи, подмножество iCalendar RRULE:
+// частота, интервал, дни недели (BYDAY) и число месяца (BYMONTHDAY).
+// В задаче хранится строкой RRULE (см. String и ParseRecurrence).
+type Recurrence struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay int
+}
+
+// интервал повтора вида 2weeks, 3 days, 1month
+var intervalPattern = regexp.MustCompile(`^(\d+)\s*(day|days|week|weeks|month|months|year|years)$`)
+
+// единицы интервала повтора
+var frequencies = map[string]Frequency{
+	"day": FreqDaily, "days": FreqDaily, "daily": FreqDaily,
+	"week": FreqWeekly, "weeks": FreqWeekly, "weekly": FreqWeekly,
+	"month": FreqMonthly, "months": FreqMonthly, "monthly": FreqMonthly,
+	"year": FreqYearly, "years": FreqYearly, "yearly": FreqYearly,
+}
+
+// коды дней недели в RRULE
+var rruleDays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// будние дни для правила weekday
+var workdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// ParseRecurrence разбирает правило повтора. Поддерживаются:
+//   - day, week, month, year (и daily, weekly, monthly, yearly) - каждый день, неделю, месяц, год;
+//   - 2weeks, 3days, 6months - повтор с интервалом;
+//   - weekday - по будням, mon..sun (и пн..вс) - раз в неделю в указанный день;
+//   - RRULE с ключами FREQ, INTERVAL, BYDAY и BYMONTHDAY, например FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH.
+//
+// Возвращает ошибку ErrInvalidRecurrence, если правило не удалось разобрать.
+func ParseRecurrence(value string) (Recurrence, error) {
+	phrase := strings.ToLower(strings.TrimSpace(value))
+	if freq, ok := frequencies[phrase]; ok {
+		return Recurrence{Freq: freq, Interval: 1}, nil
+	}
+	if match := intervalPattern.FindStringSubmatch(phrase); match != nil {
+		interval, err := strconv.Atoi(match[1])
+		if err != nil || interval <= 0 {
+			return Recurrence{}, fmt.Errorf("ошибка валидации (%w): %s", ErrInvalidRecurrence, value)
+		}
+		return Recurrence{Freq: frequencies[match[2]], Interval: interval}, nil
+	}
+	if phrase == "weekday" || phrase == "weekdays" {
+		return Recurrence{Freq: FreqWeekly, Interval: 1, ByDay: slices.Clone(workdays)}, nil
+	}
+	if weekday, ok := weekdays[phrase]; ok {
+		return Recurrence{Freq: FreqWeekly, Interval: 1, ByDay: []time.Weekday{weekday}}, nil
+	}
+	return parseRRule(value)
+}
+
+// parseRRule разбирает правило в формате iCalendar RRULE (с префиксом RRULE: или без).
+func parseRRule(value string) (Recurrence, error) {
+	invalid := func(reason string) (Recurrence, error) {
+		return Recurrence{}, fmt.Errorf("ошибка валидации (%w): %s: %s", ErrInvalidRecurrence, reason, value)
+	}
+	rule := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	result := Recurrence{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return invalid("ожидается КЛЮЧ=ЗНАЧЕНИЕ")
+		}
+		switch key {
+		case "FREQ":
+			result.Freq = Frequency(val)
+			if !slices.Contains([]Frequency{FreqDaily, FreqWeekly, FreqMonthly, FreqYearly}, result.Freq) {
+				return invalid("неподдерживаемая частота " + val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval <= 0 {
+				return invalid("некорректный интервал " + val)
+			}
+			result.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				index := slices.Index(rruleDays, code)
+				if index < 0 {
+					return invalid("некорректный день недели " + code)
+				}
+				if !slices.Contains(result.ByDay, time.Weekday(index)) {
+					result.ByDay = append(result.ByDay, time.Weekday(index))
+				}
+			}
+		case "BYMONTHDAY":
+			day, err := strconv.Atoi(val)
+			if err != nil || day == 0 || day < LastDayOfMonth || day > 31 {
+				return invalid("некорректное число месяца " + val)
+			}
+			result.ByMonthDay = day
+		default:
+			return invalid("неподдерживаемый ключ " + key)
+		}
+	}
+	if result.Freq == "" {
+		return invalid("не указан FREQ")
+	}
+	if len(result.ByDay) > 0 && result.Freq != FreqDaily && result.Freq != FreqWeekly {
+		return invalid("BYDAY поддерживается только для DAILY и WEEKLY")
+	}
+	if result.ByMonthDay != 0 && result.Freq != FreqMonthly {
+		return invalid("BYMONTHDAY поддерживается только для MONTHLY")
+	}
+	slices.SortFunc(result.ByDay, func(a, b time.Weekday) int { return mondayIndex(a) - mondayIndex(b) })
+	return result, nil
+}
+
+// mondayIndex возвращает номер дня в неделе, начинающейся с понедельника (0 - понедельник).
+func mondayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// String возвращает правило в формате RRULE без префикса, например FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH.
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, rruleDays[day])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.ByMonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// IsWeekdays сообщает, повторяется ли задача каждую неделю по будням.
+func (r Recurrence) IsWeekdays() bool {
+	return r.Freq == FreqWeekly && r.Interval == 1 && slices.Equal(r.ByDay, workdays)
+}
+
+// Next возвращает дату следующего повтора строго после after с точностью до дня.
+func (r Recurrence) Next(after time.Time) time.Time {
+	day := StartOfDay(after)
+	interval := max(r.Interval, 1)
+	switch r.Freq {
+	case FreqDaily:
+		next := day.AddDate(0, 0, interval)
+		// с BYDAY пропускаем дни не из списка, но не дольше года
+		for i := 0; len(r.ByDay) > 0 && !slices.Contains(r.ByDay, next.Weekday()) && i < 366; i++ {
+			next = next.AddDate(0, 0, interval)
+		}
+		return next
+	case FreqWeekly:
+		if len(r.ByDay) == 0 {
+			return day.AddDate(0, 0, 7*interval)
+		}
+		// дни из BYDAY в оставшейся части текущей недели, затем в каждой interval-й неделе
+		weekStart := day.AddDate(0, 0, -mondayIndex(day.Weekday()))
+		for next := day.AddDate(0, 0, 1); ; next = next.AddDate(0, 0, 1) {
+			week := int(next.Sub(weekStart).Hours()/24+0.5) / 7
+			if week%interval == 0 && slices.Contains(r.ByDay, next.Weekday()) {
+				return next
+			}
+		}
+	case FreqMonthly:
+		for months := 0; ; months += interval {
+			next := monthDay(day.Year(), day.Month()+time.Month(months), r.dayOfMonth(day), day.Location())
+			if next.After(day) {
+				return next
+			}
+		}
+	}
+	return monthDay(day.Year()+interval, day.Month(), day.Day(), day.Location())
+}
+
+// dayOfMonth возвращает число месяца для повтора: BYMONTHDAY или число исходной даты.
+func (r Recurrence) dayOfMonth(day time.Time) int {
+	if r.ByMonthDay != 0 {
+		return r.ByMonthDay
+	}
+	return day.Day()
+}
+
+// monthDay возвращает дату с указанным числом месяца, не выходя за последний день месяца.
+// Число LastDayOfMonth означает последний день месяца.
+func monthDay(year int, month time.Month, day int, location *time.Location) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, location)
+	last := first.AddDate(0, 1, -1).Day()
+	if day == LastDayOfMonth || day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,84 @@
+//go:build !production
+
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    string
+		expectedErr bool
+	}{
+		{"каждый день", "day", "FREQ=DAILY", false},
+		{"каждую неделю", "Weekly", "FREQ=WEEKLY", false},
+		{"раз в две недели", "2weeks", "FREQ=WEEKLY;INTERVAL=2", false},
+		{"раз в квартал", "3 months", "FREQ=MONTHLY;INTERVAL=3", false},
+		{"по будням", "weekday", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", false},
+		{"по пятницам", "fri", "FREQ=WEEKLY;BYDAY=FR", false},
+		{"RRULE", "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TH,MO", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", false},
+		{"последний день месяца", "freq=monthly;bymonthday=-1", "FREQ=MONTHLY;BYMONTHDAY=-1", false},
+		{"пустое правило", "", "", true},
+		{"нулевой интервал", "0weeks", "", true},
+		{"неизвестная частота", "FREQ=HOURLY", "", true},
+		{"неподдерживаемый ключ", "FREQ=DAILY;COUNT=3", "", true},
+		{"BYDAY для месяцев", "FREQ=MONTHLY;BYDAY=MO", "", true},
+		{"некорректное число месяца", "FREQ=MONTHLY;BYMONTHDAY=32", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.value)
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, ErrInvalidRecurrence)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule.String())
+
+			parsed, err := ParseRecurrence(rule.String())
+			require.NoError(t, err)
+			assert.Equal(t, rule, parsed)
+		})
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC) }
+	// среда, 15 октября 2025, середина дня
+	wednesday := time.Date(2025, time.October, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rule     string
+		after    time.Time
+		expected time.Time
+	}{
+		{"каждый день", "day", wednesday, date(time.October, 16)},
+		{"каждые 2 недели", "2weeks", wednesday, date(time.October, 29)},
+		{"по будням в пятницу", "weekday", date(time.October, 17), date(time.October, 20)},
+		{"по будням в среду", "weekday", wednesday, date(time.October, 16)},
+		{"по понедельникам и четвергам раз в 2 недели", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", wednesday, date(time.October, 16)},
+		{"после четверга - через неделю", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", date(time.October, 16), date(time.October, 27)},
+		{"каждый месяц", "month", wednesday, date(time.November, 15)},
+		{"31 число в коротком месяце", "month", date(time.January, 31), date(time.February, 28)},
+		{"20 число в этом месяце", "FREQ=MONTHLY;BYMONTHDAY=20", wednesday, date(time.October, 20)},
+		{"последний день месяца", "FREQ=MONTHLY;BYMONTHDAY=-1", date(time.October, 31), date(time.November, 30)},
+		{"каждый год", "year", wednesday, time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule.Next(tt.after))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package task
+
+import "time"
+
+// StatusChange - запись истории статусов: статус и время, когда задача в него перешла.
+type StatusChange struct {
+	Status Status    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+// SetStatus переводит задачу в статус и записывает переход в историю статусов.
+// Время переходов проставляется в поля задачи:
+//   - in_progress - StartedAt (если задача ещё не начиналась), CompletedAt сбрасывается;
+//   - completed - CompletedAt;
+//   - pending - задача открыта заново, StartedAt и CompletedAt сбрасываются.
+//
+// При переходе в completed или pending учёт времени по задаче останавливается (см. StopTracking).
+// Повторная установка текущего статуса ничего не меняет.
+func (t *Task) SetStatus(status Status, now time.Time) {
+	if t.Status == status {
+		return
+	}
+	switch status {
+	case StatusProgress:
+		if t.StartedAt == nil {
+			t.StartedAt = &now
+		}
+		t.CompletedAt = nil
+	case StatusCompleted:
+		t.CompletedAt = &now
+		t.StopTracking(now)
+	case StatusPending:
+		t.StartedAt = nil
+		t.CompletedAt = nil
+		t.StopTracking(now)
+	}
+	t.Status = status
+	t.StatusHistory = append(t.StatusHistory, StatusChange{Status: status, At: now})
+}
+
+// CycleTime возвращает время выполнения задачи - от начала работы до завершения.
+// Второе значение false, если задача не завершена или завершена без перевода в работу.
+func (t *Task) CycleTime() (time.Duration, bool) {
+	if t.Status != StatusCompleted || t.StartedAt == nil || t.CompletedAt == nil {
+		return 0, false
+	}
+	return t.CompletedAt.Sub(*t.StartedAt), true
+}
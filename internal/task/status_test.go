@@ -0,0 +1,71 @@
+//go:build !production
+
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetStatus(t *testing.T) {
+	start := time.Date(2025, time.October, 15, 10, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+
+	tests := []struct {
+		name              string
+		statuses          []Status
+		expectedStarted   *time.Time
+		expectedCompleted *time.Time
+		expectedHistory   int
+	}{
+		{"начало работы", []Status{StatusProgress}, &start, nil, 2},
+		{"завершение после начала", []Status{StatusProgress, StatusCompleted}, &start, ptr(at(1)), 3},
+		{"завершение без начала", []Status{StatusCompleted}, nil, &start, 2},
+		{"повтор статуса не пишется в историю", []Status{StatusProgress, StatusProgress}, &start, nil, 2},
+		{"возврат в работу сохраняет начало", []Status{StatusProgress, StatusCompleted, StatusProgress}, &start, nil, 4},
+		{"переоткрытие сбрасывает даты", []Status{StatusProgress, StatusCompleted, StatusPending}, nil, nil, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := NewTask(1, "single task", "description", StatusPending.String())
+			require.NoError(t, err)
+			for i, status := range tt.statuses {
+				task.SetStatus(status, at(i))
+			}
+
+			assert.Equal(t, tt.expectedStarted, task.StartedAt)
+			assert.Equal(t, tt.expectedCompleted, task.CompletedAt)
+			require.Len(t, task.StatusHistory, tt.expectedHistory)
+			assert.Equal(t, StatusPending, task.StatusHistory[0].Status)
+			assert.Equal(t, tt.statuses[len(tt.statuses)-1], task.StatusHistory[len(task.StatusHistory)-1].Status)
+		})
+	}
+}
+
+func TestCycleTime(t *testing.T) {
+	start := time.Date(2025, time.October, 15, 10, 0, 0, 0, time.UTC)
+
+	task, err := NewTask(1, "single task", "description", StatusPending.String())
+	require.NoError(t, err)
+	_, ok := task.CycleTime()
+	assert.False(t, ok, "задача не завершена")
+
+	task.SetStatus(StatusProgress, start)
+	task.SetStatus(StatusCompleted, start.Add(26*time.Hour))
+	cycleTime, ok := task.CycleTime()
+	assert.True(t, ok)
+	assert.Equal(t, 26*time.Hour, cycleTime)
+
+	completed, err := NewTask(2, "completed task", "description", StatusCompleted.String())
+	require.NoError(t, err)
+	_, ok = completed.CycleTime()
+	assert.False(t, ok, "задача завершена без перевода в работу")
+}
+
+func ptr(value time.Time) *time.Time {
+	return &value
+}
@@ -0,0 +1,48 @@
+package task
+
+// Children возвращает прямые подзадачи задачи parentID в порядке следования.
+// Задачи из корзины не учитываются.
+func Children(tasks []*Task, parentID int) []*Task {
+	children := make([]*Task, 0)
+	for _, value := range tasks {
+		if value.ParentID == parentID && value.ID != parentID && !value.IsDeleted() {
+			children = append(children, value)
+		}
+	}
+	return children
+}
+
+// Descendants возвращает все подзадачи задачи parentID на любой глубине:
+// каждая подзадача идёт раньше своих подзадач. Задачи из корзины и их подзадачи не учитываются.
+// Циклы в иерархии (например, в повреждённом файле) не приводят к зацикливанию.
+func Descendants(tasks []*Task, parentID int) []*Task {
+	visited := map[int]bool{parentID: true}
+	descendants := make([]*Task, 0)
+	var walk func(id int)
+	walk = func(id int) {
+		for _, child := range Children(tasks, id) {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			descendants = append(descendants, child)
+			walk(child.ID)
+		}
+	}
+	walk(parentID)
+	return descendants
+}
+
+// Progress возвращает количество выполненных задач и процент выполнения, округлённый вниз.
+// Для пустого списка процент равен 0.
+func Progress(tasks []*Task) (completed int, percent int) {
+	if len(tasks) == 0 {
+		return 0, 0
+	}
+	for _, value := range tasks {
+		if value.Status == StatusCompleted {
+			completed += 1
+		}
+	}
+	return completed, completed * 100 / len(tasks)
+}
@@ -0,0 +1,67 @@
+//go:build !production
+
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hierarchy возвращает задачи с иерархией: #1 -> #2 -> #4, #1 -> #3, #5 без родителя.
+// #3 выполнена, #6 - подзадача #1 в корзине.
+func hierarchy(t *testing.T) []*Task {
+	tasks := make([]*Task, 0, 6)
+	for id, parent := range []int{0, 1, 1, 2, 0, 1} {
+		value, err := NewTask(id+1, "task", "", StatusPending.String())
+		require.NoError(t, err)
+		value.ParentID = parent
+		tasks = append(tasks, value)
+	}
+	tasks[2].Status = StatusCompleted
+	deleted := time.Now()
+	tasks[5].DeletedAt = &deleted
+	return tasks
+}
+
+func ids(tasks []*Task) []int {
+	result := make([]int, 0, len(tasks))
+	for _, value := range tasks {
+		result = append(result, value.ID)
+	}
+	return result
+}
+
+func TestChildren(t *testing.T) {
+	tasks := hierarchy(t)
+
+	assert.Equal(t, []int{2, 3}, ids(Children(tasks, 1)))
+	assert.Equal(t, []int{4}, ids(Children(tasks, 2)))
+	assert.Empty(t, Children(tasks, 5))
+}
+
+func TestDescendants(t *testing.T) {
+	tasks := hierarchy(t)
+
+	assert.Equal(t, []int{2, 4, 3}, ids(Descendants(tasks, 1)))
+	assert.Empty(t, Descendants(tasks, 4))
+
+	// цикл #2 -> #4 -> #2 не приводит к зацикливанию
+	tasks[1].ParentID = 4
+	assert.Equal(t, []int{3}, ids(Descendants(tasks, 1)))
+	assert.Equal(t, []int{2}, ids(Descendants(tasks, 4)))
+}
+
+func TestProgress(t *testing.T) {
+	tasks := hierarchy(t)
+
+	completed, percent := Progress(Children(tasks, 1))
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, 50, percent)
+
+	completed, percent = Progress(nil)
+	assert.Equal(t, 0, completed)
+	assert.Equal(t, 0, percent)
+}
@@ -0,0 +1,88 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidTag = errors.New("некорректный тег")
+
+// префикс тега внутри заголовка задачи: "Починить логин +auth +backend"
+const tagPrefix = "+"
+
+// NormalizeTag приводит тег к единому виду: без префикса "+", в нижнем регистре.
+// Возвращает ошибку ErrInvalidTag для пустого тега или тега с пробелами.
+func NormalizeTag(value string) (string, error) {
+	tag := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(value), tagPrefix))
+	if tag == "" || strings.ContainsFunc(tag, unicode.IsSpace) {
+		return "", fmt.Errorf("ошибка валидации (%w): %q", ErrInvalidTag, value)
+	}
+	return tag, nil
+}
+
+// ParseTags разбирает список тегов, разделённых запятыми ("auth, +backend").
+// Пустые элементы пропускаются, дубликаты удаляются.
+func ParseTags(value string) ([]string, error) {
+	tags := make([]string, 0)
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		tag, err := NormalizeTag(part)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// ExtractTags вырезает из заголовка слова вида +tag и возвращает очищенный заголовок и найденные теги.
+// Одиночный "+" и слова вроде "C++" тегами не считаются.
+func ExtractTags(title string) (string, []string) {
+	words := strings.Fields(title)
+	kept := make([]string, 0, len(words))
+	tags := make([]string, 0)
+	for _, word := range words {
+		if strings.HasPrefix(word, tagPrefix) && len(word) > len(tagPrefix) && !strings.HasPrefix(word, tagPrefix+tagPrefix) {
+			tag, err := NormalizeTag(word)
+			if err == nil {
+				if !slices.Contains(tags, tag) {
+					tags = append(tags, tag)
+				}
+				continue
+			}
+		}
+		kept = append(kept, word)
+	}
+	return strings.Join(kept, " "), tags
+}
+
+// HasTag сообщает, отмечена ли задача тегом.
+func (t *Task) HasTag(tag string) bool {
+	return slices.Contains(t.Tags, tag)
+}
+
+// AddTags добавляет задаче теги, пропуская уже существующие.
+func (t *Task) AddTags(tags ...string) {
+	for _, tag := range tags {
+		if !t.HasTag(tag) {
+			t.Tags = append(t.Tags, tag)
+		}
+	}
+}
+
+// RemoveTags удаляет у задачи указанные теги.
+func (t *Task) RemoveTags(tags ...string) {
+	t.Tags = slices.DeleteFunc(t.Tags, func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+	if len(t.Tags) == 0 {
+		t.Tags = nil
+	}
+}
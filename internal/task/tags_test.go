@@ -0,0 +1,67 @@
+//go:build !production
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTags(t *testing.T) {
+	tests := []struct {
+		name          string
+		title         string
+		expectedTitle string
+		expectedTags  []string
+	}{
+		{"без тегов", "Купить продукты", "Купить продукты", []string{}},
+		{"теги в конце", "Починить логин +auth +Backend", "Починить логин", []string{"auth", "backend"}},
+		{"тег в середине", "Обновить +infra зависимости", "Обновить зависимости", []string{"infra"}},
+		{"повтор тега", "Тест +a +A", "Тест", []string{"a"}},
+		{"одиночный плюс и C++", "Выучить C++ + Go", "Выучить C++ + Go", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, tags := ExtractTags(tt.title)
+			assert.Equal(t, tt.expectedTitle, title)
+			assert.Equal(t, tt.expectedTags, tags)
+		})
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    []string
+		expectedErr error
+	}{
+		{"пустая строка", "", []string{}, nil},
+		{"несколько тегов", "auth, +Backend,,auth", []string{"auth", "backend"}, nil},
+		{"тег с пробелом", "two words", nil, ErrInvalidTag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseTags(tt.value)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestAddRemoveTags(t *testing.T) {
+	tsk := &Task{}
+	tsk.AddTags("a", "b", "a")
+	assert.Equal(t, []string{"a", "b"}, tsk.Tags)
+	tsk.RemoveTags("a")
+	assert.Equal(t, []string{"b"}, tsk.Tags)
+	tsk.RemoveTags("b")
+	assert.Nil(t, tsk.Tags)
+}
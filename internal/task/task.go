@@ -3,6 +3,8 @@ package task
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -10,11 +12,18 @@ import (
 // type-alias
 type Status string
 
+type Priority string
+
 var (
-	ErrInvalidStatus = errors.New("некорректный статус")
-	ErrInvalidID     = errors.New("некорректный ID")
-	ErrTaskNotFound  = errors.New("задача не найдена")
-	ErrTaskTitle     = errors.New("пустое название")
+	ErrInvalidStatus   = errors.New("некорректный статус")
+	ErrInvalidID       = errors.New("некорректный ID")
+	ErrTaskNotFound    = errors.New("задача не найдена")
+	ErrTaskTitle       = errors.New("пустое название")
+	ErrInvalidPriority = errors.New("некорректный приоритет")
+	ErrInvalidParent   = errors.New("некорректная родительская задача")
+	ErrHasSubtasks     = errors.New("у задачи есть подзадачи")
+	ErrBlocked         = errors.New("задача заблокирована")
+	ErrDependencyCycle = errors.New("циклическая зависимость")
 )
 
 const (
@@ -23,6 +32,15 @@ const (
 	StatusCompleted Status = "completed"
 )
 
+// пустой приоритет допустим - так загружаются задачи, созданные до появления приоритетов
+const (
+	PriorityNone     Priority = ""
+	PriorityLow      Priority = "low"
+	PriorityMedium   Priority = "medium"
+	PriorityHigh     Priority = "high"
+	PriorityCritical Priority = "critical"
+)
+
 // теги структур json или yaml задаются через тильда кавычки
 // omitempty - пропустить если нету значения
 // "-" - исключить вообще
@@ -31,8 +49,27 @@ type Task struct {
 	Title       string     `json:"title"`
 	Description string     `json:"description"`
 	Status      Status     `json:"status"`
+	Priority    Priority   `json:"priority,omitempty"`
 	CreatedAt   time.Time  `json:"created,omitempty"`
+	StartedAt   *time.Time `json:"started,omitempty"`
 	CompletedAt *time.Time `json:"completed,omitempty"`
+	Due         *time.Time `json:"due,omitempty"`
+	Estimate    *Estimate  `json:"estimate,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	DeletedAt   *time.Time `json:"deleted,omitempty"`
+	// ID родительской задачи, 0 - задача верхнего уровня
+	ParentID int `json:"parent,omitempty"`
+	// ID задач, которые нужно выполнить до начала этой задачи
+	BlockedBy []int `json:"blocked_by,omitempty"`
+	// правило повтора в формате RRULE (см. ParseRecurrence) и ID первой задачи серии повторов
+	Recur    string `json:"recur,omitempty"`
+	SeriesID int    `json:"series,omitempty"`
+	// интервалы работы над задачей, последний открыт, пока идёт учёт времени (см. StartTracking)
+	TimeLog []Interval `json:"time_log,omitempty"`
+	// помодоро по задаче, их время работы также записано в TimeLog
+	Pomodoros []Pomodoro `json:"pomodoros,omitempty"`
+	// история смены статусов, начиная со статуса при создании (см. SetStatus)
+	StatusHistory []StatusChange `json:"status_history,omitempty"`
 }
 
 // это метод - функция с получателем (receiver)
@@ -50,6 +87,85 @@ func (status Status) Valid() bool {
 	return false
 }
 
+func (priority Priority) String() string {
+	return string(priority)
+}
+
+func (priority Priority) Valid() bool {
+	switch priority {
+	case PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical:
+		return true
+	}
+	return false
+}
+
+// Rank возвращает вес приоритета для сортировки: чем важнее задача, тем больше число.
+// Задачи без приоритета получают наименьший вес.
+func (priority Priority) Rank() int {
+	switch priority {
+	case PriorityLow:
+		return 1
+	case PriorityMedium:
+		return 2
+	case PriorityHigh:
+		return 3
+	case PriorityCritical:
+		return 4
+	}
+	return 0
+}
+
+// ParsePriority преобразует строку в приоритет без учёта регистра.
+// Возвращает ошибку ErrInvalidPriority для неизвестного значения.
+func ParsePriority(value string) (Priority, error) {
+	priority := Priority(strings.ToLower(strings.TrimSpace(value)))
+	if !priority.Valid() {
+		return PriorityNone, fmt.Errorf("ошибка валидации (%w): %s", ErrInvalidPriority, value)
+	}
+	return priority, nil
+}
+
+// Clone возвращает глубокую копию задачи: изменения копии не затрагивают оригинал.
+func (t *Task) Clone() *Task {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	clone.StartedAt = cloneTime(t.StartedAt)
+	clone.CompletedAt = cloneTime(t.CompletedAt)
+	clone.Due = cloneTime(t.Due)
+	if t.Estimate != nil {
+		estimate := *t.Estimate
+		clone.Estimate = &estimate
+	}
+	clone.DeletedAt = cloneTime(t.DeletedAt)
+	clone.Tags = slices.Clone(t.Tags)
+	clone.BlockedBy = slices.Clone(t.BlockedBy)
+	clone.StatusHistory = slices.Clone(t.StatusHistory)
+	clone.Pomodoros = slices.Clone(t.Pomodoros)
+	if t.TimeLog != nil {
+		clone.TimeLog = make([]Interval, len(t.TimeLog))
+		for i, interval := range t.TimeLog {
+			clone.TimeLog[i] = Interval{Start: interval.Start, End: cloneTime(interval.End)}
+		}
+	}
+	return &clone
+}
+
+// cloneTime возвращает копию необязательной даты.
+func cloneTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+// IsDeleted сообщает, находится ли задача в корзине.
+func (t *Task) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
 // NewTask - конструктор указывается через New префикс. У конструктора нет именованных аргументов -
 // аргументы должны передаваться в том же порядке
 // %w позволяет обернуть ошибку для error.Is() проверки
@@ -61,11 +177,13 @@ func NewTask(id int, title, description, status string) (*Task, error) {
 	if utf8.RuneCountInString(title) <= 1 {
 		return nil, fmt.Errorf("ошибка в названии задачи (%w)", ErrTaskTitle)
 	}
-	return &Task{
+	now := time.Now()
+	task := &Task{
 		ID:          id,
 		Title:       title,
 		Description: description,
-		Status:      Status(status),
-		CreatedAt:   time.Now(),
-	}, nil
+		CreatedAt:   now,
+	}
+	task.SetStatus(Status(status), now)
+	return task, nil
 }
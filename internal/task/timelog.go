@@ -0,0 +1,79 @@
+package task
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrNotTracking = errors.New("учёт времени не ведётся")
+
+// Interval - отрезок работы над задачей. У открытого интервала нет конца: по задаче идёт учёт времени.
+type Interval struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// Duration возвращает длительность интервала, открытый интервал считается до now.
+func (i Interval) Duration(now time.Time) time.Duration {
+	return i.Overlap(time.Time{}, now, now)
+}
+
+// Overlap возвращает, сколько времени интервала приходится на промежуток [from, to).
+// Открытый интервал считается до now.
+func (i Interval) Overlap(from, to, now time.Time) time.Duration {
+	end := now
+	if i.End != nil {
+		end = *i.End
+	}
+	start := i.Start
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// IsTracking сообщает, идёт ли по задаче учёт времени (последний интервал открыт).
+func (t *Task) IsTracking() bool {
+	return len(t.TimeLog) > 0 && t.TimeLog[len(t.TimeLog)-1].End == nil
+}
+
+// StartTracking открывает новый интервал работы с момента now.
+// Возвращает false, если учёт времени по задаче уже идёт.
+func (t *Task) StartTracking(now time.Time) bool {
+	if t.IsTracking() {
+		return false
+	}
+	t.TimeLog = append(t.TimeLog, Interval{Start: now})
+	return true
+}
+
+// StopTracking закрывает открытый интервал работы моментом now и возвращает его длительность.
+// Второе значение false, если учёт времени по задаче не идёт.
+func (t *Task) StopTracking(now time.Time) (time.Duration, bool) {
+	if !t.IsTracking() {
+		return 0, false
+	}
+	last := &t.TimeLog[len(t.TimeLog)-1]
+	last.End = &now
+	return last.Duration(now), true
+}
+
+// TimeSpent возвращает общее время работы над задачей, открытый интервал считается до now.
+func (t *Task) TimeSpent(now time.Time) time.Duration {
+	return t.TimeSpentBetween(time.Time{}, now, now)
+}
+
+// TimeSpentBetween возвращает время работы над задачей в промежутке [from, to).
+func (t *Task) TimeSpentBetween(from, to, now time.Time) time.Duration {
+	var spent time.Duration
+	for _, interval := range t.TimeLog {
+		spent += interval.Overlap(from, to, now)
+	}
+	return spent
+}
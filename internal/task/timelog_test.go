@@ -0,0 +1,68 @@
+//go:build !production
+
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracking(t *testing.T) {
+	start := time.Date(2025, time.October, 15, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	task, err := NewTask(1, "single task", "description", StatusPending.String())
+	require.NoError(t, err)
+	_, ok := task.StopTracking(start)
+	assert.False(t, ok, "учёт времени не запущен")
+
+	assert.True(t, task.StartTracking(at(0)))
+	assert.False(t, task.StartTracking(at(10)), "учёт времени уже идёт")
+	assert.True(t, task.IsTracking())
+	assert.Equal(t, 30*time.Minute, task.TimeSpent(at(30)), "открытый интервал считается до now")
+
+	spent, ok := task.StopTracking(at(45))
+	assert.True(t, ok)
+	assert.Equal(t, 45*time.Minute, spent)
+	assert.False(t, task.IsTracking())
+
+	task.StartTracking(at(60))
+	task.SetStatus(StatusCompleted, at(80))
+	assert.False(t, task.IsTracking(), "завершение останавливает учёт времени")
+	require.Len(t, task.TimeLog, 2)
+	assert.Equal(t, 65*time.Minute, task.TimeSpent(at(200)))
+
+	clone := task.Clone()
+	*clone.TimeLog[0].End = at(100)
+	assert.Equal(t, at(45), *task.TimeLog[0].End, "копия не делит интервалы с оригиналом")
+}
+
+func TestTimeSpentBetween(t *testing.T) {
+	day := time.Date(2025, time.October, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hours float64) time.Time { return day.Add(time.Duration(hours * float64(time.Hour))) }
+	end := at(1)
+	task := &Task{TimeLog: []Interval{
+		{Start: at(-1), End: &end},
+		{Start: at(23)},
+	}}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		expected time.Duration
+	}{
+		{"интервал через полночь делится между днями", day, at(24), time.Hour + 30*time.Minute},
+		{"предыдущий день", at(-24), day, time.Hour},
+		{"открытый интервал до now", at(24), at(48), 0},
+		{"промежуток без работы", at(2), at(20), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, task.TimeSpentBetween(tt.from, tt.to, at(23.5)))
+		})
+	}
+}
@@ -2,7 +2,11 @@
 
 package testutil
 
-import "todo_cli/internal/task"
+import (
+	"time"
+	"todo_cli/internal/render"
+	"todo_cli/internal/task"
+)
 
 func EmptyTasks() []*task.Task {
 	return []*task.Task{}
@@ -22,36 +26,62 @@ func ManyTasks() ([]*task.Task, error) {
 	// 2 задачи StatusPending
 	// 1 задача StatusProgress
 	// 3 задачи StatusCompleted
+	// приоритеты: 2 high, по одной low, medium, critical и одна без приоритета
 	tasks := []*task.Task{}
 	samples := []struct {
 		id          int
 		title       string
 		description string
 		status      string
+		priority    task.Priority
 	}{
-		{1, "pending task 1", "description", task.StatusPending.String()},
-		{2, "pending task 2", "description", task.StatusPending.String()},
-		{3, "progress task", "description", task.StatusProgress.String()},
-		{4, "completed task 1", "description", task.StatusCompleted.String()},
-		{5, "completed task 2", "description", task.StatusCompleted.String()},
-		{6, "completed task 3", "description", task.StatusCompleted.String()},
+		{1, "pending task 1", "description", task.StatusPending.String(), task.PriorityLow},
+		{2, "pending task 2", "description", task.StatusPending.String(), task.PriorityHigh},
+		{3, "progress task", "description", task.StatusProgress.String(), task.PriorityCritical},
+		{4, "completed task 1", "description", task.StatusCompleted.String(), task.PriorityMedium},
+		{5, "completed task 2", "description", task.StatusCompleted.String(), task.PriorityNone},
+		{6, "completed task 3", "description", task.StatusCompleted.String(), task.PriorityHigh},
 	}
 	for _, values := range samples {
 		task, err := task.NewTask(values.id, values.title, values.description, values.status)
 		if err != nil {
 			return nil, err
 		}
+		task.Priority = values.priority
 		tasks = append(tasks, task)
 	}
 
 	return tasks, nil
 }
 
-func StatsTask(all, completed, progress, pending int) map[string]interface{} {
-	return map[string]interface{}{
-		"Всего задач:": all,
-		"Выполнено":    completed,
-		"В работе":     progress,
-		"Ожидает":      pending,
+// DueTasks возвращает первые 5 задач из ManyTasks со сроками относительно now:
+// 1 - просрочена, 2 - срок сегодня, 3 - срок завтра, 4 - без срока,
+// 5 - выполнена с истёкшим сроком (не считается просроченной).
+func DueTasks(now time.Time) ([]*task.Task, error) {
+	tasks, err := ManyTasks()
+	if err != nil {
+		return nil, err
+	}
+	day := task.StartOfDay(now)
+	dues := []*time.Time{
+		TimePtr(day.AddDate(0, 0, -2)),
+		TimePtr(day),
+		TimePtr(day.AddDate(0, 0, 1)),
+		nil,
+		TimePtr(day.AddDate(0, 0, -5)),
+	}
+	for index, due := range dues {
+		tasks[index].Due = due
+	}
+	return tasks[:len(dues)], nil
+}
+
+func StatsTask(all, completed, progress, pending, overdue int) render.StatusStats {
+	return render.StatusStats{
+		Total:      all,
+		Completed:  completed,
+		InProgress: progress,
+		Pending:    pending,
+		Overdue:    overdue,
 	}
 }
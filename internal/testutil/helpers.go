@@ -2,6 +2,8 @@
 
 package testutil
 
+import "time"
+
 func IntPtr(v int) *int {
 	return &v
 }
@@ -9,3 +11,7 @@ func IntPtr(v int) *int {
 func StrPtr(v string) *string {
 	return &v
 }
+
+func TimePtr(v time.Time) *time.Time {
+	return &v
+}